@@ -11,7 +11,9 @@ import (
 	"time"
 
 	"github.com/Beka01247/bitpanda-aml/docs"
+	"github.com/Beka01247/bitpanda-aml/internal/domain"
 	"github.com/Beka01247/bitpanda-aml/internal/env"
+	"github.com/Beka01247/bitpanda-aml/internal/infrastructure/providers"
 	"github.com/Beka01247/bitpanda-aml/internal/ratelimiter"
 	"github.com/go-chi/chi"
 	"github.com/go-chi/chi/middleware"
@@ -24,11 +26,57 @@ type application struct {
 	config      config
 	logger      *zap.SugaredLogger
 	rateLimiter ratelimiter.Limiter
-	handlers    interface {
+	// picks the bucket and limit for each request
+	rateLimitPolicy ratelimiter.Policy
+	// circuit breakers of the external providers
+	providerHealth interface {
+		Statuses() []providers.BreakerStatus
+	}
+	// resolves api keys sent by clients
+	authenticator interface {
+		Authenticate(ctx context.Context, secret string) (*domain.APIKey, error)
+	}
+	handlers interface {
 		CheckAddress(w http.ResponseWriter, r *http.Request)
 		GetCheckStatus(w http.ResponseWriter, r *http.Request)
+		CheckEvents(w http.ResponseWriter, r *http.Request)
 		GetReport(w http.ResponseWriter, r *http.Request)
 	}
+	watchlistHandlers interface {
+		CreateWatchlistEntry(w http.ResponseWriter, r *http.Request)
+		ListWatchlistEntries(w http.ResponseWriter, r *http.Request)
+		GetWatchlistEntry(w http.ResponseWriter, r *http.Request)
+		UpdateWatchlistEntry(w http.ResponseWriter, r *http.Request)
+		DeleteWatchlistEntry(w http.ResponseWriter, r *http.Request)
+	}
+	caseHandlers interface {
+		ListCases(w http.ResponseWriter, r *http.Request)
+		GetCase(w http.ResponseWriter, r *http.Request)
+		ClaimCase(w http.ResponseWriter, r *http.Request)
+		CommentCase(w http.ResponseWriter, r *http.Request)
+		EscalateCase(w http.ResponseWriter, r *http.Request)
+		ResolveCase(w http.ResponseWriter, r *http.Request)
+	}
+	batchHandlers interface {
+		CheckAddressBatch(w http.ResponseWriter, r *http.Request)
+		GetBatch(w http.ResponseWriter, r *http.Request)
+		GetBatchReport(w http.ResponseWriter, r *http.Request)
+	}
+	apiKeyHandlers interface {
+		CreateAPIKey(w http.ResponseWriter, r *http.Request)
+		ListAPIKeys(w http.ResponseWriter, r *http.Request)
+		GetAPIKey(w http.ResponseWriter, r *http.Request)
+		RotateAPIKey(w http.ResponseWriter, r *http.Request)
+		RevokeAPIKey(w http.ResponseWriter, r *http.Request)
+	}
+	auditHandlers interface {
+		ListAuditEntries(w http.ResponseWriter, r *http.Request)
+	}
+	webhookHandlers interface {
+		ListWebhookDeliveries(w http.ResponseWriter, r *http.Request)
+		GetWebhookDelivery(w http.ResponseWriter, r *http.Request)
+		RedeliverWebhook(w http.ResponseWriter, r *http.Request)
+	}
 }
 
 type objectStorageConfig struct {
@@ -40,23 +88,98 @@ type objectStorageConfig struct {
 	useSSL    bool
 }
 
+type dbConfig struct {
+	addr         string
+	maxOpenConns int
+	maxIdleConns int
+	maxIdleTime  string
+}
+
+type outboxConfig struct {
+	pollIntervalMs int
+	batchSize      int
+	leaseSeconds   int
+	retentionHours int
+}
+
+type amlConfig struct {
+	providers string
+	strategy  string
+	weights   string
+	quorum    int
+}
+
+type authConfig struct {
+	adminKey string
+}
+
+type redisConfig struct {
+	addr     string
+	password string
+	db       int
+}
+
+type providerCacheConfig struct {
+	// memory, redis or none
+	backend         string
+	cleanTTLSeconds int
+	hitTTLSeconds   int
+	maxEntries      int
+}
+
+type providerResilienceConfig struct {
+	callTimeoutMs      int
+	maxAttempts        int
+	backoffBaseMs      int
+	backoffMaxMs       int
+	breakerThreshold   int
+	breakerOpenSeconds int
+	// answers while AMLBot's breaker is open, empty fails fast
+	amlFallback string
+	// shared by the AML and sanctions lookups of a check, keep it below checkWaitSeconds
+	deadlineSeconds int
+}
+
+type webhookConfig struct {
+	secret             string
+	maxAttempts        int
+	backoffBaseSeconds int
+	backoffMaxSeconds  int
+	timeoutSeconds     int
+	pollIntervalMs     int
+}
+
 type config struct {
 	addr                 string
 	env                  string
 	apiURL               string
 	frontendURL          string
+	db                   dbConfig
 	rateLimiter          ratelimiter.Config
 	checkWaitSeconds     int
+	batchMaxItems        int
 	checkTTLHours        int
 	reportTTLHours       int
 	cleanupIntervalMins  int
 	tokenSecret          string
 	rabbitmqURL          string
+	outbox               outboxConfig
 	amlbotBaseURL        string
 	amlbotAPIKey         string
+	aml                  amlConfig
 	chainalysisAPIKey    string
+	ofacSDNPath          string
+	ofacReloadSeconds    int
+	policyPath           string
+	webhook              webhookConfig
+	auth                 authConfig
+	redis                redisConfig
+	providerCache        providerCacheConfig
+	providerResilience   providerResilienceConfig
 	objectStorageEnabled bool
 	objectStorageConfig  objectStorageConfig
+	// yaml or json list of evm chains and tokens, empty uses the built-in defaults
+	assetsPath string
 }
 
 func (app *application) mount() http.Handler {
@@ -70,20 +193,102 @@ func (app *application) mount() http.Handler {
 		AllowedOrigins:   []string{env.GetString("CORS_ALLOWED_ORIGIN", "http://localhost:5174")},
 		AllowedMethods:   []string{"GET", "POST", "PUT", "DELETE", "OPTIONS"},
 		AllowedHeaders:   []string{"Accept", "Authorization", "Content-Type", "X-CSRF-Token"},
-		ExposedHeaders:   []string{"Link"},
+		ExposedHeaders:   []string{"Link", "RateLimit-Limit", "RateLimit-Remaining", "RateLimit-Reset", "Retry-After"},
 		AllowCredentials: false,
 		MaxAge:           300,
 	}))
-	r.Use(app.RateLimiterMiddleware)
-
 	r.Use(middleware.Timeout(60 * time.Second))
 
 	r.Route("/v1", func(r chi.Router) {
-		r.Get("/health", app.healthCheckHandler)
+		r.With(app.IPRateLimiterMiddleware).Get("/health", app.healthCheckHandler)
+
+		r.Group(func(r chi.Router) {
+			// before auth so invalid keys are limited too
+			r.Use(app.IPRateLimiterMiddleware)
+			r.Use(app.AuthMiddleware)
+			// after auth so limits follow the key rather than the client ip
+			r.Use(app.RateLimiterMiddleware)
+
+			// tenant scoped, every lookup is limited to the caller's tenant
+			r.Group(func(r chi.Router) {
+				r.Use(app.RequireTenant)
+
+				r.Group(func(r chi.Router) {
+					r.Use(app.RequirePermission(domain.PermissionChecksWrite))
+
+					r.Post("/check-address", app.handlers.CheckAddress)
+					r.Post("/check-address/batch", app.batchHandlers.CheckAddressBatch)
+				})
+
+				r.Group(func(r chi.Router) {
+					r.Use(app.RequirePermission(domain.PermissionChecksRead))
+
+					r.Get("/check-address/{check_id}", app.handlers.GetCheckStatus)
+					r.Get("/check-address/{check_id}/events", app.handlers.CheckEvents)
+					r.Get("/report/{token}", app.handlers.GetReport)
+					r.Get("/batches/{batch_id}", app.batchHandlers.GetBatch)
+					r.Get("/batches/{batch_id}/report.pdf", app.batchHandlers.GetBatchReport)
+				})
+
+				r.Route("/webhooks/deliveries", func(r chi.Router) {
+					r.With(app.RequirePermission(domain.PermissionWebhooksRead)).Get("/", app.webhookHandlers.ListWebhookDeliveries)
+					r.With(app.RequirePermission(domain.PermissionWebhooksRead)).Get("/{delivery_id}", app.webhookHandlers.GetWebhookDelivery)
+					r.With(app.RequirePermission(domain.PermissionWebhooksWrite)).Post("/{delivery_id}/redeliver", app.webhookHandlers.RedeliverWebhook)
+				})
+			})
+
+			r.Route("/cases", func(r chi.Router) {
+				r.Group(func(r chi.Router) {
+					r.Use(app.RequirePermission(domain.PermissionCasesRead))
+
+					r.Get("/", app.caseHandlers.ListCases)
+					r.Get("/{case_id}", app.caseHandlers.GetCase)
+				})
+
+				r.Group(func(r chi.Router) {
+					r.Use(app.RequirePermission(domain.PermissionCasesWrite))
+
+					r.Post("/{case_id}/claim", app.caseHandlers.ClaimCase)
+					r.Post("/{case_id}/comments", app.caseHandlers.CommentCase)
+					r.Post("/{case_id}/escalate", app.caseHandlers.EscalateCase)
+					r.Post("/{case_id}/resolve", app.caseHandlers.ResolveCase)
+				})
+			})
+
+			r.Route("/admin", func(r chi.Router) {
+				r.Route("/api-keys", func(r chi.Router) {
+					r.Use(app.RequirePermission(domain.PermissionAPIKeysManage))
+
+					r.Post("/", app.apiKeyHandlers.CreateAPIKey)
+					r.Get("/", app.apiKeyHandlers.ListAPIKeys)
+					r.Get("/{key_id}", app.apiKeyHandlers.GetAPIKey)
+					r.Post("/{key_id}/rotate", app.apiKeyHandlers.RotateAPIKey)
+					r.Delete("/{key_id}", app.apiKeyHandlers.RevokeAPIKey)
+				})
+
+				r.With(app.RequirePermission(domain.PermissionAuditRead)).Get("/audit", app.auditHandlers.ListAuditEntries)
+
+				// shared by every tenant
+				r.Route("/watchlist", func(r chi.Router) {
+					r.Use(app.RequireNoTenant)
+
+					r.Group(func(r chi.Router) {
+						r.Use(app.RequirePermission(domain.PermissionWatchlistRead))
+
+						r.Get("/", app.watchlistHandlers.ListWatchlistEntries)
+						r.Get("/{entry_id}", app.watchlistHandlers.GetWatchlistEntry)
+					})
+
+					r.Group(func(r chi.Router) {
+						r.Use(app.RequirePermission(domain.PermissionWatchlistWrite))
 
-		r.Post("/check-address", app.handlers.CheckAddress)
-		r.Get("/check-address/{check_id}", app.handlers.GetCheckStatus)
-		r.Get("/report/{token}", app.handlers.GetReport)
+						r.Post("/", app.watchlistHandlers.CreateWatchlistEntry)
+						r.Put("/{entry_id}", app.watchlistHandlers.UpdateWatchlistEntry)
+						r.Delete("/{entry_id}", app.watchlistHandlers.DeleteWatchlistEntry)
+					})
+				})
+			})
+		})
 
 		docsURL := fmt.Sprintf("%s/swagger/doc.json", app.config.addr)
 		r.Get("/swagger/*", httpSwagger.Handler(httpSwagger.URL(docsURL)))
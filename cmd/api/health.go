@@ -2,21 +2,35 @@ package main
 
 import (
 	"net/http"
+
+	"github.com/Beka01247/bitpanda-aml/internal/infrastructure/providers"
 )
 
 // healthcheckHandler godoc
 //
 //	@Summary		Healthcheck
-//	@Description	Healthcheck endpoint
+//	@Description	Healthcheck endpoint, reports degraded while a provider's circuit breaker is not closed
 //	@Tags			ops
 //	@Produce		json
-//	@Success		200	{object}	string	"ok"
+//	@Success		200	{object}	map[string]any
 //	@Router			/health [get]
 func (app *application) healthCheckHandler(w http.ResponseWriter, r *http.Request) {
-	data := map[string]string{
-		"status":  "ok",
-		"env":     app.config.env,
-		"version": version,
+	status := "ok"
+	breakers := []providers.BreakerStatus{}
+	if app.providerHealth != nil {
+		breakers = app.providerHealth.Statuses()
+	}
+	for _, breaker := range breakers {
+		if breaker.State != providers.BreakerClosed {
+			status = "degraded"
+		}
+	}
+
+	data := map[string]any{
+		"status":    status,
+		"env":       app.config.env,
+		"version":   version,
+		"providers": breakers,
 	}
 
 	if err := writeJson(w, http.StatusOK, data); err != nil {
@@ -1,18 +1,157 @@
 package main
 
 import (
+	"crypto/subtle"
+	"errors"
+	"fmt"
+	"net"
 	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/Beka01247/bitpanda-aml/internal/domain"
+	httpTransport "github.com/Beka01247/bitpanda-aml/internal/transport/http"
 )
 
+// limits per api key, or per tenant when it has an override, every response carries
+// the RateLimit-* headers
 func (app *application) RateLimiterMiddleware(next http.Handler) http.Handler {
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		if app.config.rateLimiter.Enabled {
-			if allow, retryAfter := app.rateLimiter.Allow(r.RemoteAddr); !allow {
-				app.rateLimitExceededResponse(w, r, retryAfter.String())
+		var keyID, tenantID string
+		if key := httpTransport.APIKeyFromContext(r.Context()); key != nil {
+			keyID, tenantID = key.ID, key.TenantID
+		}
+
+		if app.allowRequest(w, r, keyID, tenantID) {
+			next.ServeHTTP(w, r)
+		}
+	})
+}
+
+// limits per client ip before the api key is looked up, so guessing keys costs budget too
+func (app *application) IPRateLimiterMiddleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if app.allowRequest(w, r, "", "") {
+			next.ServeHTTP(w, r)
+		}
+	})
+}
+
+// writes the 429 itself when the request is over its limit
+func (app *application) allowRequest(w http.ResponseWriter, r *http.Request, keyID, tenantID string) bool {
+	if !app.config.rateLimiter.Enabled {
+		return true
+	}
+
+	bucket, limit := app.rateLimitPolicy.Resolve(keyID, tenantID, clientIP(r))
+	result := app.rateLimiter.Allow(bucket, limit)
+
+	w.Header().Set("RateLimit-Limit", strconv.Itoa(result.Limit))
+	w.Header().Set("RateLimit-Remaining", strconv.Itoa(result.Remaining))
+	w.Header().Set("RateLimit-Reset", strconv.Itoa(ceilSeconds(result.Reset)))
+
+	if !result.Allowed {
+		app.rateLimitExceededResponse(w, r, strconv.Itoa(ceilSeconds(result.RetryAfter)))
+		return false
+	}
+	return true
+}
+
+// resolves the caller's api key, ADMIN_API_KEY authenticates as an admin without a tenant
+// so the first tenant keys can be issued
+func (app *application) AuthMiddleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		secret := apiKeyFromHeader(r)
+		if secret == "" {
+			app.unauthorizedErrorResponse(w, r, errors.New("authorization header is missing"))
+			return
+		}
+
+		var key *domain.APIKey
+		if app.isAdminKey(secret) {
+			key = &domain.APIKey{ID: "admin", Name: "ADMIN_API_KEY", Roles: []domain.Role{domain.RoleAdmin}}
+		} else {
+			var err error
+			key, err = app.authenticator.Authenticate(r.Context(), secret)
+			if err != nil {
+				if errors.Is(err, domain.ErrInvalidAPIKey) {
+					app.unauthorizedErrorResponse(w, r, err)
+					return
+				}
+				app.internalServerError(w, r, err)
 				return
 			}
 		}
 
+		next.ServeHTTP(w, r.WithContext(httpTransport.ContextWithAPIKey(r.Context(), key)))
+	})
+}
+
+// tenant scoped routes need a key that belongs to a tenant
+func (app *application) RequireTenant(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		key := httpTransport.APIKeyFromContext(r.Context())
+		if key == nil || key.TenantID == "" {
+			app.forbiddenResponse(w, r, errors.New("key is not bound to a tenant"))
+			return
+		}
+
+		next.ServeHTTP(w, r)
+	})
+}
+
+// routes that act on every tenant at once, only keys without a tenant such as ADMIN_API_KEY
+func (app *application) RequireNoTenant(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		key := httpTransport.APIKeyFromContext(r.Context())
+		if key == nil || key.TenantID != "" {
+			app.forbiddenResponse(w, r, errors.New("key is bound to a tenant"))
+			return
+		}
+
 		next.ServeHTTP(w, r)
 	})
 }
+
+func (app *application) RequirePermission(permission domain.Permission) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			key := httpTransport.APIKeyFromContext(r.Context())
+			if key == nil || !key.Can(permission) {
+				app.forbiddenResponse(w, r, fmt.Errorf("missing permission %s", permission))
+				return
+			}
+
+			next.ServeHTTP(w, r)
+		})
+	}
+}
+
+func (app *application) isAdminKey(secret string) bool {
+	adminKey := app.config.auth.adminKey
+	return adminKey != "" && subtle.ConstantTimeCompare([]byte(secret), []byte(adminKey)) == 1
+}
+
+// accepts "Authorization: Bearer <key>" as well as the bare key
+func apiKeyFromHeader(r *http.Request) string {
+	header := strings.TrimSpace(r.Header.Get("Authorization"))
+	if scheme, value, found := strings.Cut(header, " "); found && strings.EqualFold(scheme, "Bearer") {
+		return strings.TrimSpace(value)
+	}
+	return header
+}
+
+// RemoteAddr without the port, so every connection from a client shares its bucket
+func clientIP(r *http.Request) string {
+	host, _, err := net.SplitHostPort(r.RemoteAddr)
+	if err != nil {
+		// middleware.RealIP sets a bare address
+		return r.RemoteAddr
+	}
+	return host
+}
+
+func ceilSeconds(d time.Duration) int {
+	return int((d + time.Second - 1) / time.Second)
+}
@@ -0,0 +1,216 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/Beka01247/bitpanda-aml/internal/domain"
+	"github.com/Beka01247/bitpanda-aml/internal/infrastructure/cache"
+	"github.com/Beka01247/bitpanda-aml/internal/infrastructure/providers"
+	"github.com/Beka01247/bitpanda-aml/internal/infrastructure/redis"
+	"go.uber.org/zap"
+)
+
+// builds the provider result cache, nil when caching is off
+func newProviderCache(cfg config, redisClient *redis.Client, logger *zap.SugaredLogger) (domain.Cache, error) {
+	switch cfg.providerCache.backend {
+	case "none", "":
+		logger.Info("provider cache disabled")
+		return nil, nil
+	case "memory":
+		logger.Infow("using in-memory provider cache", "max_entries", cfg.providerCache.maxEntries)
+		return cache.NewMemoryCache(cfg.providerCache.maxEntries), nil
+	case "redis":
+		if redisClient == nil {
+			return nil, fmt.Errorf("redis provider cache requires REDIS_ADDR")
+		}
+		logger.Infow("using redis provider cache", "addr", cfg.redis.addr)
+		return cache.NewRedisCache(redisClient), nil
+	default:
+		return nil, fmt.Errorf("unknown provider cache: %s", cfg.providerCache.backend)
+	}
+}
+
+func providerCacheTTLs(cfg config) providers.CacheTTLs {
+	return providers.CacheTTLs{
+		Clean: time.Duration(cfg.providerCache.cleanTTLSeconds) * time.Second,
+		Hit:   time.Duration(cfg.providerCache.hitTTLSeconds) * time.Second,
+	}
+}
+
+func providerResilience(cfg config) providers.ResilienceConfig {
+	return providers.ResilienceConfig{
+		CallTimeout: time.Duration(cfg.providerResilience.callTimeoutMs) * time.Millisecond,
+		MaxAttempts: cfg.providerResilience.maxAttempts,
+		BackoffBase: time.Duration(cfg.providerResilience.backoffBaseMs) * time.Millisecond,
+		BackoffMax:  time.Duration(cfg.providerResilience.backoffMaxMs) * time.Millisecond,
+	}
+}
+
+// remote providers get retries and a circuit breaker, and are cached in front of that,
+// local ones are cheap to ask again
+func newAMLBotProvider(cfg config, deps providerDeps, fallback domain.AMLProvider) domain.AMLProvider {
+	var provider domain.AMLProvider = providers.NewAMLBotProvider(cfg.amlbotBaseURL, cfg.amlbotAPIKey, deps.logger)
+	provider = providers.NewResilientAMLProvider(provider, deps.breakers.Get(provider.Name()), providerResilience(cfg), fallback, deps.logger)
+	if deps.cache == nil {
+		return provider
+	}
+	return providers.NewCachedAMLProvider(provider, deps.cache, deps.assets, providerCacheTTLs(cfg), deps.logger)
+}
+
+// answers while AMLBot's breaker is open, nil fails those checks fast
+func newAMLFallbackProvider(cfg config, logger *zap.SugaredLogger) (domain.AMLProvider, error) {
+	switch cfg.providerResilience.amlFallback {
+	case "":
+		return nil, nil
+	case "mock":
+		logger.Warn("mock AML provider answers while AMLBot is unavailable")
+		return providers.NewMockAMLProvider(logger), nil
+	default:
+		return nil, fmt.Errorf("unknown aml fallback provider: %s", cfg.providerResilience.amlFallback)
+	}
+}
+
+// what the provider constructors share
+type providerDeps struct {
+	cache    domain.Cache
+	assets   domain.AssetRegistry
+	breakers *providers.BreakerRegistry
+	logger   *zap.SugaredLogger
+}
+
+// builds the AML provider from config, aggregating when several providers are listed
+func newAMLProvider(cfg config, deps providerDeps) (domain.AMLProvider, error) {
+	logger := deps.logger
+
+	// aggregates already carry on with the providers that answer
+	fallback, err := newAMLFallbackProvider(cfg, logger)
+	if err != nil {
+		return nil, err
+	}
+
+	names := splitList(cfg.aml.providers)
+	if len(names) == 0 {
+		if cfg.amlbotAPIKey != "" && cfg.amlbotBaseURL != "" {
+			logger.Infow("using AMLBot provider", "base_url", cfg.amlbotBaseURL)
+			return newAMLBotProvider(cfg, deps, fallback), nil
+		}
+		logger.Warn("using mock AML provider (no AMLBot credentials)")
+		return providers.NewMockAMLProvider(logger), nil
+	}
+
+	weights, err := parseWeights(cfg.aml.weights)
+	if err != nil {
+		return nil, err
+	}
+
+	weighted := make([]providers.WeightedAMLProvider, 0, len(names))
+	for _, name := range names {
+		var provider domain.AMLProvider
+		switch name {
+		case "amlbot":
+			if cfg.amlbotAPIKey == "" || cfg.amlbotBaseURL == "" {
+				return nil, fmt.Errorf("amlbot provider requires AMLBOT_BASE_URL and AMLBOT_API_KEY")
+			}
+			if len(names) == 1 {
+				provider = newAMLBotProvider(cfg, deps, fallback)
+			} else {
+				provider = newAMLBotProvider(cfg, deps, nil)
+			}
+		case "mock":
+			provider = providers.NewMockAMLProvider(logger)
+		default:
+			return nil, fmt.Errorf("unknown aml provider: %s", name)
+		}
+
+		weight, ok := weights[name]
+		if !ok {
+			weight = 1
+		}
+		weighted = append(weighted, providers.WeightedAMLProvider{Provider: provider, Weight: weight})
+	}
+
+	if len(weighted) == 1 {
+		logger.Infow("using single AML provider", "provider", weighted[0].Provider.Name())
+		return weighted[0].Provider, nil
+	}
+
+	strategy, err := providers.ParseAggregationStrategy(cfg.aml.strategy)
+	if err != nil {
+		return nil, err
+	}
+
+	aggregate := providers.NewAggregateAMLProvider(weighted, strategy, cfg.aml.quorum, logger)
+	logger.Infow("using aggregate AML provider", "provider", aggregate.Name(), "quorum", cfg.aml.quorum)
+
+	return aggregate, nil
+}
+
+// builds the sanctions provider from every configured source
+func newSanctionsProvider(ctx context.Context, cfg config, deps providerDeps) (domain.SanctionsProvider, error) {
+	logger := deps.logger
+	sources := make([]domain.SanctionsProvider, 0, 2)
+
+	if cfg.chainalysisAPIKey != "" {
+		var chainalysis domain.SanctionsProvider = providers.NewChainalysisProvider(cfg.chainalysisAPIKey, logger)
+		chainalysis = providers.NewResilientSanctionsProvider(chainalysis, deps.breakers.Get(chainalysis.Name()), providerResilience(cfg), logger)
+		if deps.cache != nil {
+			chainalysis = providers.NewCachedSanctionsProvider(chainalysis, deps.cache, providerCacheTTLs(cfg), logger)
+		}
+		sources = append(sources, chainalysis)
+		logger.Info("chainalysis provider initialized")
+	}
+
+	if cfg.ofacSDNPath != "" {
+		ofac, err := providers.NewOFACSDNProvider(cfg.ofacSDNPath, logger)
+		if err != nil {
+			return nil, err
+		}
+		ofac.StartWatchLoop(ctx, time.Duration(cfg.ofacReloadSeconds)*time.Second)
+		sources = append(sources, ofac)
+		logger.Infow("ofac sdn provider initialized", "path", cfg.ofacSDNPath)
+	}
+
+	switch len(sources) {
+	case 0:
+		logger.Warn("no sanctions source configured (CHAINALYSIS_API_KEY, OFAC_SDN_PATH), sanctions checks will return empty results")
+		return providers.NewChainalysisProvider("", logger), nil
+	case 1:
+		return sources[0], nil
+	default:
+		return providers.NewMultiSanctionsProvider(sources, logger), nil
+	}
+}
+
+func splitList(value string) []string {
+	items := make([]string, 0)
+	for _, item := range strings.Split(value, ",") {
+		item = strings.ToLower(strings.TrimSpace(item))
+		if item != "" {
+			items = append(items, item)
+		}
+	}
+	return items
+}
+
+// parses "amlbot=2,mock=1"
+func parseWeights(value string) (map[string]float64, error) {
+	weights := make(map[string]float64)
+	for _, item := range splitList(value) {
+		name, rawWeight, ok := strings.Cut(item, "=")
+		if !ok {
+			return nil, fmt.Errorf("invalid provider weight: %s", item)
+		}
+
+		weight, err := strconv.ParseFloat(strings.TrimSpace(rawWeight), 64)
+		if err != nil || weight <= 0 {
+			return nil, fmt.Errorf("invalid provider weight: %s", item)
+		}
+
+		weights[strings.TrimSpace(name)] = weight
+	}
+	return weights, nil
+}
@@ -0,0 +1,49 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"os"
+
+	"github.com/Beka01247/bitpanda-aml/internal/application"
+	"github.com/Beka01247/bitpanda-aml/internal/db"
+	"github.com/Beka01247/bitpanda-aml/internal/env"
+	"github.com/Beka01247/bitpanda-aml/internal/infrastructure/repositories"
+	"go.uber.org/zap"
+)
+
+const usage = "usage: audit verify"
+
+// checks the audit log hash chain in DB_ADDR, exits non-zero when it is broken
+func main() {
+	if len(os.Args) != 2 || os.Args[1] != "verify" {
+		fmt.Fprintln(os.Stderr, usage)
+		os.Exit(2)
+	}
+
+	logger := zap.Must(zap.NewProduction()).Sugar()
+	defer logger.Sync()
+
+	addr := env.GetString("DB_ADDR", "")
+	if addr == "" {
+		logger.Fatal("DB_ADDR is not set, only the postgres audit log can be verified")
+	}
+
+	database, err := db.New(addr, 1, 1, "1m")
+	if err != nil {
+		logger.Fatalw("failed to initialize database", "error", err)
+	}
+	defer database.Close()
+
+	auditLog := repositories.NewPostgresAuditLog(database, logger)
+	// the check repository is only used to record downloads
+	auditLogUseCase := application.NewAuditLogUseCase(auditLog, nil, logger)
+
+	result, err := auditLogUseCase.Verify(context.Background())
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "audit log verification failed: %v\n", err)
+		os.Exit(1)
+	}
+
+	fmt.Printf("audit log intact: %d entries, head %s\n", result.Entries, result.HeadHash)
+}
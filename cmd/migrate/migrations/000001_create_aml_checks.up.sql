@@ -0,0 +1,17 @@
+CREATE TABLE IF NOT EXISTS aml_checks (
+    id UUID PRIMARY KEY,
+    address VARCHAR(255) NOT NULL,
+    currency VARCHAR(16) NOT NULL,
+    status VARCHAR(32) NOT NULL,
+    risk_score INTEGER NOT NULL DEFAULT 0,
+    risk_level VARCHAR(32) NOT NULL DEFAULT '',
+    categories TEXT[] NOT NULL DEFAULT '{}',
+    sanctions JSONB,
+    report_key VARCHAR(255) NOT NULL DEFAULT '',
+    error_message TEXT NOT NULL DEFAULT '',
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    expires_at TIMESTAMP WITH TIME ZONE NOT NULL
+);
+
+CREATE INDEX IF NOT EXISTS idx_aml_checks_expires_at ON aml_checks (expires_at);
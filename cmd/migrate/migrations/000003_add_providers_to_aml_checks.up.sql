@@ -0,0 +1 @@
+ALTER TABLE aml_checks ADD COLUMN IF NOT EXISTS providers JSONB NOT NULL DEFAULT '[]';
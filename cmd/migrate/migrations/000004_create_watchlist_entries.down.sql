@@ -0,0 +1,3 @@
+ALTER TABLE aml_checks DROP COLUMN IF EXISTS watchlist;
+
+DROP TABLE IF EXISTS watchlist_entries;
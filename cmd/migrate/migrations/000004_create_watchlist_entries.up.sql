@@ -0,0 +1,15 @@
+CREATE TABLE IF NOT EXISTS watchlist_entries (
+    id UUID PRIMARY KEY,
+    address VARCHAR(255) NOT NULL,
+    chain VARCHAR(64) NOT NULL,
+    list_type VARCHAR(16) NOT NULL,
+    reason TEXT NOT NULL DEFAULT '',
+    author VARCHAR(255) NOT NULL DEFAULT '',
+    expires_at TIMESTAMP WITH TIME ZONE,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_watchlist_entries_chain_address ON watchlist_entries (chain, address);
+
+ALTER TABLE aml_checks ADD COLUMN IF NOT EXISTS watchlist JSONB;
@@ -0,0 +1,2 @@
+ALTER TABLE aml_checks DROP COLUMN IF EXISTS matched_rules;
+ALTER TABLE aml_checks DROP COLUMN IF EXISTS decision;
@@ -0,0 +1,2 @@
+ALTER TABLE aml_checks ADD COLUMN IF NOT EXISTS decision VARCHAR(16) NOT NULL DEFAULT '';
+ALTER TABLE aml_checks ADD COLUMN IF NOT EXISTS matched_rules JSONB NOT NULL DEFAULT '[]';
@@ -0,0 +1,22 @@
+CREATE TABLE IF NOT EXISTS review_cases (
+    id UUID PRIMARY KEY,
+    check_id UUID NOT NULL UNIQUE,
+    address VARCHAR(255) NOT NULL,
+    currency VARCHAR(16) NOT NULL,
+    risk_score INTEGER NOT NULL DEFAULT 0,
+    risk_level VARCHAR(16) NOT NULL DEFAULT '',
+    decision VARCHAR(16) NOT NULL DEFAULT '',
+    reasons JSONB NOT NULL DEFAULT '[]',
+    status VARCHAR(16) NOT NULL,
+    assignee VARCHAR(255) NOT NULL DEFAULT '',
+    disposition VARCHAR(16) NOT NULL DEFAULT '',
+    notes JSONB NOT NULL DEFAULT '[]',
+    history JSONB NOT NULL DEFAULT '[]',
+    version INTEGER NOT NULL DEFAULT 1,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    closed_at TIMESTAMP WITH TIME ZONE
+);
+
+CREATE INDEX IF NOT EXISTS idx_review_cases_status ON review_cases (status, created_at);
+CREATE INDEX IF NOT EXISTS idx_review_cases_assignee ON review_cases (assignee) WHERE assignee <> '';
@@ -0,0 +1,3 @@
+DROP TABLE IF EXISTS webhook_deliveries;
+
+ALTER TABLE aml_checks DROP COLUMN IF EXISTS callback_url;
@@ -0,0 +1,8 @@
+CREATE TABLE IF NOT EXISTS batches (
+    id UUID PRIMARY KEY,
+    items JSONB NOT NULL,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    expires_at TIMESTAMP WITH TIME ZONE NOT NULL
+);
+
+CREATE INDEX IF NOT EXISTS idx_batches_expires_at ON batches (expires_at);
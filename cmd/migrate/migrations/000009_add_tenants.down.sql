@@ -0,0 +1,5 @@
+ALTER TABLE webhook_deliveries DROP COLUMN IF EXISTS tenant_id;
+ALTER TABLE batches DROP COLUMN IF EXISTS tenant_id;
+ALTER TABLE aml_checks DROP COLUMN IF EXISTS tenant_id;
+
+DROP TABLE IF EXISTS api_keys;
@@ -0,0 +1,2 @@
+-- keys issued before roles were introduced keep submitting checks
+ALTER TABLE api_keys ADD COLUMN IF NOT EXISTS roles TEXT[] NOT NULL DEFAULT '{integrator}';
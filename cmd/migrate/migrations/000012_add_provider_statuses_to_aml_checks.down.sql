@@ -0,0 +1 @@
+ALTER TABLE aml_checks DROP COLUMN IF EXISTS provider_statuses;
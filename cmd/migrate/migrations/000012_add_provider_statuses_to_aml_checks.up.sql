@@ -0,0 +1 @@
+ALTER TABLE aml_checks ADD COLUMN IF NOT EXISTS provider_statuses JSONB NOT NULL DEFAULT '[]';
@@ -0,0 +1 @@
+ALTER TABLE aml_checks ADD COLUMN IF NOT EXISTS chain VARCHAR(32) NOT NULL DEFAULT '';
@@ -0,0 +1 @@
+ALTER TABLE aml_checks ADD COLUMN IF NOT EXISTS tag VARCHAR(128) NOT NULL DEFAULT '';
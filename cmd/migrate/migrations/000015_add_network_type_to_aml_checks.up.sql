@@ -0,0 +1 @@
+ALTER TABLE aml_checks ADD COLUMN IF NOT EXISTS network_type VARCHAR(16) NOT NULL DEFAULT 'mainnet';
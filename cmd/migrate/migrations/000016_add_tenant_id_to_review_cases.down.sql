@@ -0,0 +1,2 @@
+DROP INDEX IF EXISTS idx_review_cases_tenant_id;
+ALTER TABLE review_cases DROP COLUMN IF EXISTS tenant_id;
@@ -0,0 +1,8 @@
+-- cases take the tenant of their check, cases opened before this have none
+ALTER TABLE review_cases ADD COLUMN IF NOT EXISTS tenant_id VARCHAR(64) NOT NULL DEFAULT '';
+
+UPDATE review_cases SET tenant_id = aml_checks.tenant_id
+FROM aml_checks
+WHERE aml_checks.id = review_cases.check_id AND review_cases.tenant_id = '';
+
+CREATE INDEX IF NOT EXISTS idx_review_cases_tenant_id ON review_cases (tenant_id);
@@ -0,0 +1,8 @@
+-- one delivery per check and event, keep the oldest of any duplicates enqueued concurrently
+DELETE FROM webhook_deliveries AS newer
+USING webhook_deliveries AS older
+WHERE newer.check_id = older.check_id
+  AND newer.event_type = older.event_type
+  AND (newer.created_at, newer.id) > (older.created_at, older.id);
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_webhook_deliveries_check_event ON webhook_deliveries (check_id, event_type);
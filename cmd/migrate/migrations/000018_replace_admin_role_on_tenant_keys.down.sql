@@ -0,0 +1 @@
+UPDATE api_keys SET roles = array_replace(roles, 'tenant_admin', 'admin') WHERE 'tenant_admin' = ANY(roles);
@@ -0,0 +1,2 @@
+-- admin is reserved for ADMIN_API_KEY, tenant keys keep their access within the tenant
+UPDATE api_keys SET roles = array_replace(roles, 'admin', 'tenant_admin') WHERE 'admin' = ANY(roles);
@@ -0,0 +1,133 @@
+package application
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/Beka01247/bitpanda-aml/internal/domain"
+	"go.uber.org/zap"
+)
+
+// how many entries are loaded at a time while verifying the chain
+const auditVerifyPageSize = 1000
+
+type AuditVerification struct {
+	Entries int64
+	// hash of the last entry, anchors the log as of now
+	HeadHash string
+}
+
+type AuditLogUseCase struct {
+	auditLog        domain.AuditLog
+	checkRepository domain.AMLCheckRepository
+	logger          *zap.SugaredLogger
+}
+
+func NewAuditLogUseCase(
+	auditLog domain.AuditLog,
+	checkRepository domain.AMLCheckRepository,
+	logger *zap.SugaredLogger,
+) *AuditLogUseCase {
+	return &AuditLogUseCase{
+		auditLog:        auditLog,
+		checkRepository: checkRepository,
+		logger:          logger,
+	}
+}
+
+// records who downloaded a report, a download that can't be recorded is refused
+func (u *AuditLogUseCase) RecordReportDownload(ctx context.Context, actor, reportKey string) error {
+	checkID := domain.ReportCheckID(reportKey)
+
+	// the check may already be cleaned up while its report is still stored
+	address := ""
+	check, err := u.checkRepository.Get(ctx, checkID)
+	if err != nil {
+		u.logger.Warnw("failed to get check for audit entry", "check_id", checkID, "error", err)
+	} else if check != nil {
+		address = check.Address
+	}
+
+	entry := domain.NewAuditEntry(domain.AuditReportDownloaded, actor, domain.ReportTenant(reportKey), checkID, address, map[string]string{
+		"report_key": reportKey,
+	})
+	if err := u.auditLog.Append(ctx, entry); err != nil {
+		u.logger.Errorw("failed to record report download", "check_id", checkID, "error", err)
+		return fmt.Errorf("failed to record report download: %w", err)
+	}
+
+	return nil
+}
+
+// keys bound to a tenant only see that tenant's entries
+func (u *AuditLogUseCase) List(ctx context.Context, tenantID string, filter domain.AuditFilter) ([]*domain.AuditEntry, error) {
+	if tenantID != "" {
+		filter.TenantID = tenantID
+	}
+
+	entries, err := u.auditLog.List(ctx, filter)
+	if err != nil {
+		u.logger.Errorw("failed to list audit entries", "error", err)
+		return nil, fmt.Errorf("failed to list audit entries: %w", err)
+	}
+
+	return entries, nil
+}
+
+// walks the whole chain, returns an error wrapping ErrAuditChainBroken at the first bad entry
+func (u *AuditLogUseCase) Verify(ctx context.Context) (*AuditVerification, error) {
+	var (
+		prev     *domain.AuditEntry
+		verified int64
+	)
+
+	for {
+		after := int64(0)
+		if prev != nil {
+			after = prev.Sequence
+		}
+
+		entries, err := u.auditLog.ListAfter(ctx, after, auditVerifyPageSize)
+		if err != nil {
+			return nil, fmt.Errorf("failed to list audit entries: %w", err)
+		}
+
+		if err := domain.VerifyAuditChain(prev, entries); err != nil {
+			u.logger.Errorw("audit chain verification failed", "verified", verified, "error", err)
+			return nil, err
+		}
+
+		if len(entries) == 0 {
+			break
+		}
+
+		verified += int64(len(entries))
+		prev = entries[len(entries)-1]
+	}
+
+	result := &AuditVerification{Entries: verified}
+	if prev != nil {
+		result.HeadHash = prev.Hash
+	}
+
+	return result, nil
+}
+
+// pipeline steps are not failed over the audit log, a missing entry is logged loudly instead
+func recordAudit(ctx context.Context, auditLog domain.AuditLog, logger *zap.SugaredLogger, entry *domain.AuditEntry) {
+	if err := auditLog.Append(ctx, entry); err != nil {
+		logger.Errorw("failed to append audit entry",
+			"action", entry.Action,
+			"check_id", entry.CheckID,
+			"error", err)
+	}
+}
+
+func auditRuleIDs(rules []domain.MatchedRule) string {
+	names := make([]string, 0, len(rules))
+	for _, rule := range rules {
+		names = append(names, rule.ID)
+	}
+	return strings.Join(names, ",")
+}
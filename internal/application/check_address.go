@@ -3,16 +3,32 @@ package application
 import (
 	"context"
 	"fmt"
+	"strings"
 	"time"
 
 	"github.com/Beka01247/bitpanda-aml/internal/domain"
 	"go.uber.org/zap"
 )
 
+type CheckAddressInput struct {
+	TenantID string
+	// api key id of the caller, recorded in the audit log
+	Actor       string
+	Address     string
+	Currency    string
+	CallbackURL string
+	// skip cached provider results
+	BypassCache bool
+	// chain of a multi-chain token, empty picks the currency's default
+	Network string
+	// destination tag, memo or comment for chains that support one
+	Tag string
+}
+
 type CheckAddressUseCase struct {
 	assetRegistry domain.AssetRegistry
 	repository    domain.AMLCheckRepository
-	messageBus    domain.MessageBus
+	auditLog      domain.AuditLog
 	checkTTL      time.Duration
 	logger        *zap.SugaredLogger
 }
@@ -20,54 +36,84 @@ type CheckAddressUseCase struct {
 func NewCheckAddressUseCase(
 	assetRegistry domain.AssetRegistry,
 	repository domain.AMLCheckRepository,
-	messageBus domain.MessageBus,
+	auditLog domain.AuditLog,
 	checkTTL time.Duration,
 	logger *zap.SugaredLogger,
 ) *CheckAddressUseCase {
 	return &CheckAddressUseCase{
 		assetRegistry: assetRegistry,
 		repository:    repository,
-		messageBus:    messageBus,
+		auditLog:      auditLog,
 		checkTTL:      checkTTL,
 		logger:        logger,
 	}
 }
 
 // executes the check address use case
-func (u *CheckAddressUseCase) Execute(ctx context.Context, address, currency string) (string, error) {
+func (u *CheckAddressUseCase) Execute(ctx context.Context, input CheckAddressInput) (string, error) {
+	if input.CallbackURL != "" {
+		if err := domain.ValidateCallbackURL(input.CallbackURL); err != nil {
+			return "", err
+		}
+	}
+
 	// validate currency and address
-	asset, err := u.assetRegistry.Get(currency)
+	asset, err := u.assetRegistry.Resolve(input.Currency, input.Network)
 	if err != nil {
 		return "", err
 	}
 
-	normalizedAddress := asset.NormalizeAddress(address)
-	if err := asset.ValidateAddress(normalizedAddress); err != nil {
+	// checksums can depend on case, validate what the client sent
+	address := strings.TrimSpace(input.Address)
+	if err := asset.ValidateAddress(address); err != nil {
 		return "", fmt.Errorf("invalid address: %w", err)
 	}
 
+	// read before normalizing, an X-address carries its tag and normalizes to the classic form
+	tag, err := domain.ResolveTag(asset, address, input.Tag)
+	if err != nil {
+		return "", err
+	}
+	normalizedAddress := asset.NormalizeAddress(input.Address)
+
 	// create AML check
 	check := domain.NewAMLCheck(normalizedAddress, asset.Symbol(), u.checkTTL)
+	check.TenantID = input.TenantID
+	check.Chain = asset.Chain()
+	check.Tag = tag
+	check.NetworkType = asset.NetworkType()
+	check.CallbackURL = input.CallbackURL
 
-	// persist state
-	if err := u.repository.Create(ctx, check); err != nil {
+	event := domain.NewEvent(domain.EventAMLCheckRequested, &domain.AMLCheckRequestedPayload{
+		CheckID:     check.ID,
+		TenantID:    input.TenantID,
+		Address:     normalizedAddress,
+		Currency:    asset.Symbol(),
+		Chain:       asset.Chain(),
+		BypassCache: input.BypassCache,
+		Tag:         tag,
+		NetworkType: asset.NetworkType(),
+	})
+
+	// persist state and the requested event together, the outbox relay publishes it
+	if err := u.repository.CreateWithEvents(ctx, check, domain.NewOutboxMessage(domain.EventAMLCheckRequested, event)); err != nil {
 		u.logger.Errorw("failed to create check", "check_id", check.ID, "error", err)
 		return "", fmt.Errorf("failed to create check: %w", err)
 	}
 
-	// publish event
-	event := domain.NewEvent(domain.EventAMLCheckRequested, &domain.AMLCheckRequestedPayload{
-		CheckID:  check.ID,
-		Address:  normalizedAddress,
-		Currency: asset.Symbol(),
-	})
-
-	if err := u.messageBus.Publish(ctx, domain.EventAMLCheckRequested, event); err != nil {
-		u.logger.Errorw("failed to publish event", "check_id", check.ID, "error", err)
-		return "", fmt.Errorf("failed to publish event: %w", err)
+	details := map[string]string{
+		"currency": asset.Symbol(),
+		"chain":    asset.Chain(),
+	}
+	if tag != "" {
+		details["tag"] = tag
+	}
+	if asset.NetworkType().IsTest() {
+		details["network_type"] = string(asset.NetworkType())
 	}
+	recordAudit(ctx, u.auditLog, u.logger, domain.NewAuditEntry(domain.AuditCheckCreated, input.Actor, input.TenantID, check.ID, normalizedAddress, details))
 
-	u.logger.Infow("check initiated", "check_id", check.ID, "address", normalizedAddress, "currency", currency)
+	u.logger.Infow("check initiated", "check_id", check.ID, "tenant_id", input.TenantID, "address", normalizedAddress, "currency", asset.Symbol(), "chain", asset.Chain(), "tag", tag)
 
 	return check.ID, nil
 }
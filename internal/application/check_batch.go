@@ -0,0 +1,201 @@
+package application
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/Beka01247/bitpanda-aml/internal/domain"
+	"go.uber.org/zap"
+)
+
+// a batch with the current state of its checks, keyed by check ID,
+// checks that were already cleaned up are missing
+type BatchResult struct {
+	Batch  *domain.Batch
+	Checks map[string]*domain.AMLCheck
+}
+
+// number of checks that haven't finished yet
+func (r *BatchResult) Pending() int {
+	pending := 0
+	for _, checkID := range r.Batch.CheckIDs() {
+		if check := r.Checks[checkID]; check != nil && check.Status == domain.StatusProcessing {
+			pending++
+		}
+	}
+	return pending
+}
+
+type CheckBatchUseCase struct {
+	checkAddressUseCase *CheckAddressUseCase
+	checkRepository     domain.AMLCheckRepository
+	repository          domain.BatchRepository
+	batchTTL            time.Duration
+	maxItems            int
+	logger              *zap.SugaredLogger
+}
+
+func NewCheckBatchUseCase(
+	checkAddressUseCase *CheckAddressUseCase,
+	checkRepository domain.AMLCheckRepository,
+	repository domain.BatchRepository,
+	batchTTL time.Duration,
+	maxItems int,
+	logger *zap.SugaredLogger,
+) *CheckBatchUseCase {
+	return &CheckBatchUseCase{
+		checkAddressUseCase: checkAddressUseCase,
+		checkRepository:     checkRepository,
+		repository:          repository,
+		batchTTL:            batchTTL,
+		maxItems:            maxItems,
+		logger:              logger,
+	}
+}
+
+// starts one check per item, items that can't be started are recorded with their error instead
+// of failing the batch, so checks that were already started always end up in it
+func (u *CheckBatchUseCase) Execute(ctx context.Context, tenantID string, inputs []CheckAddressInput) (*domain.Batch, error) {
+	if len(inputs) == 0 {
+		return nil, fmt.Errorf("%w: at least one item is required", domain.ErrInvalidBatch)
+	}
+	if len(inputs) > u.maxItems {
+		return nil, fmt.Errorf("%w: at most %d items are allowed", domain.ErrInvalidBatch, u.maxItems)
+	}
+
+	// the callback is shared by every item, a bad one rejects the whole batch
+	for _, input := range inputs {
+		if input.CallbackURL == "" {
+			continue
+		}
+		if err := domain.ValidateCallbackURL(input.CallbackURL); err != nil {
+			return nil, fmt.Errorf("%w: %w", domain.ErrInvalidBatch, err)
+		}
+	}
+
+	items := make([]domain.BatchItem, 0, len(inputs))
+	for _, input := range inputs {
+		item := domain.BatchItem{Address: input.Address, Currency: input.Currency, Network: input.Network, Tag: input.Tag}
+		input.TenantID = tenantID
+
+		checkID, err := u.checkAddressUseCase.Execute(ctx, input)
+		switch {
+		case err == nil:
+			item.CheckID = checkID
+		case errors.Is(err, domain.ErrInvalidAddress) || errors.Is(err, domain.ErrUnsupportedCurrency) || errors.Is(err, domain.ErrInvalidTag):
+			item.Error = err.Error()
+		default:
+			u.logger.Errorw("failed to start batch check", "address", input.Address, "currency", input.Currency, "error", err)
+			item.Error = "failed to start check"
+		}
+
+		items = append(items, item)
+	}
+
+	batch := domain.NewBatch(tenantID, items, u.batchTTL)
+	if err := u.repository.Create(ctx, batch); err != nil {
+		u.logger.Errorw("failed to create batch", "batch_id", batch.ID, "check_ids", batch.CheckIDs(), "error", err)
+		return nil, fmt.Errorf("failed to create batch: %w", err)
+	}
+
+	u.logger.Infow("batch initiated", "batch_id", batch.ID, "tenant_id", tenantID, "items", len(items), "checks", len(batch.CheckIDs()))
+
+	return batch, nil
+}
+
+// other tenants' batches are reported as not found
+func (u *CheckBatchUseCase) Get(ctx context.Context, tenantID, batchID string) (*BatchResult, error) {
+	batch, err := u.repository.Get(ctx, batchID)
+	if err != nil {
+		u.logger.Errorw("failed to get batch", "batch_id", batchID, "error", err)
+		return nil, fmt.Errorf("failed to get batch: %w", err)
+	}
+
+	if batch == nil || batch.TenantID != tenantID {
+		return nil, domain.ErrBatchNotFound
+	}
+
+	if batch.IsExpired() {
+		return nil, domain.ErrBatchExpired
+	}
+
+	checks := make(map[string]*domain.AMLCheck, len(batch.Items))
+	for _, checkID := range batch.CheckIDs() {
+		check, err := u.checkRepository.Get(ctx, checkID)
+		if err != nil {
+			u.logger.Errorw("failed to get check", "batch_id", batchID, "check_id", checkID, "error", err)
+			return nil, fmt.Errorf("failed to get check: %w", err)
+		}
+		if check != nil {
+			checks[checkID] = check
+		}
+	}
+
+	return &BatchResult{Batch: batch, Checks: checks}, nil
+}
+
+// renders the consolidated PDF, only once every check has finished
+func (u *CheckBatchUseCase) Report(ctx context.Context, tenantID, batchID string) ([]byte, error) {
+	result, err := u.Get(ctx, tenantID, batchID)
+	if err != nil {
+		return nil, err
+	}
+
+	if pending := result.Pending(); pending > 0 {
+		return nil, fmt.Errorf("%w: %d checks pending", domain.ErrBatchNotReady, pending)
+	}
+
+	entries := make([]BatchReportEntry, 0, len(result.Batch.Items))
+	for _, item := range result.Batch.Items {
+		entry := BatchReportEntry{
+			Address:  item.Address,
+			Currency: item.Currency,
+			Network:  item.Network,
+			CheckID:  item.CheckID,
+			Status:   "rejected",
+			Error:    item.Error,
+		}
+
+		if item.CheckID != "" {
+			check := result.Checks[item.CheckID]
+			switch {
+			case check == nil:
+				entry.Status = "expired"
+			case check.Status == domain.StatusFailed:
+				entry.Status = "failed"
+				entry.Error = check.ErrorMessage
+			default:
+				entry.Status = "completed"
+				entry.Network = check.Chain
+				entry.Report = &ReportData{
+					CheckID:      check.ID,
+					Address:      check.Address,
+					Currency:     check.Currency,
+					Network:      check.Chain,
+					Tag:          check.Tag,
+					NetworkType:  check.NetworkType,
+					RiskScore:    check.RiskScore,
+					RiskLevel:    check.RiskLevel,
+					Categories:   check.Categories,
+					Sanctions:    check.Sanctions,
+					Providers:    check.Providers,
+					Watchlist:    check.Watchlist,
+					Decision:     check.Decision,
+					MatchedRules: check.MatchedRules,
+				}
+			}
+		}
+
+		entries = append(entries, entry)
+	}
+
+	pdfData, err := GenerateBatchPDF(result.Batch.ID, entries)
+	if err != nil {
+		u.logger.Errorw("failed to generate batch pdf", "batch_id", batchID, "error", err)
+		return nil, fmt.Errorf("failed to generate batch pdf: %w", err)
+	}
+
+	return pdfData, nil
+}
@@ -0,0 +1,217 @@
+package application
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/Beka01247/bitpanda-aml/internal/domain"
+	"go.uber.org/zap"
+)
+
+// renders the body POSTed to callbacks, the HTTP layer owns the response format
+type WebhookPayloadFunc func(check *domain.AMLCheck) ([]byte, error)
+
+type WebhookConfig struct {
+	MaxAttempts int
+	BackoffBase time.Duration
+	BackoffMax  time.Duration
+	// how long a claimed delivery stays hidden from other dispatchers
+	Lease     time.Duration
+	BatchSize int
+}
+
+type DeliverWebhooksUseCase struct {
+	checkRepository domain.AMLCheckRepository
+	repository      domain.WebhookDeliveryRepository
+	sender          domain.WebhookSender
+	payload         WebhookPayloadFunc
+	cfg             WebhookConfig
+	logger          *zap.SugaredLogger
+}
+
+func NewDeliverWebhooksUseCase(
+	checkRepository domain.AMLCheckRepository,
+	repository domain.WebhookDeliveryRepository,
+	sender domain.WebhookSender,
+	payload WebhookPayloadFunc,
+	cfg WebhookConfig,
+	logger *zap.SugaredLogger,
+) *DeliverWebhooksUseCase {
+	return &DeliverWebhooksUseCase{
+		checkRepository: checkRepository,
+		repository:      repository,
+		sender:          sender,
+		payload:         payload,
+		cfg:             cfg,
+		logger:          logger,
+	}
+}
+
+// records a delivery for the check's callback and makes the first attempt right away,
+// failureMessage is the error carried by aml.check.failed
+func (u *DeliverWebhooksUseCase) Enqueue(ctx context.Context, checkID, eventType, failureMessage string) error {
+	check, err := u.checkRepository.Get(ctx, checkID)
+	if err != nil {
+		u.logger.Errorw("failed to get check", "check_id", checkID, "error", err)
+		return fmt.Errorf("failed to get check: %w", err)
+	}
+
+	if check == nil {
+		return fmt.Errorf("check not found")
+	}
+
+	if check.CallbackURL == "" {
+		return nil
+	}
+
+	// aml.check.failed races the report worker marking the check failed,
+	// render from the event instead of waiting for it
+	if eventType == domain.EventAMLCheckFailed && check.Status != domain.StatusFailed {
+		failed := *check
+		failed.MarkFailed(failureMessage)
+		check = &failed
+	}
+
+	payload, err := u.payload(check)
+	if err != nil {
+		return fmt.Errorf("failed to render webhook payload: %w", err)
+	}
+
+	delivery := domain.NewWebhookDelivery(check.TenantID, checkID, eventType, check.CallbackURL, payload)
+	// hold it back from the dispatcher loop while we make the first attempt
+	delivery.NextAttemptAt = delivery.CreatedAt.Add(u.cfg.Lease)
+
+	// events are delivered at least once, the repository keeps one delivery per check and event
+	if err := u.repository.Create(ctx, delivery); err != nil {
+		if errors.Is(err, domain.ErrWebhookDeliveryExists) {
+			u.logger.Infow("webhook already enqueued", "check_id", checkID, "event_type", eventType)
+			return nil
+		}
+		u.logger.Errorw("failed to create webhook delivery", "check_id", checkID, "error", err)
+		return fmt.Errorf("failed to create webhook delivery: %w", err)
+	}
+
+	u.logger.Infow("webhook enqueued", "delivery_id", delivery.ID, "check_id", checkID, "event_type", eventType)
+
+	return u.attempt(ctx, delivery)
+}
+
+// retries deliveries whose backoff has elapsed, returns how many were attempted
+func (u *DeliverWebhooksUseCase) ProcessDue(ctx context.Context) (int, error) {
+	deliveries, err := u.repository.ClaimDue(ctx, time.Now().UTC(), u.cfg.BatchSize, u.cfg.Lease)
+	if err != nil {
+		return 0, fmt.Errorf("failed to claim webhook deliveries: %w", err)
+	}
+
+	for _, delivery := range deliveries {
+		if err := u.attempt(ctx, delivery); err != nil {
+			u.logger.Errorw("failed to record webhook attempt", "delivery_id", delivery.ID, "error", err)
+		}
+	}
+
+	return len(deliveries), nil
+}
+
+// other tenants' deliveries are reported as not found
+func (u *DeliverWebhooksUseCase) Get(ctx context.Context, tenantID, deliveryID string) (*domain.WebhookDelivery, error) {
+	delivery, err := u.repository.Get(ctx, deliveryID)
+	if err != nil {
+		u.logger.Errorw("failed to get webhook delivery", "delivery_id", deliveryID, "error", err)
+		return nil, fmt.Errorf("failed to get webhook delivery: %w", err)
+	}
+
+	if delivery == nil || delivery.TenantID != tenantID {
+		return nil, domain.ErrWebhookDeliveryNotFound
+	}
+
+	return delivery, nil
+}
+
+func (u *DeliverWebhooksUseCase) List(ctx context.Context, tenantID string, filter domain.WebhookDeliveryFilter) ([]*domain.WebhookDelivery, error) {
+	filter.TenantID = tenantID
+
+	deliveries, err := u.repository.List(ctx, filter)
+	if err != nil {
+		u.logger.Errorw("failed to list webhook deliveries", "error", err)
+		return nil, fmt.Errorf("failed to list webhook deliveries: %w", err)
+	}
+
+	return deliveries, nil
+}
+
+// sends the stored payload again with a fresh retry budget
+func (u *DeliverWebhooksUseCase) Redeliver(ctx context.Context, tenantID, deliveryID string) (*domain.WebhookDelivery, error) {
+	delivery, err := u.Get(ctx, tenantID, deliveryID)
+	if err != nil {
+		return nil, err
+	}
+
+	delivery.Reset()
+	delivery.NextAttemptAt = delivery.UpdatedAt.Add(u.cfg.Lease)
+
+	if err := u.repository.Update(ctx, delivery); err != nil {
+		return nil, fmt.Errorf("failed to update webhook delivery: %w", err)
+	}
+
+	u.logger.Infow("webhook redelivery requested", "delivery_id", deliveryID, "check_id", delivery.CheckID)
+
+	if err := u.attempt(ctx, delivery); err != nil {
+		return nil, err
+	}
+
+	return delivery, nil
+}
+
+func (u *DeliverWebhooksUseCase) attempt(ctx context.Context, delivery *domain.WebhookDelivery) error {
+	start := time.Now()
+	statusCode, err := u.sender.Send(ctx, delivery)
+	if err == nil {
+		delivery.MarkDelivered(statusCode)
+		u.logger.Infow("webhook delivered",
+			"delivery_id", delivery.ID,
+			"check_id", delivery.CheckID,
+			"status_code", statusCode,
+			"latency_ms", time.Since(start).Milliseconds())
+		return u.repository.Update(ctx, delivery)
+	}
+
+	var retryAt *time.Time
+	if delivery.Attempts+1 < u.cfg.MaxAttempts {
+		next := time.Now().UTC().Add(webhookBackoff(delivery.Attempts+1, u.cfg.BackoffBase, u.cfg.BackoffMax))
+		retryAt = &next
+	}
+
+	delivery.MarkAttemptFailed(statusCode, err.Error(), retryAt)
+
+	if retryAt != nil {
+		u.logger.Warnw("webhook attempt failed, will retry",
+			"delivery_id", delivery.ID,
+			"check_id", delivery.CheckID,
+			"attempt", delivery.Attempts,
+			"status_code", statusCode,
+			"retry_at", *retryAt,
+			"error", err)
+	} else {
+		u.logger.Errorw("webhook delivery failed, giving up",
+			"delivery_id", delivery.ID,
+			"check_id", delivery.CheckID,
+			"attempts", delivery.Attempts,
+			"error", err)
+	}
+
+	return u.repository.Update(ctx, delivery)
+}
+
+// base, 2*base, 4*base, ... capped at max
+func webhookBackoff(attempt int, base, max time.Duration) time.Duration {
+	delay := base
+	for i := 1; i < attempt; i++ {
+		delay *= 2
+		if delay >= max {
+			return max
+		}
+	}
+	return delay
+}
@@ -12,8 +12,8 @@ import (
 type GenerateReportUseCase struct {
 	repository    domain.AMLCheckRepository
 	reportStorage domain.ReportStorage
-	messageBus    domain.MessageBus
 	billingHook   domain.BillingHook
+	auditLog      domain.AuditLog
 	reportTTL     time.Duration
 	logger        *zap.SugaredLogger
 }
@@ -21,23 +21,24 @@ type GenerateReportUseCase struct {
 func NewGenerateReportUseCase(
 	repository domain.AMLCheckRepository,
 	reportStorage domain.ReportStorage,
-	messageBus domain.MessageBus,
 	billingHook domain.BillingHook,
+	auditLog domain.AuditLog,
 	reportTTL time.Duration,
 	logger *zap.SugaredLogger,
 ) *GenerateReportUseCase {
 	return &GenerateReportUseCase{
 		repository:    repository,
 		reportStorage: reportStorage,
-		messageBus:    messageBus,
 		billingHook:   billingHook,
+		auditLog:      auditLog,
 		reportTTL:     reportTTL,
 		logger:        logger,
 	}
 }
 
 // executes the generate report use case
-func (u *GenerateReportUseCase) Execute(ctx context.Context, checkID string, riskScore int, riskLevel domain.RiskLevel, categories []string, sanctions *domain.SanctionsResult) error {
+func (u *GenerateReportUseCase) Execute(ctx context.Context, result *domain.AMLCheckCompletedPayload) error {
+	checkID := result.CheckID
 	u.logger.Infow("generating report", "check_id", checkID)
 
 	// get check
@@ -52,7 +53,22 @@ func (u *GenerateReportUseCase) Execute(ctx context.Context, checkID string, ris
 	}
 
 	// generate PDF
-	pdfData, err := GeneratePDF(check.Address, check.Currency, riskScore, riskLevel, categories, sanctions, checkID)
+	pdfData, err := GeneratePDF(ReportData{
+		CheckID:      checkID,
+		Address:      check.Address,
+		Currency:     check.Currency,
+		Network:      check.Chain,
+		Tag:          check.Tag,
+		NetworkType:  check.NetworkType,
+		RiskScore:    result.RiskScore,
+		RiskLevel:    result.RiskLevel,
+		Categories:   result.Categories,
+		Sanctions:    result.Sanctions,
+		Providers:    result.Providers,
+		Watchlist:    result.Watchlist,
+		Decision:     result.Decision,
+		MatchedRules: result.MatchedRules,
+	})
 	if err != nil {
 		u.logger.Errorw("failed to generate pdf", "check_id", checkID, "error", err)
 		return fmt.Errorf("failed to generate pdf: %w", err)
@@ -65,29 +81,35 @@ func (u *GenerateReportUseCase) Execute(ctx context.Context, checkID string, ris
 	}
 
 	// Store PDF
-	reportKey := fmt.Sprintf("%s.pdf", checkID)
+	reportKey := domain.ReportKey(check.TenantID, checkID)
 	if err := u.reportStorage.Put(ctx, reportKey, pdfData, u.reportTTL); err != nil {
 		u.logger.Errorw("failed to store report", "check_id", checkID, "error", err)
 		return fmt.Errorf("failed to store report: %w", err)
 	}
 
-	// update check
-	check.MarkCompleted(riskScore, riskLevel, categories, sanctions, reportKey)
-	if err := u.repository.Update(ctx, check); err != nil {
-		u.logger.Errorw("failed to update check", "check_id", checkID, "error", err)
-		return fmt.Errorf("failed to update check: %w", err)
-	}
+	// update check together with the report ready event
+	check.MarkCompleted(result.RiskScore, result.RiskLevel, result.Categories, result.Sanctions, reportKey)
+	check.Providers = result.Providers
+	check.ProviderStatuses = result.ProviderStatuses
+	check.Watchlist = result.Watchlist
+	check.Decision = result.Decision
+	check.MatchedRules = result.MatchedRules
 
-	// publish report ready event
 	event := domain.NewEvent(domain.EventAMLReportReady, &domain.AMLReportReadyPayload{
 		CheckID:   checkID,
 		ReportKey: reportKey,
 	})
 
-	if err := u.messageBus.Publish(ctx, domain.EventAMLReportReady, event); err != nil {
-		u.logger.Errorw("failed to publish report ready event", "check_id", checkID, "error", err)
+	if err := u.repository.UpdateWithEvents(ctx, check, domain.NewOutboxMessage(domain.EventAMLReportReady, event)); err != nil {
+		u.logger.Errorw("failed to update check", "check_id", checkID, "error", err)
+		return fmt.Errorf("failed to update check: %w", err)
 	}
 
+	recordAudit(ctx, u.auditLog, u.logger, domain.NewAuditEntry(domain.AuditReportGenerated, domain.AuditActorSystem, check.TenantID, checkID, check.Address, map[string]string{
+		"report_key":  reportKey,
+		"report_hash": domain.HashPayload(pdfData),
+	}))
+
 	// billing hook (non-blocking)
 	if err := u.billingHook.OnCheckCompleted(ctx, check); err != nil {
 		u.logger.Warnw("billing hook failed", "check_id", checkID, "error", err)
@@ -23,15 +23,15 @@ func NewGetCheckStatusUseCase(
 	}
 }
 
-// executes the get check status use case
-func (u *GetCheckStatusUseCase) Execute(ctx context.Context, checkID string) (*domain.AMLCheck, error) {
+// executes the get check status use case, other tenants' checks are reported as not found
+func (u *GetCheckStatusUseCase) Execute(ctx context.Context, tenantID, checkID string) (*domain.AMLCheck, error) {
 	check, err := u.repository.Get(ctx, checkID)
 	if err != nil {
 		u.logger.Errorw("failed to get check", "check_id", checkID, "error", err)
 		return nil, fmt.Errorf("failed to get check: %w", err)
 	}
 
-	if check == nil {
+	if check == nil || check.TenantID != tenantID {
 		return nil, fmt.Errorf("check not found")
 	}
 
@@ -10,15 +10,18 @@ import (
 
 type HandleCheckFailedUseCase struct {
 	repository domain.AMLCheckRepository
+	notifier   domain.CheckNotifier
 	logger     *zap.SugaredLogger
 }
 
 func NewHandleCheckFailedUseCase(
 	repository domain.AMLCheckRepository,
+	notifier domain.CheckNotifier,
 	logger *zap.SugaredLogger,
 ) *HandleCheckFailedUseCase {
 	return &HandleCheckFailedUseCase{
 		repository: repository,
+		notifier:   notifier,
 		logger:     logger,
 	}
 }
@@ -43,5 +46,7 @@ func (u *HandleCheckFailedUseCase) Execute(ctx context.Context, checkID, errorMe
 		return fmt.Errorf("failed to update check: %w", err)
 	}
 
+	u.notifier.Notify(checkID, domain.StatusFailed)
+
 	return nil
 }
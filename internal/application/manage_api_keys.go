@@ -0,0 +1,164 @@
+package application
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/Beka01247/bitpanda-aml/internal/domain"
+	"go.uber.org/zap"
+)
+
+type IssueAPIKeyInput struct {
+	TenantID    string
+	Name        string
+	CallbackURL string
+	// defaults to integrator
+	Roles []domain.Role
+}
+
+// a freshly issued key, Secret is only ever returned here
+type IssuedAPIKey struct {
+	Key    *domain.APIKey
+	Secret string
+}
+
+type ManageAPIKeysUseCase struct {
+	repository domain.APIKeyRepository
+	logger     *zap.SugaredLogger
+}
+
+func NewManageAPIKeysUseCase(
+	repository domain.APIKeyRepository,
+	logger *zap.SugaredLogger,
+) *ManageAPIKeysUseCase {
+	return &ManageAPIKeysUseCase{
+		repository: repository,
+		logger:     logger,
+	}
+}
+
+// resolves the key sent by a client, returns ErrInvalidAPIKey for unknown, revoked and expired keys
+func (u *ManageAPIKeysUseCase) Authenticate(ctx context.Context, secret string) (*domain.APIKey, error) {
+	if secret == "" {
+		return nil, domain.ErrInvalidAPIKey
+	}
+
+	key, err := u.repository.GetByHash(ctx, domain.HashAPIKey(secret))
+	if err != nil {
+		u.logger.Errorw("failed to get api key", "error", err)
+		return nil, fmt.Errorf("failed to get api key: %w", err)
+	}
+
+	if key == nil || !key.IsActive(time.Now().UTC()) {
+		return nil, domain.ErrInvalidAPIKey
+	}
+
+	return key, nil
+}
+
+// keys bound to a tenant can only issue keys for that tenant
+func (u *ManageAPIKeysUseCase) Issue(ctx context.Context, tenantID string, input IssueAPIKeyInput) (*IssuedAPIKey, error) {
+	if !domain.TenantVisible(tenantID, input.TenantID) {
+		return nil, fmt.Errorf("%w: keys can only be issued for tenant %s", domain.ErrInvalidTenant, tenantID)
+	}
+
+	key, secret, err := domain.NewAPIKey(input.TenantID, input.Name, input.CallbackURL, input.Roles)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := u.repository.Create(ctx, key); err != nil {
+		u.logger.Errorw("failed to create api key", "tenant_id", input.TenantID, "error", err)
+		return nil, fmt.Errorf("failed to create api key: %w", err)
+	}
+
+	u.logger.Infow("api key issued", "key_id", key.ID, "tenant_id", key.TenantID, "prefix", key.Prefix, "roles", key.Roles)
+
+	return &IssuedAPIKey{Key: key, Secret: secret}, nil
+}
+
+// other tenants' keys are reported as not found, keys without a tenant see every key
+func (u *ManageAPIKeysUseCase) Get(ctx context.Context, tenantID, keyID string) (*domain.APIKey, error) {
+	key, err := u.repository.Get(ctx, keyID)
+	if err != nil {
+		u.logger.Errorw("failed to get api key", "key_id", keyID, "error", err)
+		return nil, fmt.Errorf("failed to get api key: %w", err)
+	}
+
+	if key == nil || !domain.TenantVisible(tenantID, key.TenantID) {
+		return nil, domain.ErrAPIKeyNotFound
+	}
+
+	return key, nil
+}
+
+func (u *ManageAPIKeysUseCase) List(ctx context.Context, tenantID string, filter domain.APIKeyFilter) ([]*domain.APIKey, error) {
+	if tenantID != "" {
+		filter.TenantID = tenantID
+	}
+
+	keys, err := u.repository.List(ctx, filter)
+	if err != nil {
+		u.logger.Errorw("failed to list api keys", "error", err)
+		return nil, fmt.Errorf("failed to list api keys: %w", err)
+	}
+
+	return keys, nil
+}
+
+// issues a replacement with the same tenant, name, callback and roles, the old key keeps
+// working for the grace period so clients can switch without downtime
+func (u *ManageAPIKeysUseCase) Rotate(ctx context.Context, tenantID, keyID string, grace time.Duration) (*IssuedAPIKey, error) {
+	old, err := u.Get(ctx, tenantID, keyID)
+	if err != nil {
+		return nil, err
+	}
+
+	if !old.IsActive(time.Now().UTC()) {
+		return nil, fmt.Errorf("%w: only active keys can be rotated", domain.ErrInvalidAPIKey)
+	}
+
+	issued, err := u.Issue(ctx, tenantID, IssueAPIKeyInput{
+		TenantID:    old.TenantID,
+		Name:        old.Name,
+		CallbackURL: old.CallbackURL,
+		Roles:       old.Roles,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if grace > 0 {
+		old.ExpireAt(time.Now().UTC().Add(grace))
+	} else {
+		old.Revoke()
+	}
+
+	if err := u.repository.Update(ctx, old); err != nil {
+		u.logger.Errorw("failed to expire rotated api key", "key_id", keyID, "error", err)
+		return nil, fmt.Errorf("failed to update api key: %w", err)
+	}
+
+	u.logger.Infow("api key rotated", "key_id", keyID, "new_key_id", issued.Key.ID, "tenant_id", old.TenantID, "grace", grace)
+
+	return issued, nil
+}
+
+func (u *ManageAPIKeysUseCase) Revoke(ctx context.Context, tenantID, keyID string) error {
+	key, err := u.Get(ctx, tenantID, keyID)
+	if err != nil {
+		return err
+	}
+
+	key.Revoke()
+
+	if err := u.repository.Update(ctx, key); err != nil {
+		u.logger.Errorw("failed to revoke api key", "key_id", keyID, "error", err)
+		return fmt.Errorf("failed to update api key: %w", err)
+	}
+
+	u.logger.Infow("api key revoked", "key_id", keyID, "tenant_id", key.TenantID)
+
+	return nil
+}
@@ -0,0 +1,163 @@
+package application
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/Beka01247/bitpanda-aml/internal/domain"
+	"go.uber.org/zap"
+)
+
+type ManageCasesUseCase struct {
+	checkRepository domain.AMLCheckRepository
+	repository      domain.ReviewCaseRepository
+	logger          *zap.SugaredLogger
+}
+
+func NewManageCasesUseCase(
+	checkRepository domain.AMLCheckRepository,
+	repository domain.ReviewCaseRepository,
+	logger *zap.SugaredLogger,
+) *ManageCasesUseCase {
+	return &ManageCasesUseCase{
+		checkRepository: checkRepository,
+		repository:      repository,
+		logger:          logger,
+	}
+}
+
+// opens a case for a completed check that needs an analyst, safe to call more than once
+func (u *ManageCasesUseCase) OpenForCheck(ctx context.Context, checkID string) (*domain.ReviewCase, error) {
+	check, err := u.checkRepository.Get(ctx, checkID)
+	if err != nil {
+		u.logger.Errorw("failed to get check", "check_id", checkID, "error", err)
+		return nil, fmt.Errorf("failed to get check: %w", err)
+	}
+
+	if check == nil {
+		return nil, fmt.Errorf("check not found")
+	}
+
+	reasons := domain.ReviewReasons(check)
+	if len(reasons) == 0 {
+		return nil, nil
+	}
+
+	reviewCase := domain.NewReviewCase(check, reasons)
+	if err := u.repository.Create(ctx, reviewCase); err != nil {
+		if errors.Is(err, domain.ErrCaseExists) {
+			u.logger.Infow("case already open for check", "check_id", checkID)
+			return nil, nil
+		}
+		u.logger.Errorw("failed to create case", "check_id", checkID, "error", err)
+		return nil, fmt.Errorf("failed to create case: %w", err)
+	}
+
+	u.logger.Infow("case opened", "case_id", reviewCase.ID, "check_id", checkID, "reasons", reasons)
+
+	return reviewCase, nil
+}
+
+// cases of other tenants are reported as not found, keys without a tenant see every case
+func (u *ManageCasesUseCase) Get(ctx context.Context, tenantID, caseID string) (*domain.ReviewCase, error) {
+	reviewCase, err := u.repository.Get(ctx, caseID)
+	if err != nil {
+		u.logger.Errorw("failed to get case", "case_id", caseID, "error", err)
+		return nil, fmt.Errorf("failed to get case: %w", err)
+	}
+
+	if reviewCase == nil || !domain.TenantVisible(tenantID, reviewCase.TenantID) {
+		return nil, domain.ErrCaseNotFound
+	}
+
+	return reviewCase, nil
+}
+
+func (u *ManageCasesUseCase) List(ctx context.Context, tenantID string, filter domain.CaseFilter) ([]*domain.ReviewCase, error) {
+	if tenantID != "" {
+		filter.TenantID = tenantID
+	}
+
+	cases, err := u.repository.List(ctx, filter)
+	if err != nil {
+		u.logger.Errorw("failed to list cases", "error", err)
+		return nil, fmt.Errorf("failed to list cases: %w", err)
+	}
+
+	return cases, nil
+}
+
+func (u *ManageCasesUseCase) Claim(ctx context.Context, tenantID, caseID, assignee string) (*domain.ReviewCase, error) {
+	return u.apply(ctx, tenantID, caseID, func(reviewCase *domain.ReviewCase) error {
+		return reviewCase.Claim(assignee)
+	})
+}
+
+func (u *ManageCasesUseCase) Comment(ctx context.Context, tenantID, caseID, author, body string) (*domain.ReviewCase, error) {
+	return u.apply(ctx, tenantID, caseID, func(reviewCase *domain.ReviewCase) error {
+		return reviewCase.Comment(author, body)
+	})
+}
+
+func (u *ManageCasesUseCase) Escalate(ctx context.Context, tenantID, caseID, actor, reason string) (*domain.ReviewCase, error) {
+	return u.apply(ctx, tenantID, caseID, func(reviewCase *domain.ReviewCase) error {
+		return reviewCase.Escalate(actor, reason)
+	})
+}
+
+// closes the case and publishes aml.case.closed together with it
+func (u *ManageCasesUseCase) Resolve(ctx context.Context, tenantID, caseID, actor, disposition, summary string) (*domain.ReviewCase, error) {
+	parsed, err := domain.ParseCaseDisposition(disposition)
+	if err != nil {
+		return nil, err
+	}
+
+	reviewCase, err := u.Get(ctx, tenantID, caseID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := reviewCase.Resolve(actor, parsed, summary); err != nil {
+		return nil, err
+	}
+
+	event := domain.NewEvent(domain.EventAMLCaseClosed, &domain.AMLCaseClosedPayload{
+		CaseID:      reviewCase.ID,
+		CheckID:     reviewCase.CheckID,
+		Address:     reviewCase.Address,
+		Currency:    reviewCase.Currency,
+		Disposition: reviewCase.Disposition,
+		Assignee:    reviewCase.Assignee,
+		ClosedAt:    *reviewCase.ClosedAt,
+	})
+
+	if err := u.repository.UpdateWithEvents(ctx, reviewCase, domain.NewOutboxMessage(domain.EventAMLCaseClosed, event)); err != nil {
+		u.logger.Warnw("failed to close case", "case_id", caseID, "error", err)
+		return nil, fmt.Errorf("failed to close case: %w", err)
+	}
+
+	u.logger.Infow("case closed", "case_id", caseID, "check_id", reviewCase.CheckID, "disposition", reviewCase.Disposition, "assignee", actor)
+
+	return reviewCase, nil
+}
+
+func (u *ManageCasesUseCase) apply(ctx context.Context, tenantID, caseID string, change func(*domain.ReviewCase) error) (*domain.ReviewCase, error) {
+	reviewCase, err := u.Get(ctx, tenantID, caseID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := change(reviewCase); err != nil {
+		return nil, err
+	}
+
+	if err := u.repository.Update(ctx, reviewCase); err != nil {
+		u.logger.Warnw("failed to update case", "case_id", caseID, "error", err)
+		return nil, fmt.Errorf("failed to update case: %w", err)
+	}
+
+	u.logger.Infow("case updated", "case_id", caseID, "status", reviewCase.Status, "assignee", reviewCase.Assignee)
+
+	return reviewCase, nil
+}
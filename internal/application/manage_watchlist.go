@@ -0,0 +1,155 @@
+package application
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/Beka01247/bitpanda-aml/internal/domain"
+	"go.uber.org/zap"
+)
+
+type WatchlistEntryInput struct {
+	Address   string
+	Chain     string
+	ListType  string
+	Reason    string
+	Author    string
+	ExpiresAt *time.Time
+}
+
+type ManageWatchlistUseCase struct {
+	assetRegistry domain.AssetRegistry
+	repository    domain.WatchlistRepository
+	logger        *zap.SugaredLogger
+}
+
+func NewManageWatchlistUseCase(
+	assetRegistry domain.AssetRegistry,
+	repository domain.WatchlistRepository,
+	logger *zap.SugaredLogger,
+) *ManageWatchlistUseCase {
+	return &ManageWatchlistUseCase{
+		assetRegistry: assetRegistry,
+		repository:    repository,
+		logger:        logger,
+	}
+}
+
+// creates a new watchlist entry
+func (u *ManageWatchlistUseCase) Create(ctx context.Context, input WatchlistEntryInput) (*domain.WatchlistEntry, error) {
+	address, chain, listType, err := u.validate(input)
+	if err != nil {
+		return nil, err
+	}
+
+	entry := domain.NewWatchlistEntry(address, chain, listType, input.Reason, input.Author, input.ExpiresAt)
+	if err := u.repository.Create(ctx, entry); err != nil {
+		u.logger.Errorw("failed to create watchlist entry", "error", err)
+		return nil, fmt.Errorf("failed to create watchlist entry: %w", err)
+	}
+
+	u.logger.Infow("watchlist entry created",
+		"entry_id", entry.ID,
+		"list_type", entry.ListType,
+		"chain", entry.Chain,
+		"address", entry.Address,
+		"author", entry.Author)
+
+	return entry, nil
+}
+
+func (u *ManageWatchlistUseCase) Get(ctx context.Context, entryID string) (*domain.WatchlistEntry, error) {
+	entry, err := u.repository.Get(ctx, entryID)
+	if err != nil {
+		u.logger.Errorw("failed to get watchlist entry", "entry_id", entryID, "error", err)
+		return nil, fmt.Errorf("failed to get watchlist entry: %w", err)
+	}
+
+	if entry == nil {
+		return nil, domain.ErrWatchlistEntryNotFound
+	}
+
+	return entry, nil
+}
+
+func (u *ManageWatchlistUseCase) List(ctx context.Context, filter domain.WatchlistFilter) ([]*domain.WatchlistEntry, error) {
+	entries, err := u.repository.List(ctx, filter)
+	if err != nil {
+		u.logger.Errorw("failed to list watchlist entries", "error", err)
+		return nil, fmt.Errorf("failed to list watchlist entries: %w", err)
+	}
+
+	return entries, nil
+}
+
+// replaces an existing entry
+func (u *ManageWatchlistUseCase) Update(ctx context.Context, entryID string, input WatchlistEntryInput) (*domain.WatchlistEntry, error) {
+	entry, err := u.Get(ctx, entryID)
+	if err != nil {
+		return nil, err
+	}
+
+	address, chain, listType, err := u.validate(input)
+	if err != nil {
+		return nil, err
+	}
+
+	entry.Address = address
+	entry.Chain = chain
+	entry.ListType = listType
+	entry.Reason = input.Reason
+	entry.Author = input.Author
+	entry.ExpiresAt = input.ExpiresAt
+	entry.UpdatedAt = time.Now().UTC()
+
+	if err := u.repository.Update(ctx, entry); err != nil {
+		u.logger.Errorw("failed to update watchlist entry", "entry_id", entryID, "error", err)
+		return nil, fmt.Errorf("failed to update watchlist entry: %w", err)
+	}
+
+	u.logger.Infow("watchlist entry updated", "entry_id", entry.ID, "list_type", entry.ListType, "author", entry.Author)
+
+	return entry, nil
+}
+
+func (u *ManageWatchlistUseCase) Delete(ctx context.Context, entryID string) error {
+	if err := u.repository.Delete(ctx, entryID); err != nil {
+		u.logger.Warnw("failed to delete watchlist entry", "entry_id", entryID, "error", err)
+		return fmt.Errorf("failed to delete watchlist entry: %w", err)
+	}
+
+	u.logger.Infow("watchlist entry deleted", "entry_id", entryID)
+
+	return nil
+}
+
+// normalizes the address with the rules of an asset on the requested chain
+func (u *ManageWatchlistUseCase) validate(input WatchlistEntryInput) (string, string, domain.WatchlistType, error) {
+	listType, err := domain.ParseWatchlistType(input.ListType)
+	if err != nil {
+		return "", "", "", err
+	}
+
+	if input.ExpiresAt != nil && input.ExpiresAt.Before(time.Now().UTC()) {
+		return "", "", "", fmt.Errorf("%w: expires_at is in the past", domain.ErrInvalidWatchlistEntry)
+	}
+
+	chain := strings.ToLower(strings.TrimSpace(input.Chain))
+	for _, asset := range u.assetRegistry.List() {
+		if asset.Chain() != chain {
+			continue
+		}
+
+		// checksums can depend on case, validate what the analyst sent
+		if err := asset.ValidateAddress(strings.TrimSpace(input.Address)); err != nil {
+			return "", "", "", fmt.Errorf("invalid address: %w", err)
+		}
+		address := asset.NormalizeAddress(input.Address)
+
+		return address, chain, listType, nil
+	}
+
+	return "", "", "", fmt.Errorf("%w: unsupported chain %q", domain.ErrInvalidWatchlistEntry, input.Chain)
+}
@@ -2,6 +2,7 @@ package application
 
 import (
 	"fmt"
+	"sort"
 	"strings"
 	"time"
 
@@ -9,8 +10,38 @@ import (
 	"github.com/jung-kurt/gofpdf"
 )
 
-func GeneratePDF(address, currency string, riskScore int, riskLevel domain.RiskLevel, categories []string, sanctions *domain.SanctionsResult, checkID string) ([]byte, error) {
+// everything that ends up in the report
+type ReportData struct {
+	CheckID      string
+	Address      string
+	Currency     string
+	Network      string
+	Tag          string
+	RiskScore    int
+	RiskLevel    domain.RiskLevel
+	Categories   []string
+	Sanctions    *domain.SanctionsResult
+	Providers    []domain.ProviderResult
+	Watchlist    *domain.WatchlistMatch
+	Decision     domain.Decision
+	MatchedRules []domain.MatchedRule
+
+	// test network reports are watermarked so they can't pass for a real assessment
+	NetworkType domain.NetworkType
+}
+
+func GeneratePDF(data ReportData) ([]byte, error) {
 	pdf := gofpdf.New("P", "mm", "A4", "")
+	writeCheckReport(pdf, data)
+	return outputPDF(pdf)
+}
+
+// renders one check on a new page
+func writeCheckReport(pdf *gofpdf.Fpdf, data ReportData) {
+	address, currency, checkID := data.Address, data.Currency, data.CheckID
+	riskScore, riskLevel, categories, sanctions := data.RiskScore, data.RiskLevel, data.Categories, data.Sanctions
+
+	pdf.SetHeaderFunc(testWatermark(pdf, data.NetworkType))
 	pdf.AddPage()
 
 	pdf.SetFont("Arial", "B", 20)
@@ -35,12 +66,51 @@ func GeneratePDF(address, currency string, riskScore int, riskLevel domain.RiskL
 	pdf.Cell(40, 6, "Currency:")
 	pdf.SetFont("Arial", "B", 11)
 	pdf.Cell(0, 6, currency)
-	pdf.Ln(10)
+	pdf.Ln(6)
+
+	if data.Network != "" {
+		pdf.SetFont("Arial", "", 11)
+		pdf.Cell(40, 6, "Network:")
+		pdf.SetFont("Arial", "B", 11)
+		pdf.Cell(0, 6, data.Network)
+		pdf.Ln(6)
+	}
+
+	if data.Tag != "" {
+		pdf.SetFont("Arial", "", 11)
+		pdf.Cell(40, 6, "Tag/Memo:")
+		pdf.SetFont("Arial", "B", 11)
+		pdf.Cell(0, 6, data.Tag)
+		pdf.Ln(6)
+	}
+	pdf.Ln(4)
 
 	pdf.SetFont("Arial", "B", 14)
 	pdf.Cell(0, 8, "Risk Assessment")
 	pdf.Ln(8)
 
+	if data.Watchlist != nil {
+		pdf.SetFont("Arial", "B", 11)
+		if data.Watchlist.ListType == domain.WatchlistBlocklist {
+			pdf.SetTextColor(139, 0, 0)
+			pdf.Cell(0, 6, "Decided by internal blocklist")
+		} else {
+			pdf.SetTextColor(0, 128, 0)
+			pdf.Cell(0, 6, "Decided by internal allowlist (providers not queried)")
+		}
+		pdf.SetTextColor(0, 0, 0)
+		pdf.Ln(6)
+
+		pdf.SetFont("Arial", "", 10)
+		pdf.Cell(10, 5, "")
+		pdf.Cell(20, 5, "Reason:")
+		pdf.MultiCell(0, 5, data.Watchlist.Reason, "", "", false)
+		pdf.Cell(10, 5, "")
+		pdf.Cell(20, 5, "Author:")
+		pdf.Cell(0, 5, data.Watchlist.Author)
+		pdf.Ln(8)
+	}
+
 	pdf.SetFont("Arial", "", 11)
 	pdf.Cell(40, 6, "Risk Score:")
 	pdf.SetFont("Arial", "B", 11)
@@ -63,7 +133,64 @@ func GeneratePDF(address, currency string, riskScore int, riskLevel domain.RiskL
 	}
 	pdf.Cell(0, 6, string(riskLevel))
 	pdf.SetTextColor(0, 0, 0)
-	pdf.Ln(10)
+	pdf.Ln(6)
+
+	if data.Decision != "" {
+		pdf.SetFont("Arial", "", 11)
+		pdf.Cell(40, 6, "Decision:")
+		pdf.SetFont("Arial", "B", 11)
+
+		switch data.Decision {
+		case domain.DecisionApprove:
+			pdf.SetTextColor(0, 128, 0)
+		case domain.DecisionReview:
+			pdf.SetTextColor(255, 165, 0)
+		case domain.DecisionReject:
+			pdf.SetTextColor(139, 0, 0)
+		}
+		pdf.Cell(0, 6, strings.ToUpper(string(data.Decision)))
+		pdf.SetTextColor(0, 0, 0)
+		pdf.Ln(6)
+
+		if len(data.MatchedRules) > 0 {
+			pdf.SetFont("Arial", "", 11)
+			pdf.Cell(40, 6, "Matched Rules:")
+			pdf.Ln(6)
+			pdf.SetFont("Arial", "", 10)
+			for _, rule := range data.MatchedRules {
+				pdf.Cell(10, 5, "")
+				line := fmt.Sprintf("- %s (%s)", rule.ID, rule.Decision)
+				if rule.Description != "" {
+					line = fmt.Sprintf("- %s (%s): %s", rule.ID, rule.Decision, rule.Description)
+				}
+				pdf.MultiCell(0, 5, line, "", "", false)
+			}
+		}
+	}
+	pdf.Ln(4)
+
+	if len(data.Providers) > 1 || anyCached(data.Providers) {
+		pdf.SetFont("Arial", "", 11)
+		pdf.Cell(40, 6, "Providers:")
+		pdf.Ln(6)
+		pdf.SetFont("Arial", "", 10)
+		for _, provider := range data.Providers {
+			pdf.Cell(10, 5, "")
+			if provider.Error != "" {
+				displayError := provider.Error
+				if len(displayError) > 60 {
+					displayError = displayError[:60] + "..."
+				}
+				pdf.SetTextColor(128, 128, 128)
+				pdf.Cell(0, 5, fmt.Sprintf("- %s: unavailable (%s)", provider.Provider, displayError))
+				pdf.SetTextColor(0, 0, 0)
+			} else {
+				pdf.Cell(0, 5, fmt.Sprintf("- %s: %d / 100 (%s)%s", provider.Provider, provider.RiskScore, provider.RiskLevel, cacheNote(provider.Cache)))
+			}
+			pdf.Ln(5)
+		}
+		pdf.Ln(5)
+	}
 
 	if len(categories) > 0 {
 		pdf.SetFont("Arial", "", 11)
@@ -85,7 +212,7 @@ func GeneratePDF(address, currency string, riskScore int, riskLevel domain.RiskL
 	pdf.Ln(5)
 
 	pdf.SetFont("Arial", "B", 14)
-	pdf.Cell(0, 8, "Sanctions Screening (Chainalysis)")
+	pdf.Cell(0, 8, "Sanctions Screening")
 	pdf.Ln(8)
 
 	if sanctions != nil && sanctions.Hit {
@@ -120,6 +247,22 @@ func GeneratePDF(address, currency string, riskScore int, riskLevel domain.RiskL
 			}
 			pdf.Ln(3)
 		}
+	} else if sanctions != nil && !sanctions.Screened() {
+		// not screened is not clean, don't print it in green
+		pdf.SetFont("Arial", "B", 11)
+		pdf.SetTextColor(255, 140, 0)
+		pdf.Cell(0, 6, sanctionsScreeningTitle(sanctions))
+		pdf.SetTextColor(0, 0, 0)
+		pdf.Ln(6)
+
+		pdf.SetFont("Arial", "", 10)
+		if sanctions.Provider != "" {
+			pdf.Cell(0, 5, fmt.Sprintf("Provider: %s", sanctions.Provider))
+			pdf.Ln(5)
+		}
+		if sanctions.Reason != "" {
+			pdf.MultiCell(0, 5, fmt.Sprintf("Reason: %s", sanctions.Reason), "", "", false)
+		}
 	} else {
 		pdf.SetFont("Arial", "", 11)
 		pdf.SetTextColor(0, 128, 0)
@@ -128,11 +271,84 @@ func GeneratePDF(address, currency string, riskScore int, riskLevel domain.RiskL
 		pdf.Ln(6)
 	}
 
+	if sanctions != nil && len(sanctions.Cache) > 0 {
+		providers := make([]string, 0, len(sanctions.Cache))
+		for provider := range sanctions.Cache {
+			providers = append(providers, provider)
+		}
+		sort.Strings(providers)
+
+		pdf.SetFont("Arial", "I", 9)
+		pdf.SetTextColor(128, 128, 128)
+		for _, provider := range providers {
+			info := sanctions.Cache[provider]
+			pdf.Cell(0, 5, provider+cacheNote(&info))
+			pdf.Ln(5)
+		}
+		pdf.SetTextColor(0, 0, 0)
+	}
+
 	pdf.SetY(-20)
 	pdf.SetFont("Arial", "I", 8)
 	pdf.SetTextColor(128, 128, 128)
 	pdf.Cell(0, 10, fmt.Sprintf("Check ID: %s", checkID))
+	pdf.SetTextColor(0, 0, 0)
+}
+
+// header that marks every following page, including the ones added by page breaks,
+// nil for mainnet
+func testWatermark(pdf *gofpdf.Fpdf, network domain.NetworkType) func() {
+	if !network.IsTest() {
+		return nil
+	}
+	return func() { writeTestWatermark(pdf, network) }
+}
+
+// a large diagonal TEST behind the page content and a red notice under the title
+func writeTestWatermark(pdf *gofpdf.Fpdf, network domain.NetworkType) {
+	width, height := pdf.GetPageSize()
+
+	pdf.TransformBegin()
+	pdf.TransformRotate(45, width/2, height/2)
+	pdf.SetFont("Arial", "B", 120)
+	pdf.SetTextColor(235, 235, 235)
+	pdf.Text(width/2-pdf.GetStringWidth("TEST")/2, height/2+15, "TEST")
+	pdf.TransformEnd()
+
+	pdf.SetY(8)
+	pdf.SetFont("Arial", "B", 10)
+	pdf.SetTextColor(200, 0, 0)
+	pdf.CellFormat(0, 6, fmt.Sprintf("TEST NETWORK (%s) - answered by the mock provider, not a real AML assessment", network), "", 1, "C", false, 0, "")
+	pdf.SetTextColor(0, 0, 0)
+	pdf.SetY(20)
+}
+
+func sanctionsScreeningTitle(sanctions *domain.SanctionsResult) string {
+	if sanctions.Status == domain.SanctionsError {
+		return "SANCTIONS SCREENING FAILED"
+	}
+	return "SANCTIONS SCREENING NOT PERFORMED"
+}
+
+func anyCached(providers []domain.ProviderResult) bool {
+	for _, provider := range providers {
+		if provider.Cache != nil {
+			return true
+		}
+	}
+	return false
+}
+
+// " - cached result, 12m0s old (...)", empty for live answers
+func cacheNote(info *domain.CacheInfo) string {
+	if info == nil {
+		return ""
+	}
+	age := (time.Duration(info.AgeSeconds) * time.Second).String()
+	return fmt.Sprintf(" - cached result, %s old (%s UTC)", age, info.CachedAt.UTC().Format("2006-01-02 15:04:05"))
+}
 
+func outputPDF(pdf *gofpdf.Fpdf) ([]byte, error) {
 	// generate PDF bytes
 	var buf strings.Builder
 	err := pdf.Output(&buf)
@@ -142,3 +358,101 @@ func GeneratePDF(address, currency string, riskScore int, riskLevel domain.RiskL
 
 	return []byte(buf.String()), nil
 }
+
+// one line of the batch summary, Report is set for completed checks
+type BatchReportEntry struct {
+	Address  string
+	Currency string
+	Network  string
+	CheckID  string
+	Status   string
+	Error    string
+	Report   *ReportData
+}
+
+// a summary table followed by the full report of every completed check
+func GenerateBatchPDF(batchID string, entries []BatchReportEntry) ([]byte, error) {
+	pdf := gofpdf.New("P", "mm", "A4", "")
+	pdf.SetHeaderFunc(testWatermark(pdf, batchTestNetwork(entries)))
+	pdf.AddPage()
+
+	pdf.SetFont("Arial", "B", 20)
+	pdf.Cell(0, 10, "AML Batch Report")
+	pdf.Ln(15)
+
+	pdf.SetFont("Arial", "", 10)
+	pdf.Cell(0, 6, fmt.Sprintf("Generated: %s UTC", time.Now().UTC().Format("2006-01-02 15:04:05")))
+	pdf.Ln(6)
+	pdf.Cell(0, 6, fmt.Sprintf("Batch ID: %s", batchID))
+	pdf.Ln(6)
+	pdf.Cell(0, 6, fmt.Sprintf("Addresses: %d", len(entries)))
+	pdf.Ln(10)
+
+	pdf.SetFont("Arial", "B", 14)
+	pdf.Cell(0, 8, "Summary")
+	pdf.Ln(10)
+
+	pdf.SetFont("Arial", "B", 9)
+	pdf.CellFormat(8, 6, "#", "B", 0, "", false, 0, "")
+	pdf.CellFormat(86, 6, "Address", "B", 0, "", false, 0, "")
+	pdf.CellFormat(24, 6, "Asset", "B", 0, "", false, 0, "")
+	pdf.CellFormat(14, 6, "Score", "B", 0, "", false, 0, "")
+	pdf.CellFormat(22, 6, "Level", "B", 0, "", false, 0, "")
+	pdf.CellFormat(0, 6, "Decision", "B", 1, "", false, 0, "")
+
+	pdf.SetFont("Arial", "", 8)
+	for i, entry := range entries {
+		address := entry.Address
+		if len(address) > 54 {
+			address = address[:51] + "..."
+		}
+
+		pdf.CellFormat(8, 5, fmt.Sprintf("%d", i+1), "", 0, "", false, 0, "")
+		pdf.CellFormat(86, 5, address, "", 0, "", false, 0, "")
+		pdf.CellFormat(24, 5, assetLabel(entry.Currency, entry.Network), "", 0, "", false, 0, "")
+
+		if entry.Report == nil {
+			detail := entry.Status
+			if entry.Error != "" {
+				detail = fmt.Sprintf("%s: %s", entry.Status, entry.Error)
+			}
+			if len(detail) > 45 {
+				detail = detail[:42] + "..."
+			}
+			pdf.SetTextColor(128, 128, 128)
+			pdf.CellFormat(0, 5, detail, "", 1, "", false, 0, "")
+			pdf.SetTextColor(0, 0, 0)
+			continue
+		}
+
+		pdf.CellFormat(14, 5, fmt.Sprintf("%d", entry.Report.RiskScore), "", 0, "", false, 0, "")
+		pdf.CellFormat(22, 5, string(entry.Report.RiskLevel), "", 0, "", false, 0, "")
+		pdf.CellFormat(0, 5, strings.ToUpper(string(entry.Report.Decision)), "", 1, "", false, 0, "")
+	}
+
+	for _, entry := range entries {
+		if entry.Report != nil {
+			writeCheckReport(pdf, *entry.Report)
+		}
+	}
+
+	return outputPDF(pdf)
+}
+
+// currency with the network it was screened on, e.g. USDT/tron
+func assetLabel(currency, network string) string {
+	if network == "" {
+		return currency
+	}
+	return currency + "/" + network
+}
+
+// the network of the first completed check on a test network, empty when there is none
+func batchTestNetwork(entries []BatchReportEntry) domain.NetworkType {
+	for _, entry := range entries {
+		if entry.Report != nil && entry.Report.NetworkType.IsTest() {
+			return entry.Report.NetworkType
+		}
+	}
+	return ""
+}
@@ -3,99 +3,408 @@ package application
 import (
 	"context"
 	"fmt"
+	"sort"
+	"strconv"
+	"strings"
 	"time"
 
 	"github.com/Beka01247/bitpanda-aml/internal/domain"
 	"go.uber.org/zap"
 )
 
+const (
+	blocklistRiskScore = 100
+	blocklistCategory  = "Internal Blocklist"
+)
+
 type ProcessAMLCheckUseCase struct {
 	amlProvider       domain.AMLProvider
 	sanctionsProvider domain.SanctionsProvider
+	testAMLProvider   domain.AMLProvider
 	repository        domain.AMLCheckRepository
-	messageBus        domain.MessageBus
-	logger            *zap.SugaredLogger
+	watchlist         domain.WatchlistRepository
+	policy            *domain.Policy
+	outbox            domain.Outbox
+	auditLog          domain.AuditLog
+	// shared by the AML and sanctions lookups of a check
+	deadline time.Duration
+	logger   *zap.SugaredLogger
 }
 
 func NewProcessAMLCheckUseCase(
 	amlProvider domain.AMLProvider,
 	sanctionsProvider domain.SanctionsProvider,
+	testAMLProvider domain.AMLProvider,
 	repository domain.AMLCheckRepository,
-	messageBus domain.MessageBus,
+	watchlist domain.WatchlistRepository,
+	policy *domain.Policy,
+	outbox domain.Outbox,
+	auditLog domain.AuditLog,
+	deadline time.Duration,
 	logger *zap.SugaredLogger,
 ) *ProcessAMLCheckUseCase {
 	return &ProcessAMLCheckUseCase{
 		amlProvider:       amlProvider,
 		sanctionsProvider: sanctionsProvider,
+		testAMLProvider:   testAMLProvider,
 		repository:        repository,
-		messageBus:        messageBus,
+		watchlist:         watchlist,
+		policy:            policy,
+		outbox:            outbox,
+		auditLog:          auditLog,
+		deadline:          deadline,
 		logger:            logger,
 	}
 }
 
 // executes the process AML check use case
-func (u *ProcessAMLCheckUseCase) Execute(ctx context.Context, checkID, address, currency string) error {
-	u.logger.Infow("processing aml check", "check_id", checkID, "provider", u.amlProvider.Name())
+func (u *ProcessAMLCheckUseCase) Execute(ctx context.Context, request *domain.AMLCheckRequestedPayload) error {
+	checkID, address, currency := request.CheckID, request.Address, request.Currency
+
+	if request.BypassCache {
+		ctx = domain.ContextWithCacheBypass(ctx)
+	}
+
+	// checks on test networks never reach the paid providers and are not screened
+	amlProvider, screen := u.amlProvider, true
+	if request.NetworkType.IsTest() {
+		amlProvider, screen = u.testAMLProvider, false
+	}
 
-	startTime := time.Now()
+	u.logger.Infow("processing aml check", "check_id", checkID, "provider", amlProvider.Name(), "network_type", request.NetworkType)
 
-	// call AML provider
-	amlResult, err := u.amlProvider.CheckAddress(ctx, address, currency)
+	// our own watchlist overrides whatever the providers say
+	entry, err := u.watchlist.Match(ctx, request.Chain, address, time.Now().UTC())
 	if err != nil {
-		u.logger.Errorw("aml provider failed", "check_id", checkID, "provider", u.amlProvider.Name(), "error", err)
-		return u.publishFailedEvent(ctx, checkID, fmt.Sprintf("AML check failed: %v", err))
+		u.logger.Errorw("watchlist lookup failed", "check_id", checkID, "error", err)
+		return fmt.Errorf("watchlist lookup failed: %w", err)
+	}
+
+	if entry != nil && entry.ListType == domain.WatchlistAllowlist {
+		u.logger.Infow("address allowlisted, skipping providers", "check_id", checkID, "entry_id", entry.ID)
+		return u.enqueueCompletedEvent(ctx, request, &domain.AMLCheckCompletedPayload{
+			CheckID:    checkID,
+			RiskScore:  0,
+			RiskLevel:  domain.RiskLevelLow,
+			Categories: []string{},
+			Sanctions: &domain.SanctionsResult{
+				Hit:             false,
+				Identifications: []domain.SanctionsIdentification{},
+				Status:          domain.SanctionsSkipped,
+				Reason:          "address is allowlisted",
+			},
+			Providers:        []domain.ProviderResult{},
+			Watchlist:        entry.Match(),
+			ProviderStatuses: []domain.ProviderStatus{},
+		})
+	}
+
+	aml, sanctions := u.lookup(ctx, amlProvider, screen, address, currency, request.Chain)
+
+	// AML answer
+	var providers []domain.ProviderResult
+	amlResult, err := aml.result, aml.err
+	if err != nil {
+		u.logger.Errorw("aml provider failed", "check_id", checkID, "provider", amlProvider.Name(), "error", err)
+		if entry == nil {
+			return u.enqueueFailedEvent(ctx, checkID, fmt.Sprintf("AML check failed: %v", err))
+		}
+
+		// blocklisted addresses are Critical whether or not the provider answers
+		amlResult = &domain.AMLResult{Categories: []string{}}
+		providers = []domain.ProviderResult{{
+			Provider:   amlProvider.Name(),
+			Categories: []string{},
+			Error:      err.Error(),
+		}}
+	} else {
+		u.logger.Infow("aml provider completed",
+			"check_id", checkID,
+			"provider", amlProvider.Name(),
+			"latency_ms", aml.latency.Milliseconds(),
+			"risk_score", amlResult.RiskScore)
+
+		// single providers don't report a breakdown, record their own contribution
+		providers = amlResult.Providers
+		if len(providers) == 0 {
+			providers = []domain.ProviderResult{{
+				Provider:    amlProvider.Name(),
+				RiskScore:   amlResult.RiskScore,
+				RiskLevel:   amlResult.RiskLevel,
+				Categories:  amlResult.Categories,
+				PayloadHash: amlResult.PayloadHash,
+				Cache:       amlResult.Cache,
+			}}
+		}
+	}
+
+	statuses := make([]domain.ProviderStatus, 0, len(providers)+1)
+	for _, provider := range providers {
+		statuses = append(statuses, providerStatus(provider.Provider, domain.ProviderKindAML, aml.latency, provider.Error))
+
+		details := map[string]string{
+			"provider":     provider.Provider,
+			"risk_score":   strconv.Itoa(provider.RiskScore),
+			"risk_level":   string(provider.RiskLevel),
+			"error":        provider.Error,
+			"payload_hash": provider.PayloadHash,
+		}
+		if provider.Cache != nil {
+			details["cached_at"] = provider.Cache.CachedAt.Format(time.RFC3339)
+		}
+		recordAudit(ctx, u.auditLog, u.logger, domain.NewAuditEntry(domain.AuditProviderResponded, domain.AuditActorSystem, request.TenantID, checkID, address, details))
 	}
 
-	u.logger.Infow("aml provider completed",
-		"check_id", checkID,
-		"provider", u.amlProvider.Name(),
-		"latency_ms", time.Since(startTime).Milliseconds(),
-		"risk_score", amlResult.RiskScore)
+	// sanctions answer
+	var sanctionsResult *domain.SanctionsResult
+	if screen {
+		var sanctionsStatus domain.ProviderStatus
+		sanctionsResult, sanctionsStatus = u.sanctionsAnswer(ctx, request, sanctions)
+		statuses = append(statuses, sanctionsStatus)
+	} else {
+		sanctionsResult = &domain.SanctionsResult{
+			Hit:             false,
+			Identifications: []domain.SanctionsIdentification{},
+			Status:          domain.SanctionsSkipped,
+			Reason:          fmt.Sprintf("%s addresses are not screened", request.NetworkType),
+		}
+	}
 
-	// call Chainalysis sanctions provider
-	sanctionsStart := time.Now()
-	sanctionsResult, err := u.sanctionsProvider.CheckAddress(ctx, address)
+	// blocklisted addresses are rejected whether or not they were screened
+	if entry == nil && screen && u.policy.RequireSanctionsScreening && !sanctionsResult.Screened() {
+		u.logger.Warnw("sanctions screening required but not performed", "check_id", checkID, "status", sanctionsResult.Status, "reason", sanctionsResult.Reason)
+		return u.enqueueFailedEvent(ctx, checkID, fmt.Sprintf("sanctions screening %s: %s", sanctionsResult.Status, sanctionsResult.Reason))
+	}
+
+	result := &domain.AMLCheckCompletedPayload{
+		CheckID:          checkID,
+		RiskScore:        amlResult.RiskScore,
+		RiskLevel:        amlResult.RiskLevel,
+		Categories:       amlResult.Categories,
+		Sanctions:        sanctionsResult,
+		Providers:        providers,
+		ProviderStatuses: statuses,
+	}
+
+	if entry != nil {
+		u.logger.Infow("address blocklisted, forcing critical risk", "check_id", checkID, "entry_id", entry.ID)
+		result.RiskScore = blocklistRiskScore
+		result.RiskLevel = domain.RiskLevelCritical
+		result.Categories = append(result.Categories, blocklistCategory)
+		result.Watchlist = entry.Match()
+	}
+
+	return u.enqueueCompletedEvent(ctx, request, result)
+}
+
+// turns the sanctions lookup into the result and call status, and audits the answer
+func (u *ProcessAMLCheckUseCase) sanctionsAnswer(ctx context.Context, request *domain.AMLCheckRequestedPayload, sanctions sanctionsLookup) (*domain.SanctionsResult, domain.ProviderStatus) {
+	sanctionsResult, err := sanctions.result, sanctions.err
+	sanctionsStatus := providerStatus(u.sanctionsProvider.Name(), domain.ProviderKindSanctions, sanctions.latency, "")
 	if err != nil {
-		// sanctions failure should not break the pipeline
-		u.logger.Warnw("sanctions provider failed", "check_id", checkID, "provider", u.sanctionsProvider.Name(), "error", err)
+		// sanctions failure should not break the pipeline, the status tells it apart from a clean answer
+		u.logger.Warnw("sanctions provider failed", "check_id", request.CheckID, "provider", u.sanctionsProvider.Name(), "error", err)
 		sanctionsResult = &domain.SanctionsResult{
 			Hit:             false,
 			Identifications: []domain.SanctionsIdentification{},
+			Status:          domain.SanctionsError,
+			Provider:        u.sanctionsProvider.Name(),
+			Reason:          err.Error(),
 		}
+		sanctionsStatus = providerStatus(u.sanctionsProvider.Name(), domain.ProviderKindSanctions, sanctions.latency, err.Error())
 	} else {
 		u.logger.Infow("sanctions provider completed",
-			"check_id", checkID,
+			"check_id", request.CheckID,
 			"provider", u.sanctionsProvider.Name(),
-			"latency_ms", time.Since(sanctionsStart).Milliseconds(),
+			"latency_ms", sanctions.latency.Milliseconds(),
 			"hit", sanctionsResult.Hit)
+
+		if len(sanctionsResult.Failures) > 0 {
+			sanctionsStatus.Status = domain.ProviderCallDegraded
+			sanctionsStatus.Error = joinFailures(sanctionsResult.Failures)
+		}
+
+		// providers only say so when they didn't screen
+		result := *sanctionsResult
+		if result.Status == "" {
+			result.Status = domain.SanctionsScreened
+		}
+		if result.Provider == "" {
+			result.Provider = u.sanctionsProvider.Name()
+		}
+		sanctionsResult = &result
+	}
+
+	sanctionsDetails := map[string]string{
+		"provider":  u.sanctionsProvider.Name(),
+		"hit":       strconv.FormatBool(sanctionsResult.Hit),
+		"status":    string(sanctionsStatus.Status),
+		"screening": string(sanctionsResult.Status),
+	}
+	if sanctionsResult.Reason != "" {
+		sanctionsDetails["reason"] = sanctionsResult.Reason
+	}
+	if sanctionsStatus.Error != "" {
+		sanctionsDetails["error"] = sanctionsStatus.Error
+	}
+	for provider, hash := range sanctionsResult.PayloadHashes {
+		sanctionsDetails["payload_hash."+provider] = hash
 	}
+	for provider, info := range sanctionsResult.Cache {
+		sanctionsDetails["cached_at."+provider] = info.CachedAt.Format(time.RFC3339)
+	}
+	recordAudit(ctx, u.auditLog, u.logger, domain.NewAuditEntry(domain.AuditProviderResponded, domain.AuditActorSystem, request.TenantID, request.CheckID, request.Address, sanctionsDetails))
+
+	return sanctionsResult, sanctionsStatus
+}
+
+type amlLookup struct {
+	result  *domain.AMLResult
+	err     error
+	latency time.Duration
+}
 
-	// publish completed event
-	event := domain.NewEvent(domain.EventAMLCheckCompleted, &domain.AMLCheckCompletedPayload{
-		CheckID:    checkID,
-		RiskScore:  amlResult.RiskScore,
-		RiskLevel:  amlResult.RiskLevel,
-		Categories: amlResult.Categories,
-		Sanctions:  sanctionsResult,
+type sanctionsLookup struct {
+	result  *domain.SanctionsResult
+	err     error
+	latency time.Duration
+}
+
+// asks the AML and sanctions providers at the same time, a lookup still running
+// when the deadline passes is reported as failed and its answer is dropped
+func (u *ProcessAMLCheckUseCase) lookup(ctx context.Context, amlProvider domain.AMLProvider, screen bool, address, currency, chain string) (amlLookup, sanctionsLookup) {
+	if u.deadline > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, u.deadline)
+		defer cancel()
+	}
+
+	start := time.Now()
+
+	// buffered so a lookup that outlives the deadline doesn't block forever
+	amlDone := make(chan amlLookup, 1)
+	sanctionsDone := make(chan sanctionsLookup, 1)
+
+	go func() {
+		result, err := amlProvider.CheckAddress(ctx, address, currency, chain)
+		amlDone <- amlLookup{result: result, err: err, latency: time.Since(start)}
+	}()
+
+	var (
+		aml       *amlLookup
+		sanctions *sanctionsLookup
+	)
+
+	if screen {
+		go func() {
+			result, err := u.sanctionsProvider.CheckAddress(ctx, address, chain)
+			sanctionsDone <- sanctionsLookup{result: result, err: err, latency: time.Since(start)}
+		}()
+	} else {
+		sanctions = &sanctionsLookup{}
+	}
+	for aml == nil || sanctions == nil {
+		select {
+		case done := <-amlDone:
+			aml = &done
+		case done := <-sanctionsDone:
+			sanctions = &done
+		case <-ctx.Done():
+			err := fmt.Errorf("provider deadline exceeded: %w", ctx.Err())
+			if aml == nil {
+				aml = &amlLookup{err: err, latency: time.Since(start)}
+			}
+			if sanctions == nil {
+				sanctions = &sanctionsLookup{err: err, latency: time.Since(start)}
+			}
+		}
+	}
+
+	return *aml, *sanctions
+}
+
+func providerStatus(provider string, kind domain.ProviderKind, latency time.Duration, errorMessage string) domain.ProviderStatus {
+	status := domain.ProviderStatus{
+		Provider:  provider,
+		Kind:      kind,
+		Status:    domain.ProviderCallOK,
+		LatencyMs: latency.Milliseconds(),
+		Error:     errorMessage,
+	}
+	if errorMessage != "" {
+		status.Status = domain.ProviderCallUnavailable
+	}
+	return status
+}
+
+// "provider: error" pairs in a stable order
+func joinFailures(failures map[string]string) string {
+	names := make([]string, 0, len(failures))
+	for name := range failures {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	parts := make([]string, 0, len(names))
+	for _, name := range names {
+		parts = append(parts, name+": "+failures[name])
+	}
+	return strings.Join(parts, "; ")
+}
+
+// applies the policy before publishing so every consumer sees the same decision
+func (u *ProcessAMLCheckUseCase) enqueueCompletedEvent(ctx context.Context, request *domain.AMLCheckRequestedPayload, result *domain.AMLCheckCompletedPayload) error {
+	evaluation := u.policy.Evaluate(domain.PolicyInput{
+		RiskScore:  result.RiskScore,
+		RiskLevel:  result.RiskLevel,
+		Categories: result.Categories,
+		Sanctions:  result.Sanctions,
+		Asset:      request.Currency,
+		Chain:      request.Chain,
+		Watchlist:  result.Watchlist,
 	})
+	result.Decision = evaluation.Decision
+	result.MatchedRules = evaluation.MatchedRules
+
+	u.logger.Infow("policy evaluated",
+		"check_id", result.CheckID,
+		"decision", result.Decision,
+		"matched_rules", len(result.MatchedRules))
+
+	decisionDetails := map[string]string{
+		"decision":      string(result.Decision),
+		"risk_score":    strconv.Itoa(result.RiskScore),
+		"risk_level":    string(result.RiskLevel),
+		"sanctions_hit": strconv.FormatBool(result.Sanctions != nil && result.Sanctions.Hit),
+		"matched_rules": auditRuleIDs(result.MatchedRules),
+	}
+	if result.Sanctions != nil {
+		decisionDetails["sanctions_screening"] = string(result.Sanctions.Status)
+	}
+	if result.Watchlist != nil {
+		decisionDetails["watchlist_entry_id"] = result.Watchlist.EntryID
+	}
+	recordAudit(ctx, u.auditLog, u.logger, domain.NewAuditEntry(domain.AuditCheckDecided, domain.AuditActorSystem, request.TenantID, result.CheckID, request.Address, decisionDetails))
+
+	event := domain.NewEvent(domain.EventAMLCheckCompleted, result)
 
-	if err := u.messageBus.Publish(ctx, domain.EventAMLCheckCompleted, event); err != nil {
-		u.logger.Errorw("failed to publish completed event", "check_id", checkID, "error", err)
-		return fmt.Errorf("failed to publish completed event: %w", err)
+	if err := u.outbox.Enqueue(ctx, domain.NewOutboxMessage(domain.EventAMLCheckCompleted, event)); err != nil {
+		u.logger.Errorw("failed to enqueue completed event", "check_id", result.CheckID, "error", err)
+		return fmt.Errorf("failed to enqueue completed event: %w", err)
 	}
 
 	return nil
 }
 
-func (u *ProcessAMLCheckUseCase) publishFailedEvent(ctx context.Context, checkID, errorMessage string) error {
+func (u *ProcessAMLCheckUseCase) enqueueFailedEvent(ctx context.Context, checkID, errorMessage string) error {
 	event := domain.NewEvent(domain.EventAMLCheckFailed, &domain.AMLCheckFailedPayload{
 		CheckID:      checkID,
 		ErrorMessage: errorMessage,
 	})
 
-	if err := u.messageBus.Publish(ctx, domain.EventAMLCheckFailed, event); err != nil {
-		u.logger.Errorw("failed to publish failed event", "check_id", checkID, "error", err)
-		return fmt.Errorf("failed to publish failed event: %w", err)
+	if err := u.outbox.Enqueue(ctx, domain.NewOutboxMessage(domain.EventAMLCheckFailed, event)); err != nil {
+		u.logger.Errorw("failed to enqueue failed event", "check_id", checkID, "error", err)
+		return fmt.Errorf("failed to enqueue failed event: %w", err)
 	}
 
 	return fmt.Errorf("%s", errorMessage)
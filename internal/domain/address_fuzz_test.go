@@ -0,0 +1,240 @@
+package domain
+
+import (
+	"encoding/hex"
+	"errors"
+	"strconv"
+	"strings"
+	"testing"
+)
+
+func FuzzBase58Decode(f *testing.F) {
+	for _, seed := range []string{"1A1zP1eP5QGefi2DMPTfTL5SLmv7DivfNa", "3J98t1WpEZ73CNmQviecrnyiWrnqRhWNLy", "111", "z", "0OIl"} {
+		f.Add(seed)
+	}
+
+	f.Fuzz(func(t *testing.T, value string) {
+		decoded, err := bitcoinBase58.decode(value)
+		if err != nil {
+			if !errors.Is(err, ErrInvalidAddress) {
+				t.Fatalf("decode(%q) error = %v, want ErrInvalidAddress", value, err)
+			}
+			return
+		}
+
+		if encoded := bitcoinBase58.encode(decoded); encoded != value {
+			t.Fatalf("encode(decode(%q)) = %q", value, encoded)
+		}
+	})
+}
+
+func FuzzBase58CheckDecode(f *testing.F) {
+	for _, seed := range []string{"1A1zP1eP5QGefi2DMPTfTL5SLmv7DivfNa", "3J98t1WpEZ73CNmQviecrnyiWrnqRhWNLy", "3J98t1WpEZ73CNmYviecrnyiWrnqRhWNLy", "1111"} {
+		f.Add(seed)
+	}
+
+	f.Fuzz(func(t *testing.T, value string) {
+		version, payload, err := bitcoinBase58.decodeCheck(value)
+		if err != nil {
+			return
+		}
+
+		if encoded := bitcoinBase58.encodeCheck(version, payload); encoded != value {
+			t.Fatalf("encodeCheck(decodeCheck(%q)) = %q", value, encoded)
+		}
+	})
+}
+
+func FuzzBech32Decode(f *testing.F) {
+	for _, seed := range []string{
+		"bc1qar0srrr7xfkvy5l643lydnw9re59gtzzwf5mdq",
+		"BC1QW508D6QEJXTDG4Y5R3ZARVARY0C5XW7KV8F3T4",
+		"bc1p0xlxvlhemja6c4dqv22uapctqupfhlxm9h8z3k2e72q4k9hcz7vqzk5jj0",
+		"a12uel5l",
+		"bc1gmk9yu",
+	} {
+		f.Add(seed)
+	}
+
+	f.Fuzz(func(t *testing.T, value string) {
+		hrp, data, encoding, err := bech32Decode(value)
+		if err != nil {
+			if !errors.Is(err, ErrInvalidAddress) {
+				t.Fatalf("bech32Decode(%q) error = %v, want ErrInvalidAddress", value, err)
+			}
+			return
+		}
+
+		if encoded := bech32Encode(hrp, data, encoding); encoded != strings.ToLower(value) {
+			t.Fatalf("bech32Encode(bech32Decode(%q)) = %q", value, encoded)
+		}
+	})
+}
+
+func FuzzSegwitDecode(f *testing.F) {
+	for _, seed := range []string{
+		"bc1qar0srrr7xfkvy5l643lydnw9re59gtzzwf5mdq",
+		"bc1qrp33g0q5c5txsp9arysrx4k6zdkfs4nce4xj0gdcccefvpysxf3qccfmv3",
+		"bc1p0xlxvlhemja6c4dqv22uapctqupfhlxm9h8z3k2e72q4k9hcz7vqzk5jj0",
+		"bc1zw508d6qejxtdg4y5r3zarvaryvqyzf3du",
+	} {
+		f.Add(seed)
+	}
+
+	f.Fuzz(func(t *testing.T, value string) {
+		version, program, err := decodeSegwitAddress("bc", value)
+		if err != nil {
+			return
+		}
+
+		encoded, err := encodeSegwitAddress("bc", version, program)
+		if err != nil {
+			t.Fatalf("encodeSegwitAddress() error = %v", err)
+		}
+		if encoded != strings.ToLower(value) {
+			t.Fatalf("encodeSegwitAddress(decodeSegwitAddress(%q)) = %q", value, encoded)
+		}
+	})
+}
+
+func FuzzCashAddrDecode(f *testing.F) {
+	for _, seed := range []string{
+		"bitcoincash:qpm2qsznhks23z7629mms6s4cwef74vcwvy22gdx6a",
+		"BITCOINCASH:PPM2QSZNHKS23Z7629MMS6S4CWEF74VCWVN0H829PQ",
+		"qr95sy3j9xwd2ap32xkykttr4cvcu7as4y0qverfuy",
+		"bitcoincash:pvg3zyg3zyg3zyg3zyg3zyg3zyg3zyg3zyg3zyg3zyg3zyg3zyg3zch7f55mh",
+	} {
+		f.Add(seed)
+	}
+
+	f.Fuzz(func(t *testing.T, value string) {
+		version, hash, err := decodeCashAddr("bitcoincash", value)
+		if err != nil {
+			if !errors.Is(err, ErrInvalidAddress) {
+				t.Fatalf("decodeCashAddr(%q) error = %v, want ErrInvalidAddress", value, err)
+			}
+			return
+		}
+
+		want := strings.ToLower(value)
+		if !strings.HasPrefix(want, "bitcoincash:") {
+			want = "bitcoincash:" + want
+		}
+
+		encoded, err := encodeCashAddr("bitcoincash", version, hash)
+		if err != nil {
+			t.Fatalf("encodeCashAddr() error = %v", err)
+		}
+		if encoded != want {
+			t.Fatalf("encodeCashAddr(decodeCashAddr(%q)) = %q", value, encoded)
+		}
+	})
+}
+
+func FuzzXAddressDecode(f *testing.F) {
+	for _, seed := range []string{
+		"XVLhHMPHU98es4dbozjVtdWzVrDjtV5fdx1mHp98tDMoQXb",
+		"XVLhHMPHU98es4dbozjVtdWzVrDjtV8xvjGQTYPiAx6gwDC",
+		"XVLhHMPHU98es4dbozjVtdWzVrDjtV18pX8yuPT7y4xaEHi",
+	} {
+		f.Add(seed)
+	}
+
+	f.Fuzz(func(t *testing.T, value string) {
+		accountID, tag, err := decodeXAddress(value)
+		if err != nil {
+			return
+		}
+
+		var rawTag *uint32
+		if tag != "" {
+			parsed, err := strconv.ParseUint(tag, 10, 32)
+			if err != nil {
+				t.Fatalf("decodeXAddress(%q) tag = %q, not a 32-bit number", value, tag)
+			}
+			value32 := uint32(parsed)
+			rawTag = &value32
+		}
+
+		if encoded := encodeXAddress(accountID, rawTag); encoded != value {
+			t.Fatalf("encodeXAddress(decodeXAddress(%q)) = %q", value, encoded)
+		}
+	})
+}
+
+func FuzzStrkeyDecode(f *testing.F) {
+	for _, seed := range []string{
+		"GA7QYNF7SOWQ3GLR2BGMZEHXAVIRZA4KVWLTJJFC7MGXUA74P7UJVSGZ",
+		"GAAZI4TCR3TY5OJHCTJC2A4QSY6CJWJH5IAJTGKIN2ER7LBNVKOCCWN7",
+		"GAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAWHF",
+	} {
+		f.Add(seed)
+	}
+
+	f.Fuzz(func(t *testing.T, value string) {
+		payload, err := decodeStrkey(strkeyAccountID, value)
+		if err != nil {
+			if !errors.Is(err, ErrInvalidAddress) {
+				t.Fatalf("decodeStrkey(%q) error = %v, want ErrInvalidAddress", value, err)
+			}
+			return
+		}
+
+		// only canonical strings decode, so encoding gives the input back
+		if encoded := encodeStrkey(strkeyAccountID, payload); encoded != value {
+			t.Fatalf("encodeStrkey(decodeStrkey(%q)) = %q", value, encoded)
+		}
+	})
+}
+
+func FuzzTonAddressParse(f *testing.F) {
+	for _, seed := range []string{
+		"EQCD39VS5jcptHL8vMjEXrzGaRcCVYto7HUn4bpAOg8xqB2N",
+		"UQCD39VS5jcptHL8vMjEXrzGaRcCVYto7HUn4bpAOg8xqEBI",
+		"0:83dfd552e63729b472fcbcc8c45ebcc6691702558b68ec7527e1ba403a0f31a8",
+	} {
+		f.Add(seed)
+	}
+
+	f.Fuzz(func(t *testing.T, value string) {
+		parsed, err := parseTonAddress(value)
+		if err != nil {
+			if !errors.Is(err, ErrInvalidAddress) {
+				t.Fatalf("parseTonAddress(%q) error = %v, want ErrInvalidAddress", value, err)
+			}
+			return
+		}
+
+		// every user-friendly form parses back to the same raw address
+		for _, bounceable := range []bool{true, false} {
+			again, err := parseTonAddress(parsed.friendly(bounceable))
+			if err != nil || again.raw() != parsed.raw() {
+				t.Fatalf("parseTonAddress(friendly(%q)) = %q, %v", value, again.raw(), err)
+			}
+		}
+	})
+}
+
+func FuzzEIP55Checksum(f *testing.F) {
+	f.Add([]byte("\x5a\xae\xb6\x05\x3f\x3e\x94\xc9\xb9\xa0\x9f\x33\x66\x94\x35\xe7\xef\x1b\xea\xed"))
+	f.Add(make([]byte, 20))
+
+	f.Fuzz(func(t *testing.T, raw []byte) {
+		if len(raw) != 20 {
+			return
+		}
+
+		address := "0x" + hex.EncodeToString(raw)
+		checksummed := eip55Checksum(address)
+
+		if err := validateEVMAddress(checksummed); err != nil {
+			t.Fatalf("validateEVMAddress(%q) error = %v", checksummed, err)
+		}
+		if eip55Checksum(checksummed) != checksummed {
+			t.Fatalf("eip55Checksum is not idempotent for %q", checksummed)
+		}
+		if !strings.EqualFold(checksummed, address) {
+			t.Fatalf("eip55Checksum(%q) = %q changed more than case", address, checksummed)
+		}
+	})
+}
@@ -23,6 +23,21 @@ const (
 	RiskLevelCritical RiskLevel = "Critical"
 )
 
+// orders risk levels from least to most severe
+func (l RiskLevel) Rank() int {
+	switch l {
+	case RiskLevelLow:
+		return 1
+	case RiskLevelMedium:
+		return 2
+	case RiskLevelHigh:
+		return 3
+	case RiskLevelCritical:
+		return 4
+	}
+	return 0
+}
+
 func DeriveRiskLevel(score int) RiskLevel {
 	if score >= 80 {
 		return RiskLevelCritical
@@ -34,9 +49,36 @@ func DeriveRiskLevel(score int) RiskLevel {
 	return RiskLevelLow
 }
 
+type SanctionsStatus string
+
+const (
+	SanctionsScreened SanctionsStatus = "screened"
+	// no source was asked, e.g. none is configured or the address is allowlisted
+	SanctionsSkipped SanctionsStatus = "skipped"
+	// the sources failed, a clean result does not mean the address is clean
+	SanctionsError SanctionsStatus = "error"
+)
+
 type SanctionsResult struct {
 	Hit             bool                      `json:"hit"`
 	Identifications []SanctionsIdentification `json:"identifications"`
+	// results stored before screening statuses existed have none and count as screened
+	Status SanctionsStatus `json:"status,omitempty"`
+	// source that was asked or should have been
+	Provider string `json:"provider,omitempty"`
+	// why screening was skipped or failed
+	Reason string `json:"reason,omitempty"`
+	// hash of each raw provider response by provider name
+	PayloadHashes map[string]string `json:"payload_hashes,omitempty"`
+	// providers whose answer came from cache, by provider name
+	Cache map[string]CacheInfo `json:"cache,omitempty"`
+	// sources that failed while others answered, error by provider name
+	Failures map[string]string `json:"failures,omitempty"`
+}
+
+// false when the address was never actually compared against a sanctions list
+func (r *SanctionsResult) Screened() bool {
+	return r != nil && (r.Status == SanctionsScreened || r.Status == "")
 }
 
 type SanctionsIdentification struct {
@@ -45,8 +87,49 @@ type SanctionsIdentification struct {
 	URL      string `json:"url"`
 }
 
+// what a single AML provider contributed to a (possibly aggregated) result
+type ProviderResult struct {
+	Provider   string    `json:"provider"`
+	RiskScore  int       `json:"risk_score"`
+	RiskLevel  RiskLevel `json:"risk_level"`
+	Categories []string  `json:"categories"`
+	Weight     float64   `json:"weight,omitempty"`
+	Error      string    `json:"error,omitempty"`
+	// hash of the raw provider response
+	PayloadHash string `json:"payload_hash,omitempty"`
+	// set when the answer came from cache
+	Cache *CacheInfo `json:"cache,omitempty"`
+}
+
+type ProviderKind string
+
+const (
+	ProviderKindAML       ProviderKind = "aml"
+	ProviderKindSanctions ProviderKind = "sanctions"
+)
+
+type ProviderCallStatus string
+
+const (
+	ProviderCallOK ProviderCallStatus = "ok"
+	// some sources of a combined provider answered, others failed
+	ProviderCallDegraded ProviderCallStatus = "degraded"
+	// failed or ran past the check's deadline, its answer is missing from the result
+	ProviderCallUnavailable ProviderCallStatus = "unavailable"
+)
+
+// how a provider lookup of a check went
+type ProviderStatus struct {
+	Provider  string             `json:"provider"`
+	Kind      ProviderKind       `json:"kind"`
+	Status    ProviderCallStatus `json:"status"`
+	LatencyMs int64              `json:"latency_ms"`
+	Error     string             `json:"error,omitempty"`
+}
+
 type AMLCheck struct {
 	ID           string
+	TenantID     string
 	Address      string
 	Currency     string
 	Status       AMLCheckStatus
@@ -54,25 +137,41 @@ type AMLCheck struct {
 	RiskLevel    RiskLevel
 	Categories   []string
 	Sanctions    *SanctionsResult
+	Providers    []ProviderResult
+	Watchlist    *WatchlistMatch
+	Decision     Decision
+	MatchedRules []MatchedRule
+	// where the outcome is POSTed, empty means the client polls
+	CallbackURL  string
 	ReportKey    string
 	ErrorMessage string
 	CreatedAt    time.Time
 	UpdatedAt    time.Time
 	ExpiresAt    time.Time
+	// outcome of each provider lookup, unavailable ones are missing from the result
+	ProviderStatuses []ProviderStatus
+	// chain the currency was screened on, empty for checks created before networks
+	Chain string
+	// destination tag, memo or comment that identifies the account behind a shared address
+	Tag string
+	// mainnet, testnet or regtest, empty counts as mainnet
+	NetworkType NetworkType
 }
 
 func NewAMLCheck(address, currency string, ttl time.Duration) *AMLCheck {
 	now := time.Now().UTC()
 	return &AMLCheck{
-		ID:         uuid.New().String(),
-		Address:    address,
-		Currency:   currency,
-		Status:     StatusProcessing,
-		CreatedAt:  now,
-		UpdatedAt:  now,
-		ExpiresAt:  now.Add(ttl),
-		Sanctions:  &SanctionsResult{Hit: false, Identifications: []SanctionsIdentification{}},
-		Categories: []string{},
+		ID:           uuid.New().String(),
+		Address:      address,
+		Currency:     currency,
+		Status:       StatusProcessing,
+		CreatedAt:    now,
+		UpdatedAt:    now,
+		ExpiresAt:    now.Add(ttl),
+		Sanctions:    &SanctionsResult{Hit: false, Identifications: []SanctionsIdentification{}},
+		Categories:   []string{},
+		Providers:    []ProviderResult{},
+		MatchedRules: []MatchedRule{},
 	}
 }
 
@@ -144,4 +144,24 @@ func TestAMLCheck_IsExpired(t *testing.T) {
 	})
 }
 
+func TestSanctionsResult_Screened(t *testing.T) {
+	tests := []struct {
+		name   string
+		result *SanctionsResult
+		want   bool
+	}{
+		{"nil", nil, false},
+		{"screened", &SanctionsResult{Status: SanctionsScreened}, true},
+		{"stored without status", &SanctionsResult{}, true},
+		{"skipped", &SanctionsResult{Status: SanctionsSkipped}, false},
+		{"error", &SanctionsResult{Status: SanctionsError}, false},
+	}
 
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.result.Screened(); got != tt.want {
+				t.Errorf("Screened() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
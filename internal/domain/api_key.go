@@ -0,0 +1,166 @@
+package domain
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"regexp"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+var (
+	ErrAPIKeyNotFound = errors.New("api key not found")
+	// unknown, revoked or expired key, deliberately not more specific
+	ErrInvalidAPIKey = errors.New("invalid api key")
+	ErrInvalidTenant = errors.New("invalid tenant id")
+)
+
+const (
+	apiKeyPrefix = "aml_"
+	// how much of the key is kept in clear text to tell keys apart
+	apiKeyDisplayLength = 12
+)
+
+var tenantIDPattern = regexp.MustCompile(`^[a-z0-9][a-z0-9_-]{0,63}$`)
+
+// tenant ids end up in report keys, keep them to a safe slug
+func ValidateTenantID(tenantID string) error {
+	if !tenantIDPattern.MatchString(tenantID) {
+		return fmt.Errorf("%w: must be 1-64 lowercase letters, digits, '-' or '_'", ErrInvalidTenant)
+	}
+	return nil
+}
+
+// keys without a tenant, i.e. ADMIN_API_KEY, operate across all tenants
+func TenantVisible(callerTenantID, tenantID string) bool {
+	return callerTenantID == "" || callerTenantID == tenantID
+}
+
+// a tenant scoped credential, only the hash of the secret is stored
+type APIKey struct {
+	ID       string
+	TenantID string
+	Name     string
+	// first characters of the key, safe to display
+	Prefix string
+	Hash   string
+	// default webhook for checks created with this key
+	CallbackURL string
+	Roles       []Role
+	CreatedAt   time.Time
+	// set when the key is rotated out, it keeps working until then
+	ExpiresAt *time.Time
+	RevokedAt *time.Time
+}
+
+// returns the key and its secret, the secret is never stored and can't be recovered,
+// keys without roles are integrator keys
+func NewAPIKey(tenantID, name, callbackURL string, roles []Role) (*APIKey, string, error) {
+	if err := ValidateTenantID(tenantID); err != nil {
+		return nil, "", err
+	}
+
+	if callbackURL != "" {
+		if err := ValidateCallbackURL(callbackURL); err != nil {
+			return nil, "", err
+		}
+	}
+
+	if len(roles) == 0 {
+		roles = []Role{RoleIntegrator}
+	}
+	for _, role := range roles {
+		if _, err := ParseRole(string(role)); err != nil {
+			return nil, "", err
+		}
+		// admin reaches every tenant, a tenant's key gets tenant_admin instead
+		if role == RoleAdmin {
+			return nil, "", fmt.Errorf("%w: %s is reserved for ADMIN_API_KEY, use %s", ErrInvalidRole, RoleAdmin, RoleTenantAdmin)
+		}
+	}
+
+	random := make([]byte, 32)
+	if _, err := rand.Read(random); err != nil {
+		return nil, "", fmt.Errorf("failed to generate api key: %w", err)
+	}
+	secret := apiKeyPrefix + hex.EncodeToString(random)
+
+	return &APIKey{
+		ID:          uuid.New().String(),
+		TenantID:    tenantID,
+		Name:        strings.TrimSpace(name),
+		Prefix:      secret[:apiKeyDisplayLength],
+		Hash:        HashAPIKey(secret),
+		CallbackURL: callbackURL,
+		Roles:       slices.Compact(slices.Sorted(slices.Values(roles))),
+		CreatedAt:   time.Now().UTC(),
+	}, secret, nil
+}
+
+// keys are 256 bit random values, a plain SHA-256 is enough and allows lookups by hash
+func HashAPIKey(secret string) string {
+	sum := sha256.Sum256([]byte(secret))
+	return hex.EncodeToString(sum[:])
+}
+
+func (k *APIKey) IsActive(now time.Time) bool {
+	if k.RevokedAt != nil {
+		return false
+	}
+	return k.ExpiresAt == nil || now.Before(*k.ExpiresAt)
+}
+
+func (k *APIKey) Can(permission Permission) bool {
+	return RolesCan(k.Roles, permission)
+}
+
+func (k *APIKey) Revoke() {
+	if k.RevokedAt == nil {
+		now := time.Now().UTC()
+		k.RevokedAt = &now
+	}
+}
+
+// keeps the key usable until at, used to give clients time to switch after a rotation
+func (k *APIKey) ExpireAt(at time.Time) {
+	if k.ExpiresAt == nil || at.Before(*k.ExpiresAt) {
+		k.ExpiresAt = &at
+	}
+}
+
+type APIKeyFilter struct {
+	TenantID string
+}
+
+func (f APIKeyFilter) Matches(k *APIKey) bool {
+	return f.TenantID == "" || f.TenantID == k.TenantID
+}
+
+// report keys carry their tenant, "<tenant>.<check id>.pdf"
+func ReportKey(tenantID, checkID string) string {
+	return fmt.Sprintf("%s.%s.pdf", tenantID, checkID)
+}
+
+// the tenant a report key belongs to, empty for reports stored before tenancy
+func ReportTenant(reportKey string) string {
+	tenantID, rest, found := strings.Cut(reportKey, ".")
+	if !found || rest == "pdf" {
+		return ""
+	}
+	return tenantID
+}
+
+// the check a report key belongs to, for both the tenant and the legacy form
+func ReportCheckID(reportKey string) string {
+	key := strings.TrimSuffix(reportKey, ".pdf")
+	if _, checkID, found := strings.Cut(key, "."); found {
+		return checkID
+	}
+	return key
+}
@@ -0,0 +1,93 @@
+package domain
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestNewAPIKey(t *testing.T) {
+	key, secret, err := NewAPIKey("acme", " backend ", "", nil)
+	if err != nil {
+		t.Fatalf("NewAPIKey() error = %v", err)
+	}
+
+	if !strings.HasPrefix(secret, key.Prefix) || key.Hash != HashAPIKey(secret) || key.Hash == secret {
+		t.Errorf("NewAPIKey() = %+v, secret %q", key, secret)
+	}
+	if key.Name != "backend" {
+		t.Errorf("Name = %q, want trimmed", key.Name)
+	}
+	if len(key.Roles) != 1 || key.Roles[0] != RoleIntegrator {
+		t.Errorf("Roles = %v, want [integrator]", key.Roles)
+	}
+
+	if _, _, err := NewAPIKey("Acme Corp", "", "", nil); !errors.Is(err, ErrInvalidTenant) {
+		t.Errorf("NewAPIKey() invalid tenant error = %v, want %v", err, ErrInvalidTenant)
+	}
+	if _, _, err := NewAPIKey("acme", "", "", []Role{"root"}); !errors.Is(err, ErrInvalidRole) {
+		t.Errorf("NewAPIKey() invalid role error = %v, want %v", err, ErrInvalidRole)
+	}
+	if _, _, err := NewAPIKey("acme", "", "", []Role{RoleAdmin}); !errors.Is(err, ErrInvalidRole) {
+		t.Errorf("NewAPIKey() admin role error = %v, want %v", err, ErrInvalidRole)
+	}
+	if _, _, err := NewAPIKey("acme", "", "http://10.0.0.1/hook", nil); !errors.Is(err, ErrInvalidCallbackURL) {
+		t.Errorf("NewAPIKey() private callback error = %v, want %v", err, ErrInvalidCallbackURL)
+	}
+}
+
+func TestAPIKeyIsActive(t *testing.T) {
+	now := time.Now().UTC()
+
+	key, _, _ := NewAPIKey("acme", "", "", nil)
+	if !key.IsActive(now) {
+		t.Error("new key is not active")
+	}
+
+	key.ExpireAt(now.Add(time.Hour))
+	if !key.IsActive(now) || key.IsActive(now.Add(2*time.Hour)) {
+		t.Error("expiring key should only be active before its expiry")
+	}
+
+	key.Revoke()
+	if key.IsActive(now) {
+		t.Error("revoked key is active")
+	}
+}
+
+func TestTenantVisible(t *testing.T) {
+	tests := []struct {
+		caller string
+		tenant string
+		want   bool
+	}{
+		{"acme", "acme", true},
+		{"acme", "globex", false},
+		{"acme", "", false},
+		{"", "globex", true},
+	}
+
+	for _, tt := range tests {
+		if got := TenantVisible(tt.caller, tt.tenant); got != tt.want {
+			t.Errorf("TenantVisible(%q, %q) = %v, want %v", tt.caller, tt.tenant, got, tt.want)
+		}
+	}
+}
+
+func TestReportTenant(t *testing.T) {
+	tests := []struct {
+		key  string
+		want string
+	}{
+		{ReportKey("acme", "id"), "acme"},
+		{"id.pdf", ""},
+		{"id", ""},
+	}
+
+	for _, tt := range tests {
+		if got := ReportTenant(tt.key); got != tt.want {
+			t.Errorf("ReportTenant(%q) = %q, want %q", tt.key, got, tt.want)
+		}
+	}
+}
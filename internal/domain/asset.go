@@ -3,127 +3,766 @@ package domain
 import (
 	"errors"
 	"fmt"
-	"regexp"
+	"strconv"
 	"strings"
+	"unicode/utf8"
 )
 
 var (
 	ErrInvalidAddress      = errors.New("invalid address format")
 	ErrUnsupportedCurrency = errors.New("unsupported currency")
+	ErrInvalidAssetConfig  = errors.New("invalid asset config")
+	ErrInvalidTag          = errors.New("invalid tag")
 )
 
 // represents a cryptocurrency asset
 type Asset interface {
 	Symbol() string
 	Chain() string
+	NetworkType() NetworkType
 	ValidateAddress(address string) error
 	NormalizeAddress(address string) string
 }
 
+// an asset whose deposits are told apart by a destination tag, memo or comment
+// next to the address, usually because the address is an exchange's omnibus wallet
+type TaggedAsset interface {
+	Asset
+	// what the chain calls it, e.g. destination tag or memo
+	TagName() string
+	// only called with a non-empty tag
+	ValidateTag(tag string) error
+	NormalizeTag(tag string) string
+	// the tag carried by the address itself, e.g. an XRP X-address, empty when there is none
+	AddressTag(address string) string
+}
+
+// the normalized tag of a check, a tag inside the address counts as if it was sent
+// separately and must not contradict the one that was
+func ResolveTag(asset Asset, address, tag string) (string, error) {
+	tag = strings.TrimSpace(tag)
+
+	tagged, ok := asset.(TaggedAsset)
+	if !ok {
+		if tag != "" {
+			return "", fmt.Errorf("%w: %s addresses take no tag or memo", ErrInvalidTag, asset.Symbol())
+		}
+		return "", nil
+	}
+
+	if embedded := tagged.AddressTag(address); embedded != "" {
+		if tag != "" && tagged.NormalizeTag(tag) != embedded {
+			return "", fmt.Errorf("%w: %s %s differs from %s in the address", ErrInvalidTag, tagged.TagName(), tag, embedded)
+		}
+		tag = embedded
+	}
+
+	if tag == "" {
+		return "", nil
+	}
+	if err := tagged.ValidateTag(tag); err != nil {
+		return "", err
+	}
+	return tagged.NormalizeTag(tag), nil
+}
+
 // manages supported assets
 type AssetRegistry interface {
+	// the default network of the symbol
 	Get(symbol string) (Asset, error)
+	// a token issued on several chains or a network type such as testnet, an empty
+	// network picks the default
+	Resolve(symbol, network string) (Asset, error)
 	List() []Asset
 }
 
-// btc implementation
-type Bitcoin struct{}
+// btc implementation, the zero value is mainnet
+type Bitcoin struct {
+	Network NetworkType
+}
+
+// version bytes and segwit prefix of a bitcoin network
+type bitcoinParams struct {
+	p2pkh byte
+	p2sh  byte
+	hrp   string
+}
 
 func (b Bitcoin) Symbol() string { return "BTC" }
-func (b Bitcoin) Chain() string  { return "bitcoin" }
+
+// bitcoin, bitcoin-testnet or bitcoin-regtest
+func (b Bitcoin) Chain() string {
+	if network := b.NetworkType(); network != NetworkMainnet {
+		return "bitcoin-" + string(network)
+	}
+	return "bitcoin"
+}
+
+func (b Bitcoin) NetworkType() NetworkType {
+	if b.Network == "" {
+		return NetworkMainnet
+	}
+	return b.Network
+}
+
+// testnet and signet share m/n, 2 and tb1, regtest differs only in its bcrt1 prefix
+func (b Bitcoin) params() bitcoinParams {
+	switch b.NetworkType() {
+	case NetworkTestnet:
+		return bitcoinParams{p2pkh: 0x6f, p2sh: 0xc4, hrp: "tb"}
+	case NetworkRegtest:
+		return bitcoinParams{p2pkh: 0x6f, p2sh: 0xc4, hrp: "bcrt"}
+	default:
+		return bitcoinParams{p2pkh: 0x00, p2sh: 0x05, hrp: "bc"}
+	}
+}
 
 func (b Bitcoin) ValidateAddress(address string) error {
 	if address == "" {
-		return ErrInvalidAddress
-	}
-	// base58 (legacy): starts with 1 or 3, length 26-35
-	// bech32 (native segwit): starts with bc1, length 42-62
-	if (strings.HasPrefix(address, "1") || strings.HasPrefix(address, "3")) && len(address) >= 26 && len(address) <= 35 {
-		matched, _ := regexp.MatchString(`^[13][a-km-zA-HJ-NP-Z1-9]{25,34}$`, address)
-		if matched {
-			return nil
-		}
+		return fmt.Errorf("%w: address is empty", ErrInvalidAddress)
+	}
+	err := b.validate(address)
+	if err == nil {
+		return nil
 	}
-	if strings.HasPrefix(address, "bc1") && len(address) >= 42 && len(address) <= 62 {
-		matched, _ := regexp.MatchString(`^bc1[a-z0-9]{39,59}$`, address)
-		if matched {
-			return nil
+
+	// a valid address of another network is a wrong network, not a typo
+	for _, network := range []NetworkType{NetworkMainnet, NetworkTestnet, NetworkRegtest} {
+		other := Bitcoin{Network: network}
+		if network != b.NetworkType() && other.validate(address) == nil {
+			return fmt.Errorf("%w: address belongs to bitcoin %s, not %s", ErrInvalidAddress, network, b.NetworkType())
 		}
 	}
-	return ErrInvalidAddress
+	return err
 }
 
+func (b Bitcoin) validate(address string) error {
+	params := b.params()
+	// bech32 (native segwit) and bech32m (taproot): bc1..., tb1... or bcrt1...
+	if strings.HasPrefix(strings.ToLower(address), params.hrp+"1") {
+		_, _, err := decodeSegwitAddress(params.hrp, address)
+		return err
+	}
+	// base58check (legacy): P2PKH starts with 1 (m or n on test networks), P2SH with 3 (2)
+	_, _, err := decodeBase58Hash(address, params.p2pkh, params.p2sh)
+	return err
+}
+
+// decodes a legacy base58check address whose version byte is either the P2PKH or the P2SH one
+func decodeBase58Hash(address string, p2pkh, p2sh byte) (byte, []byte, error) {
+	version, payload, err := bitcoinBase58.decodeCheck(address)
+	if err != nil {
+		return 0, nil, err
+	}
+	if version != p2pkh && version != p2sh {
+		return 0, nil, fmt.Errorf("%w: version byte 0x%02x is neither P2PKH (0x%02x) nor P2SH (0x%02x)", ErrInvalidAddress, version, p2pkh, p2sh)
+	}
+	if len(payload) != 20 {
+		return 0, nil, fmt.Errorf("%w: hash is %d bytes, want 20", ErrInvalidAddress, len(payload))
+	}
+	return version, payload, nil
+}
+
+// bech32 may be written in upper case, base58 is case sensitive
 func (b Bitcoin) NormalizeAddress(address string) string {
+	address = strings.TrimSpace(address)
+	if strings.HasPrefix(strings.ToLower(address), b.params().hrp+"1") {
+		return strings.ToLower(address)
+	}
+	return address
+}
+
+// trx implementation
+type Tron struct{}
+
+func (t Tron) Symbol() string           { return "TRX" }
+func (t Tron) Chain() string            { return "tron" }
+func (t Tron) NetworkType() NetworkType { return NetworkMainnet }
+
+// base58check with version byte 0x41, which puts a T in front
+func (t Tron) ValidateAddress(address string) error {
+	if address == "" {
+		return fmt.Errorf("%w: address is empty", ErrInvalidAddress)
+	}
+	if !strings.HasPrefix(address, "T") {
+		return fmt.Errorf("%w: tron address must start with T", ErrInvalidAddress)
+	}
+	version, payload, err := bitcoinBase58.decodeCheck(address)
+	if err != nil {
+		return err
+	}
+	if version != 0x41 {
+		return fmt.Errorf("%w: version byte 0x%02x, want 0x41", ErrInvalidAddress, version)
+	}
+	if len(payload) != 20 {
+		return fmt.Errorf("%w: hash is %d bytes, want 20", ErrInvalidAddress, len(payload))
+	}
+	return nil
+}
+
+// base58 is case sensitive
+func (t Tron) NormalizeAddress(address string) string {
 	return strings.TrimSpace(address)
 }
 
-// eth implementation
-type Ethereum struct{}
+// ltc implementation
+type Litecoin struct{}
 
-func (e Ethereum) Symbol() string { return "ETH" }
-func (e Ethereum) Chain() string  { return "ethereum" }
+func (l Litecoin) Symbol() string           { return "LTC" }
+func (l Litecoin) Chain() string            { return "litecoin" }
+func (l Litecoin) NetworkType() NetworkType { return NetworkMainnet }
 
-func (e Ethereum) ValidateAddress(address string) error {
+func (l Litecoin) ValidateAddress(address string) error {
 	if address == "" {
-		return ErrInvalidAddress
+		return fmt.Errorf("%w: address is empty", ErrInvalidAddress)
+	}
+	// bech32 (native segwit) and bech32m: ltc1...
+	if strings.HasPrefix(strings.ToLower(address), "ltc1") {
+		_, _, err := decodeSegwitAddress("ltc", address)
+		return err
 	}
-	// eth addresses: 0x + 40 hex chars
-	matched, _ := regexp.MatchString(`^0x[a-fA-F0-9]{40}$`, address)
-	if !matched {
-		return ErrInvalidAddress
+	// base58check (legacy): P2PKH starts with L, P2SH with M
+	_, _, err := decodeBase58Hash(address, 0x30, 0x32)
+	return err
+}
+
+// bech32 may be written in upper case, base58 is case sensitive
+func (l Litecoin) NormalizeAddress(address string) string {
+	address = strings.TrimSpace(address)
+	if strings.HasPrefix(strings.ToLower(address), "ltc1") {
+		return strings.ToLower(address)
+	}
+	return address
+}
+
+// doge implementation
+type Dogecoin struct{}
+
+func (d Dogecoin) Symbol() string           { return "DOGE" }
+func (d Dogecoin) Chain() string            { return "dogecoin" }
+func (d Dogecoin) NetworkType() NetworkType { return NetworkMainnet }
+
+// base58check only: P2PKH starts with D, P2SH with 9 or A
+func (d Dogecoin) ValidateAddress(address string) error {
+	if address == "" {
+		return fmt.Errorf("%w: address is empty", ErrInvalidAddress)
+	}
+	_, _, err := decodeBase58Hash(address, 0x1e, 0x16)
+	return err
+}
+
+func (d Dogecoin) NormalizeAddress(address string) string {
+	return strings.TrimSpace(address)
+}
+
+// bch implementation
+type BitcoinCash struct{}
+
+const bitcoinCashPrefix = "bitcoincash"
+
+func (b BitcoinCash) Symbol() string           { return "BCH" }
+func (b BitcoinCash) Chain() string            { return "bitcoin-cash" }
+func (b BitcoinCash) NetworkType() NetworkType { return NetworkMainnet }
+
+// CashAddr with or without the bitcoincash: prefix, or a legacy 1.../3... address
+func (b BitcoinCash) ValidateAddress(address string) error {
+	if address == "" {
+		return fmt.Errorf("%w: address is empty", ErrInvalidAddress)
+	}
+	_, _, err := b.decode(address)
+	return err
+}
+
+// every form becomes the lower-case bitcoincash: CashAddr, so cache keys and
+// watchlist entries don't depend on how the client wrote the address
+func (b BitcoinCash) NormalizeAddress(address string) string {
+	address = strings.TrimSpace(address)
+	version, hash, err := b.decode(address)
+	if err != nil {
+		return address
+	}
+	normalized, err := encodeCashAddr(bitcoinCashPrefix, version, hash)
+	if err != nil {
+		return address
+	}
+	return normalized
+}
+
+// returns the CashAddr version byte and hash of either form
+func (b BitcoinCash) decode(address string) (byte, []byte, error) {
+	if strings.HasPrefix(address, "1") || strings.HasPrefix(address, "3") {
+		version, hash, err := decodeBase58Hash(address, 0x00, 0x05)
+		if err != nil {
+			return 0, nil, err
+		}
+		// CashAddr type 0 is P2PKH, type 1 (version 0x08) is P2SH
+		if version == 0x05 {
+			return 0x08, hash, nil
+		}
+		return 0x00, hash, nil
+	}
+
+	version, hash, err := decodeCashAddr(bitcoinCashPrefix, address)
+	if err != nil {
+		return 0, nil, err
+	}
+	if kind := version >> 3; kind != 0 && kind != 1 {
+		return 0, nil, fmt.Errorf("%w: cashaddr type %d is neither P2PKH (0) nor P2SH (1)", ErrInvalidAddress, kind)
+	}
+	return version, hash, nil
+}
+
+// sol implementation
+type Solana struct{}
+
+func (s Solana) Symbol() string           { return "SOL" }
+func (s Solana) Chain() string            { return "solana" }
+func (s Solana) NetworkType() NetworkType { return NetworkMainnet }
+
+// an ed25519 public key or program address, 32 bytes in base58 without a checksum
+func (s Solana) ValidateAddress(address string) error {
+	if address == "" {
+		return fmt.Errorf("%w: address is empty", ErrInvalidAddress)
+	}
+	if len(address) < 32 || len(address) > 44 {
+		return fmt.Errorf("%w: solana address is %d characters, want 32 to 44", ErrInvalidAddress, len(address))
+	}
+	key, err := bitcoinBase58.decode(address)
+	if err != nil {
+		return err
+	}
+	if len(key) != 32 {
+		return fmt.Errorf("%w: public key is %d bytes, want 32", ErrInvalidAddress, len(key))
+	}
+	return nil
+}
+
+// base58 is case sensitive
+func (s Solana) NormalizeAddress(address string) string {
+	return strings.TrimSpace(address)
+}
+
+// xrp implementation
+type Ripple struct{}
+
+func (r Ripple) Symbol() string           { return "XRP" }
+func (r Ripple) Chain() string            { return "ripple" }
+func (r Ripple) NetworkType() NetworkType { return NetworkMainnet }
+func (r Ripple) TagName() string          { return "destination tag" }
+
+// classic r... addresses and X-addresses, which carry the destination tag
+func (r Ripple) ValidateAddress(address string) error {
+	if address == "" {
+		return fmt.Errorf("%w: address is empty", ErrInvalidAddress)
+	}
+	if strings.HasPrefix(address, "X") {
+		_, _, err := decodeXAddress(address)
+		return err
+	}
+	if !strings.HasPrefix(address, "r") {
+		return fmt.Errorf("%w: xrp address must start with r or X", ErrInvalidAddress)
+	}
+	_, err := decodeClassicAddress(address)
+	return err
+}
+
+// X-addresses become the classic address of the account, the tag is kept separately
+func (r Ripple) NormalizeAddress(address string) string {
+	address = strings.TrimSpace(address)
+	if !strings.HasPrefix(address, "X") {
+		return address
+	}
+	accountID, _, err := decodeXAddress(address)
+	if err != nil {
+		return address
+	}
+	return rippleBase58.encodeCheck(0x00, accountID)
+}
+
+func (r Ripple) ValidateTag(tag string) error {
+	if _, err := strconv.ParseUint(tag, 10, 32); err != nil {
+		return fmt.Errorf("%w: destination tag must be a number from 0 to 4294967295", ErrInvalidTag)
 	}
 	return nil
 }
 
-func (e Ethereum) NormalizeAddress(address string) string {
+func (r Ripple) NormalizeTag(tag string) string {
+	value, err := strconv.ParseUint(strings.TrimSpace(tag), 10, 32)
+	if err != nil {
+		return strings.TrimSpace(tag)
+	}
+	return strconv.FormatUint(value, 10)
+}
+
+func (r Ripple) AddressTag(address string) string {
+	if !strings.HasPrefix(address, "X") {
+		return ""
+	}
+	_, tag, err := decodeXAddress(strings.TrimSpace(address))
+	if err != nil {
+		return ""
+	}
+	return tag
+}
+
+// xlm implementation
+type Stellar struct{}
+
+const stellarMaxMemoBytes = 28
+
+func (s Stellar) Symbol() string           { return "XLM" }
+func (s Stellar) Chain() string            { return "stellar" }
+func (s Stellar) NetworkType() NetworkType { return NetworkMainnet }
+func (s Stellar) TagName() string          { return "memo" }
+
+// a G... strkey holding a 32-byte ed25519 public key
+func (s Stellar) ValidateAddress(address string) error {
+	if address == "" {
+		return fmt.Errorf("%w: address is empty", ErrInvalidAddress)
+	}
+	if strings.HasPrefix(address, "M") {
+		return fmt.Errorf("%w: muxed accounts are not supported, send the G address and the memo", ErrInvalidAddress)
+	}
+	if !strings.HasPrefix(address, "G") {
+		return fmt.Errorf("%w: stellar address must start with G", ErrInvalidAddress)
+	}
+	key, err := decodeStrkey(strkeyAccountID, address)
+	if err != nil {
+		return err
+	}
+	if len(key) != 32 {
+		return fmt.Errorf("%w: public key is %d bytes, want 32", ErrInvalidAddress, len(key))
+	}
+	return nil
+}
+
+func (s Stellar) NormalizeAddress(address string) string {
+	return strings.TrimSpace(address)
+}
+
+// a text memo of up to 28 bytes, which also fits every id memo
+func (s Stellar) ValidateTag(tag string) error {
+	if !utf8.ValidString(tag) {
+		return fmt.Errorf("%w: memo is not valid utf-8", ErrInvalidTag)
+	}
+	if len(tag) > stellarMaxMemoBytes {
+		return fmt.Errorf("%w: memo is %d bytes, at most %d allowed", ErrInvalidTag, len(tag), stellarMaxMemoBytes)
+	}
+	return nil
+}
+
+func (s Stellar) NormalizeTag(tag string) string   { return strings.TrimSpace(tag) }
+func (s Stellar) AddressTag(address string) string { return "" }
+
+// ton implementation
+type Ton struct{}
+
+const tonMaxCommentBytes = 120
+
+func (t Ton) Symbol() string           { return "TON" }
+func (t Ton) Chain() string            { return "ton" }
+func (t Ton) NetworkType() NetworkType { return NetworkMainnet }
+func (t Ton) TagName() string          { return "comment" }
+
+// the raw workchain:hash form or the base64 user-friendly form, bounceable or not
+func (t Ton) ValidateAddress(address string) error {
+	if address == "" {
+		return fmt.Errorf("%w: address is empty", ErrInvalidAddress)
+	}
+	parsed, err := parseTonAddress(address)
+	if err != nil {
+		return err
+	}
+	if parsed.testnet {
+		return fmt.Errorf("%w: ton address is flagged as testnet", ErrInvalidAddress)
+	}
+	if parsed.workchain != 0 && parsed.workchain != -1 {
+		return fmt.Errorf("%w: ton workchain %d is neither basechain (0) nor masterchain (-1)", ErrInvalidAddress, parsed.workchain)
+	}
+	return nil
+}
+
+// every form of an account becomes its raw form, so EQ... and UQ... match
+func (t Ton) NormalizeAddress(address string) string {
+	address = strings.TrimSpace(address)
+	parsed, err := parseTonAddress(address)
+	if err != nil {
+		return address
+	}
+	return parsed.raw()
+}
+
+func (t Ton) ValidateTag(tag string) error {
+	if !utf8.ValidString(tag) {
+		return fmt.Errorf("%w: comment is not valid utf-8", ErrInvalidTag)
+	}
+	if len(tag) > tonMaxCommentBytes {
+		return fmt.Errorf("%w: comment is %d bytes, at most %d allowed", ErrInvalidTag, len(tag), tonMaxCommentBytes)
+	}
+	return nil
+}
+
+func (t Ton) NormalizeTag(tag string) string   { return strings.TrimSpace(tag) }
+func (t Ton) AddressTag(address string) string { return "" }
+
+// an EVM-compatible chain, every one of them shares ethereum's address format
+type EVMChain struct {
+	// EIP-155 chain id, 1 for ethereum mainnet
+	ID   int64  `json:"id" yaml:"id"`
+	Name string `json:"name" yaml:"name"`
+	// symbol of the native currency, e.g. ETH or POL
+	Native string `json:"symbol" yaml:"symbol"`
+	// empty means mainnet, e.g. testnet for sepolia
+	Network NetworkType `json:"network,omitempty" yaml:"network,omitempty"`
+}
+
+func (c EVMChain) Symbol() string { return c.Native }
+func (c EVMChain) Chain() string  { return c.Name }
+
+func (c EVMChain) NetworkType() NetworkType {
+	if c.Network == "" {
+		return NetworkMainnet
+	}
+	return c.Network
+}
+
+// mixed case addresses must carry a valid EIP-55 checksum, so validate before normalizing
+func (c EVMChain) ValidateAddress(address string) error {
+	if address == "" {
+		return fmt.Errorf("%w: address is empty", ErrInvalidAddress)
+	}
+	return validateEVMAddress(address)
+}
+
+func (c EVMChain) NormalizeAddress(address string) string {
 	return strings.ToLower(strings.TrimSpace(address))
 }
 
-type USDT struct{}
+// a token contract on another asset's chain, holders use the chain's addresses
+type Token struct {
+	symbol   string
+	contract string
+	decimals int
+	chain    Asset
+}
+
+func NewToken(symbol, contract string, decimals int, chain Asset) Token {
+	return Token{
+		symbol:   strings.ToUpper(symbol),
+		contract: chain.NormalizeAddress(contract),
+		decimals: decimals,
+		chain:    chain,
+	}
+}
+
+func (t Token) Symbol() string   { return t.symbol }
+func (t Token) Chain() string    { return t.chain.Chain() }
+func (t Token) Contract() string { return t.contract }
+func (t Token) Decimals() int    { return t.decimals }
+
+// a token is on the network of its chain
+func (t Token) NetworkType() NetworkType { return t.chain.NetworkType() }
+
+func (t Token) ValidateAddress(address string) error {
+	return t.chain.ValidateAddress(address)
+}
 
-func (u USDT) Symbol() string { return "USDT" }
-func (u USDT) Chain() string  { return "ethereum" }
+func (t Token) NormalizeAddress(address string) string {
+	return t.chain.NormalizeAddress(address)
+}
 
-func (u USDT) ValidateAddress(address string) error {
-	eth := Ethereum{}
-	return eth.ValidateAddress(address)
+// token standard names clients send instead of the chain
+var networkAliases = map[string]string{
+	"erc20": "ethereum",
+	"trc20": "tron",
+	"bep20": "bsc",
 }
 
-func (u USDT) NormalizeAddress(address string) string {
-	eth := Ethereum{}
-	return eth.NormalizeAddress(address)
+// normalizes a network name to the chain identifier used by assets
+func NormalizeNetwork(network string) string {
+	network = strings.ToLower(strings.TrimSpace(network))
+	network = strings.ReplaceAll(network, "-", "")
+	if chain, ok := networkAliases[network]; ok {
+		return chain
+	}
+	return network
 }
 
 type DefaultAssetRegistry struct {
-	assets map[string]Asset
+	// symbol -> chain -> asset
+	assets map[string]map[string]Asset
+	// the first mainnet chain registered for a symbol
+	defaults map[string]Asset
+	// chain -> its native asset, tokens are validated with it
+	chains map[string]Asset
+	// symbol -> network type -> the first asset registered on it
+	networks map[string]map[NetworkType]Asset
 }
 
+// the registry of DefaultAssetConfig
 func NewDefaultAssetRegistry() *DefaultAssetRegistry {
+	registry, err := NewAssetRegistry(DefaultAssetConfig())
+	if err != nil {
+		panic(fmt.Sprintf("default asset config: %v", err))
+	}
+	return registry
+}
+
+// registers the built-in chains, then the configured EVM chains and tokens in order
+func NewAssetRegistry(config AssetConfig) (*DefaultAssetRegistry, error) {
 	registry := &DefaultAssetRegistry{
-		assets: make(map[string]Asset),
+		assets:   make(map[string]map[string]Asset),
+		defaults: make(map[string]Asset),
+		chains:   make(map[string]Asset),
+		networks: make(map[string]map[NetworkType]Asset),
 	}
 	registry.register(Bitcoin{})
-	registry.register(Ethereum{})
-	registry.register(USDT{})
-	return registry
+	registry.register(Bitcoin{Network: NetworkTestnet})
+	registry.register(Bitcoin{Network: NetworkRegtest})
+	registry.register(Tron{})
+	registry.register(Solana{})
+	registry.register(Litecoin{})
+	registry.register(BitcoinCash{})
+	registry.register(Dogecoin{})
+	registry.register(Ripple{})
+	registry.register(Stellar{})
+	registry.register(Ton{})
+
+	ids := make(map[int64]string)
+	for _, chain := range config.EVMChains {
+		chain.Name = strings.ToLower(strings.TrimSpace(chain.Name))
+		chain.Native = strings.ToUpper(strings.TrimSpace(chain.Native))
+
+		networkType, err := ParseNetworkType(string(chain.Network))
+		if err != nil {
+			return nil, fmt.Errorf("%w: evm chain %q: %v", ErrInvalidAssetConfig, chain.Name, err)
+		}
+		chain.Network = networkType
+
+		switch {
+		case chain.ID <= 0:
+			return nil, fmt.Errorf("%w: evm chain %q needs a positive chain id", ErrInvalidAssetConfig, chain.Name)
+		case !validChainName(chain.Name):
+			return nil, fmt.Errorf("%w: evm chain name %q must be lower case letters, digits or dashes", ErrInvalidAssetConfig, chain.Name)
+		case chain.Native == "":
+			return nil, fmt.Errorf("%w: evm chain %q has no native symbol", ErrInvalidAssetConfig, chain.Name)
+		case findChain(registry.chains, chain.Name) != nil:
+			return nil, fmt.Errorf("%w: chain %q is defined twice", ErrInvalidAssetConfig, chain.Name)
+		case ids[chain.ID] != "":
+			return nil, fmt.Errorf("%w: chain id %d is used by %q and %q", ErrInvalidAssetConfig, chain.ID, ids[chain.ID], chain.Name)
+		}
+
+		ids[chain.ID] = chain.Name
+		registry.register(chain)
+	}
+
+	for _, token := range config.Tokens {
+		symbol := strings.ToUpper(strings.TrimSpace(token.Symbol))
+		chainName := strings.ToLower(strings.TrimSpace(token.Chain))
+
+		chain := findChain(registry.chains, chainName)
+		switch {
+		case symbol == "":
+			return nil, fmt.Errorf("%w: token on %q has no symbol", ErrInvalidAssetConfig, token.Chain)
+		case chain == nil:
+			return nil, fmt.Errorf("%w: token %s is on unknown chain %q", ErrInvalidAssetConfig, symbol, token.Chain)
+		case token.Decimals < 0 || token.Decimals > 255:
+			return nil, fmt.Errorf("%w: token %s on %s has %d decimals, want 0 to 255", ErrInvalidAssetConfig, symbol, chainName, token.Decimals)
+		case registry.assets[symbol][chain.Chain()] != nil:
+			return nil, fmt.Errorf("%w: %s is defined twice on %s", ErrInvalidAssetConfig, symbol, chainName)
+		}
+
+		if err := chain.ValidateAddress(strings.TrimSpace(token.Contract)); err != nil {
+			return nil, fmt.Errorf("%w: contract of %s on %s: %v", ErrInvalidAssetConfig, symbol, chainName, err)
+		}
+
+		registry.register(NewToken(symbol, strings.TrimSpace(token.Contract), token.Decimals, chain))
+	}
+
+	return registry, nil
 }
 
 func (r *DefaultAssetRegistry) register(asset Asset) {
-	r.assets[asset.Symbol()] = asset
+	symbol := asset.Symbol()
+	if r.assets[symbol] == nil {
+		r.assets[symbol] = make(map[string]Asset)
+		r.networks[symbol] = make(map[NetworkType]Asset)
+	}
+	r.assets[symbol][asset.Chain()] = asset
+
+	// a test network is only the default until a mainnet chain shows up
+	if current := r.defaults[symbol]; current == nil || (current.NetworkType().IsTest() && !asset.NetworkType().IsTest()) {
+		r.defaults[symbol] = asset
+	}
+	if _, ok := r.networks[symbol][asset.NetworkType()]; !ok {
+		r.networks[symbol][asset.NetworkType()] = asset
+	}
+
+	// natives define chains, tokens only live on them
+	if _, ok := asset.(Token); !ok {
+		r.chains[asset.Chain()] = asset
+	}
 }
 
 func (r *DefaultAssetRegistry) Get(symbol string) (Asset, error) {
-	asset, ok := r.assets[strings.ToUpper(symbol)]
+	return r.Resolve(symbol, "")
+}
+
+func (r *DefaultAssetRegistry) Resolve(symbol, network string) (Asset, error) {
+	symbol = strings.ToUpper(strings.TrimSpace(symbol))
+	chains, ok := r.assets[symbol]
 	if !ok {
 		return nil, fmt.Errorf("%w: %s", ErrUnsupportedCurrency, symbol)
 	}
-	return asset, nil
+
+	if strings.TrimSpace(network) == "" {
+		return r.defaults[symbol], nil
+	}
+
+	if asset := findChain(chains, network); asset != nil {
+		return asset, nil
+	}
+
+	// a network type instead of a chain, e.g. BTC on testnet
+	if networkType, err := ParseNetworkType(network); err == nil {
+		if asset, ok := r.networks[symbol][networkType]; ok {
+			return asset, nil
+		}
+	}
+
+	return nil, fmt.Errorf("%w: %s on network %s", ErrUnsupportedCurrency, symbol, network)
+}
+
+// chain names match regardless of case and dashes, so bitcoin-cash and BITCOINCASH are the same chain
+func findChain(assets map[string]Asset, network string) Asset {
+	key := NormalizeNetwork(network)
+	for chain, asset := range assets {
+		if NormalizeNetwork(chain) == key {
+			return asset
+		}
+	}
+	return nil
 }
 
 func (r *DefaultAssetRegistry) List() []Asset {
 	assets := make([]Asset, 0, len(r.assets))
-	for _, asset := range r.assets {
-		assets = append(assets, asset)
+	for _, chains := range r.assets {
+		for _, asset := range chains {
+			assets = append(assets, asset)
+		}
 	}
 	return assets
 }
+
+func validChainName(name string) bool {
+	if name == "" {
+		return false
+	}
+	for i := 0; i < len(name); i++ {
+		char := name[i]
+		if (char < 'a' || char > 'z') && (char < '0' || char > '9') && char != '-' {
+			return false
+		}
+	}
+	return true
+}
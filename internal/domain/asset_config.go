@@ -0,0 +1,50 @@
+package domain
+
+// the assets a deployment screens, on top of the built-in bitcoin (mainnet, testnet
+// and regtest), tron, solana, litecoin, bitcoin cash, dogecoin, ripple, stellar and ton
+type AssetConfig struct {
+	// the first chain listed for a native symbol is its default network
+	EVMChains []EVMChain    `json:"evm_chains" yaml:"evm_chains"`
+	Tokens    []TokenConfig `json:"tokens" yaml:"tokens"`
+}
+
+// an ERC-20 style token, the first chain listed for a symbol is its default network
+type TokenConfig struct {
+	Symbol string `json:"symbol" yaml:"symbol"`
+	// name of a configured EVM chain or of a built-in one such as tron or solana
+	Chain    string `json:"chain" yaml:"chain"`
+	Contract string `json:"contract" yaml:"contract"`
+	Decimals int    `json:"decimals" yaml:"decimals"`
+}
+
+// checks the config the way NewAssetRegistry would build it
+func (c AssetConfig) Validate() error {
+	_, err := NewAssetRegistry(c)
+	return err
+}
+
+// used when no asset file is configured
+func DefaultAssetConfig() AssetConfig {
+	return AssetConfig{
+		EVMChains: []EVMChain{
+			{ID: 1, Name: "ethereum", Native: "ETH"},
+			{ID: 137, Name: "polygon", Native: "POL"},
+			{ID: 56, Name: "bsc", Native: "BNB"},
+			{ID: 42161, Name: "arbitrum", Native: "ETH"},
+			{ID: 10, Name: "optimism", Native: "ETH"},
+			{ID: 8453, Name: "base", Native: "ETH"},
+			// screened with the mock provider, listed after ethereum so ETH keeps its default
+			{ID: 11155111, Name: "sepolia", Native: "ETH", Network: NetworkTestnet},
+		},
+		Tokens: []TokenConfig{
+			// ethereum stays the default so requests without a network keep their meaning
+			{Symbol: "USDT", Chain: "ethereum", Contract: "0xdAC17F958D2ee523a2206206994597C13D831ec7", Decimals: 6},
+			{Symbol: "USDT", Chain: "tron", Contract: "TR7NHqjeKQxGTCi8q8ZY4pL8otSzgjLj6t", Decimals: 6},
+			{Symbol: "USDT", Chain: "polygon", Contract: "0xc2132D05D31c914a87C6611C10748AEb04B58e8F", Decimals: 6},
+			{Symbol: "USDT", Chain: "bsc", Contract: "0x55d398326f99059fF775485246999027B3197955", Decimals: 18},
+			{Symbol: "USDT", Chain: "arbitrum", Contract: "0xFd086bC7CD5C481DCC9C85ebE478A1C0b69FCbb9", Decimals: 6},
+			{Symbol: "USDC", Chain: "ethereum", Contract: "0xA0b86991c6218b36c1d19D4a2e9Eb0cE3606eB48", Decimals: 6},
+			{Symbol: "USDC", Chain: "base", Contract: "0x833589fCD6eDb6E08f4c7C32D4f71b54bdA02913", Decimals: 6},
+		},
+	}
+}
@@ -1,6 +1,8 @@
 package domain
 
 import (
+	"errors"
+	"strings"
 	"testing"
 )
 
@@ -13,12 +15,21 @@ func TestBitcoinValidateAddress(t *testing.T) {
 		wantErr bool
 	}{
 		{"valid legacy", "1A1zP1eP5QGefi2DMPTfTL5SLmv7DivfNa", false},
-		{"valid legacy 3", "3J98t1WpEZ73CNmYviecrnyiWrnqRhWNLy", false},
+		{"valid legacy 3", "3J98t1WpEZ73CNmQviecrnyiWrnqRhWNLy", false},
 		{"valid bech32", "bc1qar0srrr7xfkvy5l643lydnw9re59gtzzwf5mdq", false},
+		{"valid bech32 uppercase", "BC1QW508D6QEJXTDG4Y5R3ZARVARY0C5XW7KV8F3T4", false},
+		{"valid p2wsh", "bc1qrp33g0q5c5txsp9arysrx4k6zdkfs4nce4xj0gdcccefvpysxf3qccfmv3", false},
+		{"valid taproot", "bc1p0xlxvlhemja6c4dqv22uapctqupfhlxm9h8z3k2e72q4k9hcz7vqzk5jj0", false},
 		{"empty", "", true},
 		{"invalid prefix", "2A1zP1eP5QGefi2DMPTfTL5SLmv7DivfNa", true},
 		{"too short", "1A1zP", true},
 		{"invalid chars", "1A1zP1eP5QGefi2DMPTfTL5SLmv7DivfN@", true},
+		{"legacy typo", "3J98t1WpEZ73CNmYviecrnyiWrnqRhWNLy", true},
+		{"bech32 typo", "bc1qar0srrr7xfkvy5l643lydnw9re59gtzzwf5mdr", true},
+		{"bech32 mixed case", "bc1qW508d6qejxtdg4y5r3zarvary0c5xw7kv8f3t4", true},
+		{"taproot with bech32 checksum", "bc1p0xlxvlhemja6c4dqv22uapctqupfhlxm9h8z3k2e72q4k9hcz7vqh2y7hd", true},
+		{"segwit v0 with bech32m checksum", "bc1qw508d6qejxtdg4y5r3zarvary0c5xw7kemeawh", true},
+		{"testnet bech32", "tb1qw508d6qejxtdg4y5r3zarvary0c5xw7kxpjzsx", true},
 	}
 
 	for _, tt := range tests {
@@ -32,7 +43,7 @@ func TestBitcoinValidateAddress(t *testing.T) {
 }
 
 func TestEthereumValidateAddress(t *testing.T) {
-	eth := Ethereum{}
+	eth := EVMChain{ID: 1, Name: "ethereum", Native: "ETH"}
 
 	tests := []struct {
 		name    string
@@ -41,11 +52,14 @@ func TestEthereumValidateAddress(t *testing.T) {
 	}{
 		{"valid lowercase", "0x742d35cc6634c0532925a3b844bc9e7595f0beb8", false},
 		{"valid uppercase", "0x742D35CC6634C0532925A3B844BC9E7595F0BEB8", false},
-		{"valid mixed", "0x742d35Cc6634C0532925a3b844Bc9e7595f0beb8", false},
+		{"valid mixed", "0x742D35cC6634C0532925A3b844bc9E7595f0BEb8", false},
+		{"valid eip-55", "0x5aAeb6053F3E94C9b9A09f33669435E7Ef1BeAed", false},
 		{"empty", "", true},
 		{"missing 0x", "742d35cc6634c0532925a3b844bc9e7595f0beb8", true},
 		{"too short", "0x742d35cc", true},
 		{"invalid char", "0x742d35cc6634c0532925a3b844bc9e7595f0beg8", true},
+		{"bad checksum", "0x742d35Cc6634C0532925a3b844Bc9e7595f0beb8", true},
+		{"one flipped case", "0x5aAeb6053F3E94C9b9A09f33669435E7Ef1BeAeD", true},
 	}
 
 	for _, tt := range tests {
@@ -59,7 +73,7 @@ func TestEthereumValidateAddress(t *testing.T) {
 }
 
 func TestUSDTValidateAddress(t *testing.T) {
-	usdt := USDT{}
+	usdt := NewToken("USDT", "0xdAC17F958D2ee523a2206206994597C13D831ec7", 6, EVMChain{ID: 1, Name: "ethereum", Native: "ETH"})
 
 	// USDT on Ethereum uses Ethereum address format
 	tests := []struct {
@@ -82,8 +96,30 @@ func TestUSDTValidateAddress(t *testing.T) {
 	}
 }
 
+func TestBitcoinNormalizeAddress(t *testing.T) {
+	btc := Bitcoin{}
+
+	tests := []struct {
+		name    string
+		address string
+		want    string
+	}{
+		{"legacy keeps case", " 1A1zP1eP5QGefi2DMPTfTL5SLmv7DivfNa ", "1A1zP1eP5QGefi2DMPTfTL5SLmv7DivfNa"},
+		{"bech32 uppercase", "BC1QW508D6QEJXTDG4Y5R3ZARVARY0C5XW7KV8F3T4", "bc1qw508d6qejxtdg4y5r3zarvary0c5xw7kv8f3t4"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := btc.NormalizeAddress(tt.address)
+			if got != tt.want {
+				t.Errorf("Bitcoin.NormalizeAddress() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
 func TestEthereumNormalizeAddress(t *testing.T) {
-	eth := Ethereum{}
+	eth := EVMChain{ID: 1, Name: "ethereum", Native: "ETH"}
 
 	tests := []struct {
 		name    string
@@ -122,9 +158,9 @@ func TestDefaultAssetRegistry(t *testing.T) {
 	})
 
 	t.Run("get unsupported currency", func(t *testing.T) {
-		_, err := registry.Get("XRP")
+		_, err := registry.Get("XMR")
 		if err == nil {
-			t.Error("registry.Get(XRP) should return error")
+			t.Error("registry.Get(XMR) should return error")
 		}
 	})
 
@@ -140,10 +176,565 @@ func TestDefaultAssetRegistry(t *testing.T) {
 
 	t.Run("list all", func(t *testing.T) {
 		assets := registry.List()
-		if len(assets) != 3 {
-			t.Errorf("registry.List() length = %v, want 3", len(assets))
+		// eleven built-in chains, seven evm chains and seven tokens
+		if len(assets) != 25 {
+			t.Errorf("registry.List() length = %v, want 25", len(assets))
+		}
+	})
+
+	t.Run("resolve network", func(t *testing.T) {
+		tests := []struct {
+			currency  string
+			network   string
+			wantChain string
+		}{
+			{"USDT", "", "ethereum"},
+			{"USDT", "ETHEREUM", "ethereum"},
+			{"USDT", "TRON", "tron"},
+			{"usdt", "trc-20", "tron"},
+			{"USDT", "ERC20", "ethereum"},
+			{"TRX", "", "tron"},
+			{"ETH", "", "ethereum"},
+			{"ETH", "ARBITRUM", "arbitrum"},
+			{"POL", "", "polygon"},
+			{"USDT", "BSC", "bsc"},
+			{"USDC", "base", "base"},
+			{"BCH", "", "bitcoin-cash"},
+			{"SOL", "", "solana"},
+			{"BCH", "bitcoin-cash", "bitcoin-cash"},
+			{"BTC", "mainnet", "bitcoin"},
+			{"BTC", "TESTNET", "bitcoin-testnet"},
+			{"BTC", "signet", "bitcoin-testnet"},
+			{"BTC", "bitcoin-regtest", "bitcoin-regtest"},
+			{"ETH", "sepolia", "sepolia"},
+			{"ETH", "testnet", "sepolia"},
+		}
+		for _, tt := range tests {
+			asset, err := registry.Resolve(tt.currency, tt.network)
+			if err != nil {
+				t.Errorf("registry.Resolve(%s, %s) error = %v", tt.currency, tt.network, err)
+				continue
+			}
+			if asset.Chain() != tt.wantChain {
+				t.Errorf("registry.Resolve(%s, %s) chain = %v, want %v", tt.currency, tt.network, asset.Chain(), tt.wantChain)
+			}
+		}
+	})
+
+	t.Run("resolve unsupported network", func(t *testing.T) {
+		_, err := registry.Resolve("BTC", "TRON")
+		if !errors.Is(err, ErrUnsupportedCurrency) {
+			t.Errorf("registry.Resolve(BTC, TRON) error = %v, want ErrUnsupportedCurrency", err)
+		}
+
+		_, err = registry.Resolve("USDT", "testnet")
+		if !errors.Is(err, ErrUnsupportedCurrency) {
+			t.Errorf("registry.Resolve(USDT, testnet) error = %v, want ErrUnsupportedCurrency", err)
+		}
+	})
+
+	t.Run("network types", func(t *testing.T) {
+		for _, tt := range []struct {
+			currency string
+			network  string
+			want     NetworkType
+		}{
+			{"BTC", "", NetworkMainnet},
+			{"BTC", "testnet", NetworkTestnet},
+			{"BTC", "regtest", NetworkRegtest},
+			{"ETH", "", NetworkMainnet},
+			{"ETH", "sepolia", NetworkTestnet},
+			{"USDT", "tron", NetworkMainnet},
+		} {
+			asset, err := registry.Resolve(tt.currency, tt.network)
+			if err != nil {
+				t.Fatalf("registry.Resolve(%s, %s) error = %v", tt.currency, tt.network, err)
+			}
+			if asset.NetworkType() != tt.want {
+				t.Errorf("registry.Resolve(%s, %s) network type = %v, want %v", tt.currency, tt.network, asset.NetworkType(), tt.want)
+			}
+		}
+	})
+}
+
+func TestNewAssetRegistry(t *testing.T) {
+	ethereum := EVMChain{ID: 1, Name: "ethereum", Native: "ETH"}
+
+	t.Run("token", func(t *testing.T) {
+		registry, err := NewAssetRegistry(AssetConfig{
+			EVMChains: []EVMChain{ethereum},
+			Tokens:    []TokenConfig{{Symbol: "dai", Chain: "Ethereum", Contract: "0x6B175474E89094C44Da98b954EedeAC495271d0F", Decimals: 18}},
+		})
+		if err != nil {
+			t.Fatalf("NewAssetRegistry() error = %v", err)
+		}
+
+		asset, err := registry.Get("DAI")
+		if err != nil {
+			t.Fatalf("registry.Get(DAI) error = %v", err)
+		}
+		token, ok := asset.(Token)
+		if !ok {
+			t.Fatalf("registry.Get(DAI) = %T, want Token", asset)
+		}
+		if token.Chain() != "ethereum" || token.Decimals() != 18 || token.Contract() != "0x6b175474e89094c44da98b954eedeac495271d0f" {
+			t.Errorf("token = %s on %s, %d decimals, contract %s", token.Symbol(), token.Chain(), token.Decimals(), token.Contract())
+		}
+		if err := token.ValidateAddress("1A1zP1eP5QGefi2DMPTfTL5SLmv7DivfNa"); err == nil {
+			t.Error("token accepted a bitcoin address")
+		}
+	})
+
+	t.Run("test network is not the default", func(t *testing.T) {
+		registry, err := NewAssetRegistry(AssetConfig{
+			EVMChains: []EVMChain{{ID: 11155111, Name: "sepolia", Native: "ETH", Network: NetworkTestnet}, ethereum},
+		})
+		if err != nil {
+			t.Fatalf("NewAssetRegistry() error = %v", err)
+		}
+
+		asset, err := registry.Get("ETH")
+		if err != nil {
+			t.Fatalf("registry.Get(ETH) error = %v", err)
+		}
+		if asset.Chain() != "ethereum" {
+			t.Errorf("registry.Get(ETH) chain = %v, want ethereum", asset.Chain())
+		}
+	})
+
+	tests := []struct {
+		name   string
+		config AssetConfig
+	}{
+		{"zero chain id", AssetConfig{EVMChains: []EVMChain{{Name: "ethereum", Native: "ETH"}}}},
+		{"bad chain name", AssetConfig{EVMChains: []EVMChain{{ID: 1, Name: "eth mainnet", Native: "ETH"}}}},
+		{"no native symbol", AssetConfig{EVMChains: []EVMChain{{ID: 1, Name: "ethereum"}}}},
+		{"duplicate chain", AssetConfig{EVMChains: []EVMChain{ethereum, {ID: 2, Name: "ethereum", Native: "ETH"}}}},
+		{"built-in chain", AssetConfig{EVMChains: []EVMChain{{ID: 728126428, Name: "tron", Native: "TRX"}}}},
+		{"built-in chain without dash", AssetConfig{EVMChains: []EVMChain{{ID: 10000, Name: "bitcoincash", Native: "BCH"}}}},
+		{"unknown network type", AssetConfig{EVMChains: []EVMChain{{ID: 11155111, Name: "sepolia", Native: "ETH", Network: "devnet"}}}},
+		{"duplicate chain id", AssetConfig{EVMChains: []EVMChain{ethereum, {ID: 1, Name: "mainnet", Native: "ETH"}}}},
+		{"unknown token chain", AssetConfig{Tokens: []TokenConfig{{Symbol: "USDT", Chain: "ethereum", Contract: "0xdAC17F958D2ee523a2206206994597C13D831ec7", Decimals: 6}}}},
+		{"bad contract", AssetConfig{EVMChains: []EVMChain{ethereum}, Tokens: []TokenConfig{{Symbol: "USDT", Chain: "ethereum", Contract: "0xdAC17F958D2ee523a2206206994597C13D831ec8", Decimals: 6}}}},
+		{"negative decimals", AssetConfig{EVMChains: []EVMChain{ethereum}, Tokens: []TokenConfig{{Symbol: "USDT", Chain: "ethereum", Contract: "0xdac17f958d2ee523a2206206994597c13d831ec7", Decimals: -1}}}},
+		{"token shadows native", AssetConfig{EVMChains: []EVMChain{ethereum}, Tokens: []TokenConfig{{Symbol: "ETH", Chain: "ethereum", Contract: "0xdac17f958d2ee523a2206206994597c13d831ec7"}}}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := NewAssetRegistry(tt.config); !errors.Is(err, ErrInvalidAssetConfig) {
+				t.Errorf("NewAssetRegistry() error = %v, want ErrInvalidAssetConfig", err)
+			}
+		})
+	}
+}
+
+func TestTronValidateAddress(t *testing.T) {
+	trx := Tron{}
+
+	tests := []struct {
+		name    string
+		address string
+		wantErr bool
+	}{
+		{"valid", "TR7NHqjeKQxGTCi8q8ZY4pL8otSzgjLj6t", false},
+		{"valid 2", "TLa2f6VPqDgRE67v1736s7bJ8Ray5wYjU7", false},
+		{"empty", "", true},
+		{"checksum typo", "TR7NHqjeKQxGTCi8q8ZY4pL8otSzgjLj6u", true},
+		{"bitcoin address", "1A1zP1eP5QGefi2DMPTfTL5SLmv7DivfNa", true},
+		{"ethereum address", "0x742d35cc6634c0532925a3b844bc9e7595f0beb8", true},
+		{"lowercased", "tr7nhqjekqxgtci8q8zy4pl8otszgjlj6t", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := trx.ValidateAddress(tt.address)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Tron.ValidateAddress() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+
+
+func TestLitecoinValidateAddress(t *testing.T) {
+	ltc := Litecoin{}
+
+	tests := []struct {
+		name    string
+		address string
+		wantErr bool
+	}{
+		{"valid p2pkh", "LM2WMpR1Rp6j3Sa59cMXMs1SPzj9eXpGc1", false},
+		{"valid p2sh", "MGxNPPB7eBoWPUaprtX9v9CXJZoD2465zN", false},
+		{"valid bech32", "ltc1qw508d6qejxtdg4y5r3zarvary0c5xw7kgmn4n9", false},
+		{"valid bech32 uppercase", "LTC1QW508D6QEJXTDG4Y5R3ZARVARY0C5XW7KGMN4N9", false},
+		{"empty", "", true},
+		{"checksum typo", "LM2WMpR1Rp6j3Sa59cMXMs1SPzj9eXpGc2", true},
+		{"bitcoin address", "1A1zP1eP5QGefi2DMPTfTL5SLmv7DivfNa", true},
+		{"bitcoin bech32", "bc1qw508d6qejxtdg4y5r3zarvary0c5xw7kv8f3t4", true},
+		{"bech32 typo", "ltc1qw508d6qejxtdg4y5r3zarvary0c5xw7kgmn4n8", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := ltc.ValidateAddress(tt.address)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Litecoin.ValidateAddress() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestDogecoinValidateAddress(t *testing.T) {
+	doge := Dogecoin{}
+
+	tests := []struct {
+		name    string
+		address string
+		wantErr bool
+	}{
+		{"valid p2pkh", "DH5yaieqoZN36fDVciNyRueRGvGLR3mr7L", false},
+		{"valid p2sh 9", "9rSGfPZLcyCGzY4uYEL1fkzJr6fkicS2rs", false},
+		{"valid p2sh A", "AFmseVrdL9f9oyCzZefL9tG6UbvhFLcxeB", false},
+		{"empty", "", true},
+		{"checksum typo", "DH5yaieqoZN36fDVciNyRueRGvGLR3mr7M", true},
+		{"litecoin address", "LM2WMpR1Rp6j3Sa59cMXMs1SPzj9eXpGc1", true},
+		{"bitcoin address", "1A1zP1eP5QGefi2DMPTfTL5SLmv7DivfNa", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := doge.ValidateAddress(tt.address)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Dogecoin.ValidateAddress() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestSolanaValidateAddress(t *testing.T) {
+	sol := Solana{}
+
+	tests := []struct {
+		name    string
+		address string
+		wantErr bool
+	}{
+		{"system program", "11111111111111111111111111111111", false},
+		{"token program", "TokenkegQfeZyiNwAJbNbGKPFXCWuBvf9Ss623VQ5DA", false},
+		{"wrapped sol", "So11111111111111111111111111111111111111112", false},
+		{"empty", "", true},
+		{"too short", "1111111111111111111111111111111", true},
+		{"33 bytes", "TokenkegQfeZyiNwAJbNbGKPFXCWuBvf9Ss623VQ5DAA", true},
+		{"invalid char", "TokenkegQfeZyiNwAJbNbGKPFXCWuBvf9Ss623VQ5D0", true},
+		{"ethereum address", "0x742d35cc6634c0532925a3b844bc9e7595f0beb8", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := sol.ValidateAddress(tt.address)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Solana.ValidateAddress() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestBitcoinCashValidateAddress(t *testing.T) {
+	bch := BitcoinCash{}
+
+	tests := []struct {
+		name    string
+		address string
+		wantErr bool
+	}{
+		{"valid cashaddr", "bitcoincash:qpm2qsznhks23z7629mms6s4cwef74vcwvy22gdx6a", false},
+		{"valid cashaddr p2sh", "bitcoincash:ppm2qsznhks23z7629mms6s4cwef74vcwvn0h829pq", false},
+		{"valid without prefix", "qr95sy3j9xwd2ap32xkykttr4cvcu7as4y0qverfuy", false},
+		{"valid uppercase", "BITCOINCASH:QPM2QSZNHKS23Z7629MMS6S4CWEF74VCWVY22GDX6A", false},
+		{"valid p2sh32", "bitcoincash:pvg3zyg3zyg3zyg3zyg3zyg3zyg3zyg3zyg3zyg3zyg3zyg3zyg3zch7f55mh", false},
+		{"valid legacy", "1BpEi6DfDAUFd7GtittLSdBeYJvcoaVggu", false},
+		{"valid legacy p2sh", "3CWFddi6m4ndiGyKqzYvsFYagqDLPVMTzC", false},
+		{"empty", "", true},
+		{"checksum typo", "bitcoincash:qpm2qsznhks23z7629mms6s4cwef74vcwvy22gdx6b", true},
+		{"mixed case", "bitcoincash:Qpm2qsznhks23z7629mms6s4cwef74vcwvy22gdx6a", true},
+		{"wrong prefix", "bchtest:qpm2qsznhks23z7629mms6s4cwef74vcwvy22gdx6a", true},
+		{"unknown type", "bitcoincash:zqg3zyg3zyg3zyg3zyg3zyg3zyg3zyg3zy7m9s3er2", true},
+		{"bech32 address", "bc1qw508d6qejxtdg4y5r3zarvary0c5xw7kv8f3t4", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := bch.ValidateAddress(tt.address)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("BitcoinCash.ValidateAddress() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+// legacy vectors from the CashAddr specification
+func TestBitcoinCashNormalizeAddress(t *testing.T) {
+	bch := BitcoinCash{}
+
+	tests := []struct {
+		address string
+		want    string
+	}{
+		{"1BpEi6DfDAUFd7GtittLSdBeYJvcoaVggu", "bitcoincash:qpm2qsznhks23z7629mms6s4cwef74vcwvy22gdx6a"},
+		{"1KXrWXciRDZUpQwQmuM1DbwsKDLYAYsVLR", "bitcoincash:qr95sy3j9xwd2ap32xkykttr4cvcu7as4y0qverfuy"},
+		{"16w1D5WRVKJuZUsSRzdLp9w3YGcgoxDXb", "bitcoincash:qqq3728yw0y47sqn6l2na30mcw6zm78dzqre909m2r"},
+		{"3CWFddi6m4ndiGyKqzYvsFYagqDLPVMTzC", "bitcoincash:ppm2qsznhks23z7629mms6s4cwef74vcwvn0h829pq"},
+		{"3LDsS579y7sruadqu11beEJoTjdFiFCdX4", "bitcoincash:pr95sy3j9xwd2ap32xkykttr4cvcu7as4yc93ky28e"},
+		{"31nwvkZwyPdgzjBJZXfDmSWsC4ZLKpYyUw", "bitcoincash:pqq3728yw0y47sqn6l2na30mcw6zm78dzq5ucqzc37"},
+		{"qpm2qsznhks23z7629mms6s4cwef74vcwvy22gdx6a", "bitcoincash:qpm2qsznhks23z7629mms6s4cwef74vcwvy22gdx6a"},
+		{" BITCOINCASH:QPM2QSZNHKS23Z7629MMS6S4CWEF74VCWVY22GDX6A ", "bitcoincash:qpm2qsznhks23z7629mms6s4cwef74vcwvy22gdx6a"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.address, func(t *testing.T) {
+			if got := bch.NormalizeAddress(tt.address); got != tt.want {
+				t.Errorf("BitcoinCash.NormalizeAddress() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+// X-address vectors from the XLS-5d specification
+func TestRippleValidateAddress(t *testing.T) {
+	xrp := Ripple{}
+
+	tests := []struct {
+		name    string
+		address string
+		wantErr bool
+	}{
+		{"valid classic", "rHb9CJAWyB4rj91VRWn96DkukG4bwdtyTh", false},
+		{"valid classic 2", "rGWrZyQqhTp9Xu7G5Pkayo7bXjH4k4QYpf", false},
+		{"valid x-address", "XVLhHMPHU98es4dbozjVtdWzVrDjtV5fdx1mHp98tDMoQXb", false},
+		{"valid x-address with tag", "XVLhHMPHU98es4dbozjVtdWzVrDjtV8xvjGQTYPiAx6gwDC", false},
+		{"empty", "", true},
+		{"checksum typo", "rHb9CJAWyB4rj91VRWn96DkukG4bwdtyTi", true},
+		{"x-address typo", "XVLhHMPHU98es4dbozjVtdWzVrDjtV5fdx1mHp98tDMoQXc", true},
+		{"bitcoin address", "1A1zP1eP5QGefi2DMPTfTL5SLmv7DivfNa", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := xrp.ValidateAddress(tt.address)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Ripple.ValidateAddress() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestRippleNormalizeAddress(t *testing.T) {
+	xrp := Ripple{}
+
+	tests := []struct {
+		address string
+		want    string
+		wantTag string
+	}{
+		{"rGWrZyQqhTp9Xu7G5Pkayo7bXjH4k4QYpf", "rGWrZyQqhTp9Xu7G5Pkayo7bXjH4k4QYpf", ""},
+		{"XVLhHMPHU98es4dbozjVtdWzVrDjtV5fdx1mHp98tDMoQXb", "rGWrZyQqhTp9Xu7G5Pkayo7bXjH4k4QYpf", ""},
+		{"XVLhHMPHU98es4dbozjVtdWzVrDjtV8xvjGQTYPiAx6gwDC", "rGWrZyQqhTp9Xu7G5Pkayo7bXjH4k4QYpf", "1"},
+		{"XVLhHMPHU98es4dbozjVtdWzVrDjtV1N75zgFKga4R1B9Mk", "rGWrZyQqhTp9Xu7G5Pkayo7bXjH4k4QYpf", "11747"},
+		{"XVLhHMPHU98es4dbozjVtdWzVrDjtV18pX8yuPT7y4xaEHi", "rGWrZyQqhTp9Xu7G5Pkayo7bXjH4k4QYpf", "4294967295"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.address, func(t *testing.T) {
+			if got := xrp.NormalizeAddress(tt.address); got != tt.want {
+				t.Errorf("Ripple.NormalizeAddress() = %v, want %v", got, tt.want)
+			}
+			if got := xrp.AddressTag(tt.address); got != tt.wantTag {
+				t.Errorf("Ripple.AddressTag() = %v, want %v", got, tt.wantTag)
+			}
+		})
+	}
+}
+
+func TestStellarValidateAddress(t *testing.T) {
+	xlm := Stellar{}
+
+	tests := []struct {
+		name    string
+		address string
+		wantErr bool
+	}{
+		{"valid", "GA7QYNF7SOWQ3GLR2BGMZEHXAVIRZA4KVWLTJJFC7MGXUA74P7UJVSGZ", false},
+		{"valid 2", "GAAZI4TCR3TY5OJHCTJC2A4QSY6CJWJH5IAJTGKIN2ER7LBNVKOCCWN7", false},
+		{"empty", "", true},
+		{"checksum typo", "GA7QYNF7SOWQ3GLR2BGMZEHXAVIRZA4KVWLTJJFC7MGXUA74P7UJVSGA", true},
+		{"lowercase", "ga7qynf7sowq3glr2bgmzehxavirza4kvwltjjfc7mgxua74p7ujvsgz", true},
+		{"secret seed", "SBU2RRGLXH3E5CQHTD3ODLDF2BWDCYUSSBLLZ5GNW7JXHDIYKXZWHOKR", true},
+		{"too short", "GA7QYNF7SOWQ3GLR2BGMZEHXAVIRZA4KVWLTJJFC7MGXUA74P7UJVS", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := xlm.ValidateAddress(tt.address)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Stellar.ValidateAddress() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestTonValidateAddress(t *testing.T) {
+	ton := Ton{}
+
+	tests := []struct {
+		name    string
+		address string
+		wantErr bool
+	}{
+		{"valid bounceable", "EQCD39VS5jcptHL8vMjEXrzGaRcCVYto7HUn4bpAOg8xqB2N", false},
+		{"valid non-bounceable", "UQCD39VS5jcptHL8vMjEXrzGaRcCVYto7HUn4bpAOg8xqEBI", false},
+		{"valid raw", "0:83dfd552e63729b472fcbcc8c45ebcc6691702558b68ec7527e1ba403a0f31a8", false},
+		{"empty", "", true},
+		{"checksum typo", "EQCD39VS5jcptHL8vMjEXrzGaRcCVYto7HUn4bpAOg8xqB2M", true},
+		{"testnet", "kQCD39VS5jcptHL8vMjEXrzGaRcCVYto7HUn4bpAOg8xqKYH", true},
+		{"raw short hash", "0:83dfd552e63729b472fcbcc8c45ebcc6691702558b68ec7527e1ba403a0f31", true},
+		{"raw unknown workchain", "5:83dfd552e63729b472fcbcc8c45ebcc6691702558b68ec7527e1ba403a0f31a8", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := ton.ValidateAddress(tt.address)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Ton.ValidateAddress() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+
+	t.Run("normalize to raw", func(t *testing.T) {
+		want := "0:83dfd552e63729b472fcbcc8c45ebcc6691702558b68ec7527e1ba403a0f31a8"
+		for _, address := range []string{"EQCD39VS5jcptHL8vMjEXrzGaRcCVYto7HUn4bpAOg8xqB2N", "UQCD39VS5jcptHL8vMjEXrzGaRcCVYto7HUn4bpAOg8xqEBI", "0:83DFD552E63729B472FCBCC8C45EBCC6691702558B68EC7527E1BA403A0F31A8"} {
+			if got := ton.NormalizeAddress(address); got != want {
+				t.Errorf("Ton.NormalizeAddress(%s) = %v, want %v", address, got, want)
+			}
+		}
+	})
+}
+
+func TestResolveTag(t *testing.T) {
+	tests := []struct {
+		name    string
+		asset   Asset
+		address string
+		tag     string
+		want    string
+		wantErr bool
+	}{
+		{"xrp tag", Ripple{}, "rGWrZyQqhTp9Xu7G5Pkayo7bXjH4k4QYpf", " 012345 ", "12345", false},
+		{"xrp no tag", Ripple{}, "rGWrZyQqhTp9Xu7G5Pkayo7bXjH4k4QYpf", "", "", false},
+		{"xrp tag from x-address", Ripple{}, "XVLhHMPHU98es4dbozjVtdWzVrDjtV1N75zgFKga4R1B9Mk", "", "11747", false},
+		{"xrp same tag twice", Ripple{}, "XVLhHMPHU98es4dbozjVtdWzVrDjtV1N75zgFKga4R1B9Mk", "11747", "11747", false},
+		{"xrp conflicting tags", Ripple{}, "XVLhHMPHU98es4dbozjVtdWzVrDjtV1N75zgFKga4R1B9Mk", "1", "", true},
+		{"xrp text tag", Ripple{}, "rGWrZyQqhTp9Xu7G5Pkayo7bXjH4k4QYpf", "deposit", "", true},
+		{"xrp tag too large", Ripple{}, "rGWrZyQqhTp9Xu7G5Pkayo7bXjH4k4QYpf", "4294967296", "", true},
+		{"xlm text memo", Stellar{}, "GA7QYNF7SOWQ3GLR2BGMZEHXAVIRZA4KVWLTJJFC7MGXUA74P7UJVSGZ", "user 42", "user 42", false},
+		{"xlm memo too long", Stellar{}, "GA7QYNF7SOWQ3GLR2BGMZEHXAVIRZA4KVWLTJJFC7MGXUA74P7UJVSGZ", "12345678901234567890123456789", "", true},
+		{"ton comment", Ton{}, "EQCD39VS5jcptHL8vMjEXrzGaRcCVYto7HUn4bpAOg8xqB2N", "A1B2C3", "A1B2C3", false},
+		{"btc takes no tag", Bitcoin{}, "1A1zP1eP5QGefi2DMPTfTL5SLmv7DivfNa", "1", "", true},
+		{"btc without tag", Bitcoin{}, "1A1zP1eP5QGefi2DMPTfTL5SLmv7DivfNa", "", "", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ResolveTag(tt.asset, tt.address, tt.tag)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ResolveTag() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if err != nil && !errors.Is(err, ErrInvalidTag) {
+				t.Errorf("ResolveTag() error = %v, want ErrInvalidTag", err)
+			}
+			if got != tt.want {
+				t.Errorf("ResolveTag() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+// BIP-173 and BIP-350 test network vectors
+func TestBitcoinTestNetworks(t *testing.T) {
+	tests := []struct {
+		name    string
+		network NetworkType
+		address string
+		wantErr bool
+	}{
+		{"testnet P2WPKH", NetworkTestnet, "tb1qw508d6qejxtdg4y5r3zarvary0c5xw7kxpjzsx", false},
+		{"testnet P2WSH", NetworkTestnet, "tb1qrp33g0q5c5txsp9arysrx4k6zdkfs4nce4xj0gdcccefvpysxf3q0sl5k7", false},
+		{"testnet taproot", NetworkTestnet, "tb1pqqqqp399et2xygdj5xreqhjjvcmzhxw4aywxecjdzew6hylgvsesf3hn0c", false},
+		{"testnet P2PKH", NetworkTestnet, "mipcBbFg9gMiCh81Kj8tqqdgoZub1ZJRfn", false},
+		{"testnet P2SH", NetworkTestnet, "2MzQwSSnBHWHqSAqtTVQ6v47XtaisrJa1Vc", false},
+		{"testnet rejects mainnet", NetworkTestnet, "bc1qw508d6qejxtdg4y5r3zarvary0c5xw7kv8f3t4", true},
+		{"testnet rejects regtest", NetworkTestnet, "bcrt1qw508d6qejxtdg4y5r3zarvary0c5xw7kygt080", true},
+		{"regtest P2WPKH", NetworkRegtest, "bcrt1qw508d6qejxtdg4y5r3zarvary0c5xw7kygt080", false},
+		{"regtest P2PKH", NetworkRegtest, "mipcBbFg9gMiCh81Kj8tqqdgoZub1ZJRfn", false},
+		{"regtest rejects testnet segwit", NetworkRegtest, "tb1qw508d6qejxtdg4y5r3zarvary0c5xw7kxpjzsx", true},
+		{"mainnet rejects testnet segwit", NetworkMainnet, "tb1qw508d6qejxtdg4y5r3zarvary0c5xw7kxpjzsx", true},
+		{"mainnet rejects testnet P2PKH", NetworkMainnet, "mipcBbFg9gMiCh81Kj8tqqdgoZub1ZJRfn", true},
+		{"mainnet rejects testnet P2SH", NetworkMainnet, "2MzQwSSnBHWHqSAqtTVQ6v47XtaisrJa1Vc", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := Bitcoin{Network: tt.network}.ValidateAddress(tt.address)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Bitcoin.ValidateAddress() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+
+	t.Run("wrong network is named", func(t *testing.T) {
+		err := Bitcoin{}.ValidateAddress("tb1qw508d6qejxtdg4y5r3zarvary0c5xw7kxpjzsx")
+		if err == nil || !strings.Contains(err.Error(), "bitcoin testnet") {
+			t.Errorf("Bitcoin.ValidateAddress() error = %v, want it to name bitcoin testnet", err)
+		}
+	})
+
+	t.Run("chains and normalization", func(t *testing.T) {
+		testnet := Bitcoin{Network: NetworkTestnet}
+		if testnet.Chain() != "bitcoin-testnet" {
+			t.Errorf("Bitcoin.Chain() = %v, want bitcoin-testnet", testnet.Chain())
+		}
+		if got := testnet.NormalizeAddress("TB1QW508D6QEJXTDG4Y5R3ZARVARY0C5XW7KXPJZSX"); got != "tb1qw508d6qejxtdg4y5r3zarvary0c5xw7kxpjzsx" {
+			t.Errorf("Bitcoin.NormalizeAddress() = %v", got)
+		}
+		if (Bitcoin{}).Chain() != "bitcoin" || (Bitcoin{}).NetworkType() != NetworkMainnet {
+			t.Errorf("Bitcoin{} should be mainnet bitcoin")
 		}
 	})
 }
 
+func TestParseNetworkType(t *testing.T) {
+	tests := []struct {
+		value   string
+		want    NetworkType
+		wantErr bool
+	}{
+		{"", NetworkMainnet, false},
+		{"Mainnet", NetworkMainnet, false},
+		{"testnet", NetworkTestnet, false},
+		{"signet", NetworkTestnet, false},
+		{" regtest ", NetworkRegtest, false},
+		{"devnet", "", true},
+	}
 
+	for _, tt := range tests {
+		got, err := ParseNetworkType(tt.value)
+		if (err != nil) != tt.wantErr {
+			t.Errorf("ParseNetworkType(%q) error = %v, wantErr %v", tt.value, err, tt.wantErr)
+		}
+		if got != tt.want {
+			t.Errorf("ParseNetworkType(%q) = %v, want %v", tt.value, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,163 @@
+package domain
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+var ErrAuditChainBroken = errors.New("audit chain broken")
+
+type AuditAction string
+
+const (
+	AuditCheckCreated      AuditAction = "check.created"
+	AuditProviderResponded AuditAction = "provider.responded"
+	AuditCheckDecided      AuditAction = "check.decided"
+	AuditReportGenerated   AuditAction = "report.generated"
+	AuditReportDownloaded  AuditAction = "report.downloaded"
+)
+
+// actor of entries written by the pipeline rather than on behalf of a caller
+const AuditActorSystem = "system"
+
+// an append-only audit record, Hash covers every field and the previous entry's hash,
+// so editing, dropping or reordering entries breaks the chain
+type AuditEntry struct {
+	Sequence int64
+	ID       string
+	Action   AuditAction
+	// api key id, or system
+	Actor     string
+	TenantID  string
+	CheckID   string
+	Address   string
+	Details   map[string]string
+	CreatedAt time.Time
+	PrevHash  string
+	Hash      string
+}
+
+func NewAuditEntry(action AuditAction, actor, tenantID, checkID, address string, details map[string]string) *AuditEntry {
+	if details == nil {
+		details = map[string]string{}
+	}
+
+	return &AuditEntry{
+		ID:       uuid.New().String(),
+		Action:   action,
+		Actor:    actor,
+		TenantID: tenantID,
+		CheckID:  checkID,
+		Address:  address,
+		Details:  details,
+		// postgres keeps microseconds, the hash must survive a round trip
+		CreatedAt: time.Now().UTC().Truncate(time.Microsecond),
+	}
+}
+
+// places the entry after the one with prevHash, sequences start at 1 with an empty prevHash
+func (e *AuditEntry) Seal(sequence int64, prevHash string) {
+	e.Sequence = sequence
+	e.PrevHash = prevHash
+	e.Hash = e.computeHash()
+}
+
+func (e *AuditEntry) computeHash() string {
+	details := e.Details
+	if details == nil {
+		details = map[string]string{}
+	}
+
+	// encoding/json sorts map keys, so the encoding is stable
+	payload, _ := json.Marshal(struct {
+		Sequence  int64             `json:"sequence"`
+		ID        string            `json:"id"`
+		Action    AuditAction       `json:"action"`
+		Actor     string            `json:"actor"`
+		TenantID  string            `json:"tenant_id"`
+		CheckID   string            `json:"check_id"`
+		Address   string            `json:"address"`
+		Details   map[string]string `json:"details"`
+		CreatedAt string            `json:"created_at"`
+		PrevHash  string            `json:"prev_hash"`
+	}{
+		Sequence:  e.Sequence,
+		ID:        e.ID,
+		Action:    e.Action,
+		Actor:     e.Actor,
+		TenantID:  e.TenantID,
+		CheckID:   e.CheckID,
+		Address:   e.Address,
+		Details:   details,
+		CreatedAt: e.CreatedAt.UTC().Format(time.RFC3339Nano),
+		PrevHash:  e.PrevHash,
+	})
+
+	return HashPayload(payload)
+}
+
+// checks that entries directly follow prev, which is nil at the start of the log
+func VerifyAuditChain(prev *AuditEntry, entries []*AuditEntry) error {
+	for _, entry := range entries {
+		sequence, prevHash := int64(1), ""
+		if prev != nil {
+			sequence, prevHash = prev.Sequence+1, prev.Hash
+		}
+
+		if entry.Sequence != sequence {
+			return fmt.Errorf("%w: expected sequence %d, found %d", ErrAuditChainBroken, sequence, entry.Sequence)
+		}
+		if entry.PrevHash != prevHash {
+			return fmt.Errorf("%w: entry %d does not link to the previous entry", ErrAuditChainBroken, entry.Sequence)
+		}
+		if entry.Hash != entry.computeHash() {
+			return fmt.Errorf("%w: entry %d was modified", ErrAuditChainBroken, entry.Sequence)
+		}
+
+		prev = entry
+	}
+
+	return nil
+}
+
+type AuditFilter struct {
+	CheckID string
+	Address string
+	// inclusive
+	From *time.Time
+	// exclusive
+	To       *time.Time
+	Limit    int
+	TenantID string
+}
+
+func (f AuditFilter) Matches(e *AuditEntry) bool {
+	if f.CheckID != "" && f.CheckID != e.CheckID {
+		return false
+	}
+	if f.Address != "" && f.Address != e.Address {
+		return false
+	}
+	if f.TenantID != "" && f.TenantID != e.TenantID {
+		return false
+	}
+	if f.From != nil && e.CreatedAt.Before(*f.From) {
+		return false
+	}
+	if f.To != nil && !e.CreatedAt.Before(*f.To) {
+		return false
+	}
+	return true
+}
+
+// sha256 hex of a raw payload, lets us prove what a provider returned without storing it
+func HashPayload(payload []byte) string {
+	sum := sha256.Sum256(payload)
+	return hex.EncodeToString(sum[:])
+}
@@ -0,0 +1,70 @@
+package domain
+
+import (
+	"errors"
+	"testing"
+)
+
+func newTestAuditChain(n int) []*AuditEntry {
+	entries := make([]*AuditEntry, 0, n)
+	prevHash := ""
+	for i := 0; i < n; i++ {
+		entry := NewAuditEntry(AuditCheckCreated, "key", "acme", "check", "address", map[string]string{"currency": "BTC"})
+		entry.Seal(int64(i+1), prevHash)
+		prevHash = entry.Hash
+		entries = append(entries, entry)
+	}
+	return entries
+}
+
+func TestVerifyAuditChain(t *testing.T) {
+	tests := []struct {
+		name   string
+		tamper func(entries []*AuditEntry) []*AuditEntry
+	}{
+		{"modified details", func(entries []*AuditEntry) []*AuditEntry {
+			entries[1].Details["currency"] = "ETH"
+			return entries
+		}},
+		{"modified actor", func(entries []*AuditEntry) []*AuditEntry {
+			entries[2].Actor = "someone else"
+			return entries
+		}},
+		{"dropped entry", func(entries []*AuditEntry) []*AuditEntry {
+			return append(entries[:1], entries[2:]...)
+		}},
+		{"reordered entries", func(entries []*AuditEntry) []*AuditEntry {
+			entries[1], entries[2] = entries[2], entries[1]
+			return entries
+		}},
+		{"resealed entry", func(entries []*AuditEntry) []*AuditEntry {
+			entries[1].Actor = "someone else"
+			entries[1].Seal(entries[1].Sequence, entries[1].PrevHash)
+			return entries
+		}},
+	}
+
+	if err := VerifyAuditChain(nil, newTestAuditChain(3)); err != nil {
+		t.Fatalf("VerifyAuditChain() intact chain error = %v", err)
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			entries := tt.tamper(newTestAuditChain(3))
+			if err := VerifyAuditChain(nil, entries); !errors.Is(err, ErrAuditChainBroken) {
+				t.Errorf("VerifyAuditChain() error = %v, want %v", err, ErrAuditChainBroken)
+			}
+		})
+	}
+}
+
+func TestVerifyAuditChainContinues(t *testing.T) {
+	entries := newTestAuditChain(4)
+
+	if err := VerifyAuditChain(entries[1], entries[2:]); err != nil {
+		t.Errorf("VerifyAuditChain() error = %v", err)
+	}
+	if err := VerifyAuditChain(entries[0], entries[2:]); !errors.Is(err, ErrAuditChainBroken) {
+		t.Errorf("VerifyAuditChain() gap error = %v, want %v", err, ErrAuditChainBroken)
+	}
+}
@@ -0,0 +1,107 @@
+package domain
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"fmt"
+	"math/big"
+)
+
+// base58 digits in value order
+type base58Alphabet struct {
+	digits string
+	values [256]int
+}
+
+func newBase58Alphabet(digits string) *base58Alphabet {
+	alphabet := &base58Alphabet{digits: digits}
+	for i := range alphabet.values {
+		alphabet.values[i] = -1
+	}
+	for i := 0; i < len(digits); i++ {
+		alphabet.values[digits[i]] = i
+	}
+	return alphabet
+}
+
+var bitcoinBase58 = newBase58Alphabet("123456789ABCDEFGHJKLMNPQRSTUVWXYZabcdefghijkmnopqrstuvwxyz")
+
+var bigRadix = big.NewInt(58)
+
+func (a *base58Alphabet) decode(value string) ([]byte, error) {
+	if value == "" {
+		return nil, fmt.Errorf("%w: empty base58 string", ErrInvalidAddress)
+	}
+
+	number := new(big.Int)
+	for i := 0; i < len(value); i++ {
+		digit := a.values[value[i]]
+		if digit < 0 {
+			return nil, fmt.Errorf("%w: invalid base58 character %q at position %d", ErrInvalidAddress, value[i], i)
+		}
+		number.Mul(number, bigRadix)
+		number.Add(number, big.NewInt(int64(digit)))
+	}
+
+	// every leading zero digit stands for a leading zero byte
+	zeros := 0
+	for zeros < len(value) && value[zeros] == a.digits[0] {
+		zeros++
+	}
+
+	return append(make([]byte, zeros), number.Bytes()...), nil
+}
+
+func (a *base58Alphabet) encode(data []byte) string {
+	zeros := 0
+	for zeros < len(data) && data[zeros] == 0 {
+		zeros++
+	}
+
+	number := new(big.Int).SetBytes(data)
+	digits := make([]byte, 0, len(data)*138/100+1)
+	remainder := new(big.Int)
+	for number.Sign() > 0 {
+		number.DivMod(number, bigRadix, remainder)
+		digits = append(digits, a.digits[remainder.Int64()])
+	}
+	for i := 0; i < zeros; i++ {
+		digits = append(digits, a.digits[0])
+	}
+
+	for i, j := 0, len(digits)-1; i < j; i, j = i+1, j-1 {
+		digits[i], digits[j] = digits[j], digits[i]
+	}
+	return string(digits)
+}
+
+// splits a base58check string into its version byte and payload, verifying the
+// double sha256 checksum
+func (a *base58Alphabet) decodeCheck(value string) (byte, []byte, error) {
+	decoded, err := a.decode(value)
+	if err != nil {
+		return 0, nil, err
+	}
+
+	if len(decoded) < 5 {
+		return 0, nil, fmt.Errorf("%w: base58check data is %d bytes, too short for a version byte and checksum", ErrInvalidAddress, len(decoded))
+	}
+
+	body, checksum := decoded[:len(decoded)-4], decoded[len(decoded)-4:]
+	if !bytes.Equal(checksum, doubleSHA256(body)[:4]) {
+		return 0, nil, fmt.Errorf("%w: base58check checksum mismatch", ErrInvalidAddress)
+	}
+
+	return body[0], body[1:], nil
+}
+
+func (a *base58Alphabet) encodeCheck(version byte, payload []byte) string {
+	body := append([]byte{version}, payload...)
+	return a.encode(append(body, doubleSHA256(body)[:4]...))
+}
+
+func doubleSHA256(data []byte) []byte {
+	first := sha256.Sum256(data)
+	second := sha256.Sum256(first[:])
+	return second[:]
+}
@@ -0,0 +1,62 @@
+package domain
+
+import (
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+var (
+	ErrBatchNotFound = errors.New("batch not found")
+	ErrBatchExpired  = errors.New("batch expired")
+	ErrInvalidBatch  = errors.New("invalid batch")
+	// the consolidated report needs every check to be finished
+	ErrBatchNotReady = errors.New("batch is still processing")
+)
+
+// one requested address, CheckID is empty when the item was rejected up front
+type BatchItem struct {
+	Address  string `json:"address"`
+	Currency string `json:"currency"`
+	CheckID  string `json:"check_id,omitempty"`
+	Error    string `json:"error,omitempty"`
+	// as requested, empty means the default network of the currency
+	Network string `json:"network,omitempty"`
+	// destination tag, memo or comment as requested
+	Tag string `json:"tag,omitempty"`
+}
+
+// a group of checks requested together, the checks themselves are regular AMLChecks
+type Batch struct {
+	ID        string
+	TenantID  string
+	Items     []BatchItem
+	CreatedAt time.Time
+	ExpiresAt time.Time
+}
+
+func NewBatch(tenantID string, items []BatchItem, ttl time.Duration) *Batch {
+	now := time.Now().UTC()
+	return &Batch{
+		ID:        uuid.New().String(),
+		TenantID:  tenantID,
+		Items:     items,
+		CreatedAt: now,
+		ExpiresAt: now.Add(ttl),
+	}
+}
+
+func (b *Batch) IsExpired() bool {
+	return time.Now().UTC().After(b.ExpiresAt)
+}
+
+func (b *Batch) CheckIDs() []string {
+	checkIDs := make([]string, 0, len(b.Items))
+	for _, item := range b.Items {
+		if item.CheckID != "" {
+			checkIDs = append(checkIDs, item.CheckID)
+		}
+	}
+	return checkIDs
+}
@@ -0,0 +1,215 @@
+package domain
+
+import (
+	"fmt"
+	"strings"
+)
+
+// BIP-173 and BIP-350
+type bech32Encoding int
+
+const (
+	bech32Standard bech32Encoding = iota + 1
+	bech32M
+)
+
+func (e bech32Encoding) String() string {
+	if e == bech32M {
+		return "bech32m"
+	}
+	return "bech32"
+}
+
+func (e bech32Encoding) constant() uint32 {
+	if e == bech32M {
+		return 0x2bc830a3
+	}
+	return 1
+}
+
+const (
+	bech32Charset   = "qpzry9x8gf2tvdw0s3jn54khce6mua7l"
+	bech32MaxLength = 90
+)
+
+var bech32Generator = [5]uint32{0x3b6a57b2, 0x26508e6d, 0x1ea119fa, 0x3d4233dd, 0x2a1462b3}
+
+func bech32Polymod(values []byte) uint32 {
+	checksum := uint32(1)
+	for _, value := range values {
+		top := checksum >> 25
+		checksum = (checksum&0x1ffffff)<<5 ^ uint32(value)
+		for i := 0; i < 5; i++ {
+			if (top>>i)&1 == 1 {
+				checksum ^= bech32Generator[i]
+			}
+		}
+	}
+	return checksum
+}
+
+func bech32ExpandHRP(hrp string) []byte {
+	expanded := make([]byte, 0, len(hrp)*2+1)
+	for i := 0; i < len(hrp); i++ {
+		expanded = append(expanded, hrp[i]>>5)
+	}
+	expanded = append(expanded, 0)
+	for i := 0; i < len(hrp); i++ {
+		expanded = append(expanded, hrp[i]&31)
+	}
+	return expanded
+}
+
+// returns the human readable part, the 5-bit data without checksum and the
+// checksum variant that matched
+func bech32Decode(value string) (string, []byte, bech32Encoding, error) {
+	if len(value) > bech32MaxLength {
+		return "", nil, 0, fmt.Errorf("%w: bech32 string is %d characters, at most %d allowed", ErrInvalidAddress, len(value), bech32MaxLength)
+	}
+
+	if strings.ToLower(value) != value && strings.ToUpper(value) != value {
+		return "", nil, 0, fmt.Errorf("%w: bech32 string mixes upper and lower case", ErrInvalidAddress)
+	}
+	value = strings.ToLower(value)
+
+	separator := strings.LastIndexByte(value, '1')
+	if separator < 1 {
+		return "", nil, 0, fmt.Errorf("%w: bech32 string has no human readable part", ErrInvalidAddress)
+	}
+	if separator+7 > len(value) {
+		return "", nil, 0, fmt.Errorf("%w: bech32 data part is too short for a checksum", ErrInvalidAddress)
+	}
+
+	hrp := value[:separator]
+	for i := 0; i < len(hrp); i++ {
+		if hrp[i] < 33 || hrp[i] > 126 {
+			return "", nil, 0, fmt.Errorf("%w: invalid bech32 human readable character at position %d", ErrInvalidAddress, i)
+		}
+	}
+
+	data := make([]byte, 0, len(value)-separator-1)
+	for i := separator + 1; i < len(value); i++ {
+		digit := strings.IndexByte(bech32Charset, value[i])
+		if digit < 0 {
+			return "", nil, 0, fmt.Errorf("%w: invalid bech32 character %q at position %d", ErrInvalidAddress, value[i], i)
+		}
+		data = append(data, byte(digit))
+	}
+
+	var encoding bech32Encoding
+	switch bech32Polymod(append(bech32ExpandHRP(hrp), data...)) {
+	case bech32Standard.constant():
+		encoding = bech32Standard
+	case bech32M.constant():
+		encoding = bech32M
+	default:
+		return "", nil, 0, fmt.Errorf("%w: bech32 checksum mismatch", ErrInvalidAddress)
+	}
+
+	return hrp, data[:len(data)-6], encoding, nil
+}
+
+func bech32Encode(hrp string, data []byte, encoding bech32Encoding) string {
+	values := append(bech32ExpandHRP(hrp), data...)
+	polymod := bech32Polymod(append(values, 0, 0, 0, 0, 0, 0)) ^ encoding.constant()
+
+	var encoded strings.Builder
+	encoded.WriteString(hrp)
+	encoded.WriteByte('1')
+	for _, value := range data {
+		encoded.WriteByte(bech32Charset[value])
+	}
+	for i := 0; i < 6; i++ {
+		encoded.WriteByte(bech32Charset[(polymod>>(5*(5-i)))&31])
+	}
+	return encoded.String()
+}
+
+// regroups bits, padding is only allowed when widening
+func convertBits(data []byte, from, to uint, pad bool) ([]byte, error) {
+	var (
+		accumulator uint
+		bits        uint
+		converted   = make([]byte, 0, len(data)*int(from)/int(to)+1)
+		maxValue    = uint(1)<<to - 1
+	)
+
+	for _, value := range data {
+		if uint(value)>>from != 0 {
+			return nil, fmt.Errorf("%w: value %d does not fit in %d bits", ErrInvalidAddress, value, from)
+		}
+		accumulator = accumulator<<from | uint(value)
+		bits += from
+		for bits >= to {
+			bits -= to
+			converted = append(converted, byte(accumulator>>bits&maxValue))
+		}
+	}
+
+	if pad {
+		if bits > 0 {
+			converted = append(converted, byte(accumulator<<(to-bits)&maxValue))
+		}
+	} else if bits >= from || accumulator<<(to-bits)&maxValue != 0 {
+		return nil, fmt.Errorf("%w: invalid padding in bech32 data", ErrInvalidAddress)
+	}
+
+	return converted, nil
+}
+
+// decodes a segwit address for the given network prefix, version 0 must use bech32
+// and later versions bech32m
+func decodeSegwitAddress(hrp, address string) (byte, []byte, error) {
+	decodedHRP, data, encoding, err := bech32Decode(address)
+	if err != nil {
+		return 0, nil, err
+	}
+
+	if decodedHRP != hrp {
+		return 0, nil, fmt.Errorf("%w: segwit prefix is %q, want %q", ErrInvalidAddress, decodedHRP, hrp)
+	}
+
+	if len(data) < 1 {
+		return 0, nil, fmt.Errorf("%w: segwit address has no witness version", ErrInvalidAddress)
+	}
+
+	version := data[0]
+	if version > 16 {
+		return 0, nil, fmt.Errorf("%w: witness version %d is above 16", ErrInvalidAddress, version)
+	}
+
+	program, err := convertBits(data[1:], 5, 8, false)
+	if err != nil {
+		return 0, nil, err
+	}
+
+	if len(program) < 2 || len(program) > 40 {
+		return 0, nil, fmt.Errorf("%w: witness program is %d bytes, want 2 to 40", ErrInvalidAddress, len(program))
+	}
+
+	if version == 0 && len(program) != 20 && len(program) != 32 {
+		return 0, nil, fmt.Errorf("%w: witness v0 program is %d bytes, want 20 (P2WPKH) or 32 (P2WSH)", ErrInvalidAddress, len(program))
+	}
+
+	if version == 0 && encoding != bech32Standard {
+		return 0, nil, fmt.Errorf("%w: witness v0 address must use bech32, not %s", ErrInvalidAddress, encoding)
+	}
+	if version != 0 && encoding != bech32M {
+		return 0, nil, fmt.Errorf("%w: witness v%d address must use bech32m, not %s", ErrInvalidAddress, version, encoding)
+	}
+
+	return version, program, nil
+}
+
+func encodeSegwitAddress(hrp string, version byte, program []byte) (string, error) {
+	data, err := convertBits(program, 8, 5, true)
+	if err != nil {
+		return "", err
+	}
+
+	encoding := bech32M
+	if version == 0 {
+		encoding = bech32Standard
+	}
+	return bech32Encode(hrp, append([]byte{version}, data...), encoding), nil
+}
@@ -0,0 +1,32 @@
+package domain
+
+import (
+	"context"
+	"time"
+)
+
+// set when a provider result was served from cache
+type CacheInfo struct {
+	CachedAt time.Time `json:"cached_at"`
+	// age of the result when the check used it
+	AgeSeconds int64 `json:"age_seconds"`
+}
+
+func NewCacheInfo(cachedAt, now time.Time) *CacheInfo {
+	return &CacheInfo{
+		CachedAt:   cachedAt,
+		AgeSeconds: int64(now.Sub(cachedAt) / time.Second),
+	}
+}
+
+type cacheBypassKey struct{}
+
+// asks cached providers to call through and refresh their entry
+func ContextWithCacheBypass(ctx context.Context) context.Context {
+	return context.WithValue(ctx, cacheBypassKey{}, true)
+}
+
+func CacheBypassed(ctx context.Context) bool {
+	bypass, _ := ctx.Value(cacheBypassKey{}).(bool)
+	return bypass
+}
@@ -0,0 +1,107 @@
+package domain
+
+import (
+	"fmt"
+	"strings"
+)
+
+// bitcoin cash CashAddr, bech32's charset with a 40-bit BCH checksum
+const cashAddrChecksumLength = 8
+
+var cashAddrGenerator = [5]uint64{0x98f2bc8e61, 0x79b76d99e2, 0xf33e5fb3c4, 0xae2eabe2a8, 0x1e4f43e470}
+
+func cashAddrPolymod(values []byte) uint64 {
+	checksum := uint64(1)
+	for _, value := range values {
+		top := checksum >> 35
+		checksum = (checksum&0x07ffffffff)<<5 ^ uint64(value)
+		for i := 0; i < 5; i++ {
+			if (top>>i)&1 == 1 {
+				checksum ^= cashAddrGenerator[i]
+			}
+		}
+	}
+	return checksum ^ 1
+}
+
+// the low five bits of every prefix character followed by the separator
+func cashAddrExpandPrefix(prefix string) []byte {
+	expanded := make([]byte, 0, len(prefix)+1)
+	for i := 0; i < len(prefix); i++ {
+		expanded = append(expanded, prefix[i]&31)
+	}
+	return append(expanded, 0)
+}
+
+// hash sizes in bytes by the low three bits of the version byte
+var cashAddrHashSizes = [8]int{20, 24, 28, 32, 40, 48, 56, 64}
+
+// decodes a CashAddr for the given prefix, the prefix itself may be left out
+func decodeCashAddr(prefix, address string) (byte, []byte, error) {
+	if strings.ToLower(address) != address && strings.ToUpper(address) != address {
+		return 0, nil, fmt.Errorf("%w: cashaddr mixes upper and lower case", ErrInvalidAddress)
+	}
+	address = strings.ToLower(address)
+
+	if separator := strings.IndexByte(address, ':'); separator >= 0 {
+		if address[:separator] != prefix {
+			return 0, nil, fmt.Errorf("%w: cashaddr prefix is %q, want %q", ErrInvalidAddress, address[:separator], prefix)
+		}
+		address = address[separator+1:]
+	}
+
+	if len(address) <= cashAddrChecksumLength {
+		return 0, nil, fmt.Errorf("%w: cashaddr payload is too short for a checksum", ErrInvalidAddress)
+	}
+
+	data := make([]byte, 0, len(address))
+	for i := 0; i < len(address); i++ {
+		digit := strings.IndexByte(bech32Charset, address[i])
+		if digit < 0 {
+			return 0, nil, fmt.Errorf("%w: invalid cashaddr character %q at position %d", ErrInvalidAddress, address[i], i)
+		}
+		data = append(data, byte(digit))
+	}
+
+	if cashAddrPolymod(append(cashAddrExpandPrefix(prefix), data...)) != 0 {
+		return 0, nil, fmt.Errorf("%w: cashaddr checksum mismatch", ErrInvalidAddress)
+	}
+
+	payload, err := convertBits(data[:len(data)-cashAddrChecksumLength], 5, 8, false)
+	if err != nil {
+		return 0, nil, err
+	}
+	if len(payload) < 1 {
+		return 0, nil, fmt.Errorf("%w: cashaddr has no version byte", ErrInvalidAddress)
+	}
+
+	version, hash := payload[0], payload[1:]
+	if version&0x80 != 0 {
+		return 0, nil, fmt.Errorf("%w: cashaddr version byte 0x%02x has the reserved bit set", ErrInvalidAddress, version)
+	}
+	if size := cashAddrHashSizes[version&0x07]; len(hash) != size {
+		return 0, nil, fmt.Errorf("%w: cashaddr hash is %d bytes, version 0x%02x wants %d", ErrInvalidAddress, len(hash), version, size)
+	}
+
+	return version, hash, nil
+}
+
+func encodeCashAddr(prefix string, version byte, hash []byte) (string, error) {
+	data, err := convertBits(append([]byte{version}, hash...), 8, 5, true)
+	if err != nil {
+		return "", err
+	}
+
+	polymod := cashAddrPolymod(append(append(cashAddrExpandPrefix(prefix), data...), make([]byte, cashAddrChecksumLength)...))
+
+	var encoded strings.Builder
+	encoded.WriteString(prefix)
+	encoded.WriteByte(':')
+	for _, value := range data {
+		encoded.WriteByte(bech32Charset[value])
+	}
+	for i := 0; i < cashAddrChecksumLength; i++ {
+		encoded.WriteByte(bech32Charset[(polymod>>(5*(cashAddrChecksumLength-1-i)))&31])
+	}
+	return encoded.String(), nil
+}
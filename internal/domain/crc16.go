@@ -0,0 +1,17 @@
+package domain
+
+// CRC-16/XMODEM, used by stellar strkeys and ton addresses
+func crc16XModem(data []byte) uint16 {
+	crc := uint16(0)
+	for _, value := range data {
+		crc ^= uint16(value) << 8
+		for i := 0; i < 8; i++ {
+			if crc&0x8000 != 0 {
+				crc = crc<<1 ^ 0x1021
+			} else {
+				crc <<= 1
+			}
+		}
+	}
+	return crc
+}
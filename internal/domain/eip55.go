@@ -0,0 +1,62 @@
+package domain
+
+import (
+	"fmt"
+	"strings"
+
+	"golang.org/x/crypto/sha3"
+)
+
+// mixed-case checksum encoding of a 0x-prefixed ethereum address
+func eip55Checksum(address string) string {
+	lower := strings.ToLower(strings.TrimPrefix(address, "0x"))
+
+	hash := sha3.NewLegacyKeccak256()
+	hash.Write([]byte(lower))
+	digest := hash.Sum(nil)
+
+	checksummed := []byte(lower)
+	for i, char := range checksummed {
+		if char < 'a' || char > 'f' {
+			continue
+		}
+		// the nibble of the hash at the same position decides the case
+		nibble := digest[i/2] >> 4
+		if i%2 == 1 {
+			nibble = digest[i/2] & 0x0f
+		}
+		if nibble >= 8 {
+			checksummed[i] = char - 'a' + 'A'
+		}
+	}
+
+	return "0x" + string(checksummed)
+}
+
+// all-lowercase and all-uppercase addresses carry no checksum, mixed case must match EIP-55
+func validateEVMAddress(address string) error {
+	if !strings.HasPrefix(address, "0x") {
+		return fmt.Errorf("%w: address must start with 0x", ErrInvalidAddress)
+	}
+
+	digits := address[2:]
+	if len(digits) != 40 {
+		return fmt.Errorf("%w: address has %d hex digits, want 40", ErrInvalidAddress, len(digits))
+	}
+
+	for i := 0; i < len(digits); i++ {
+		if strings.IndexByte("0123456789abcdefABCDEF", digits[i]) < 0 {
+			return fmt.Errorf("%w: invalid hex character %q at position %d", ErrInvalidAddress, digits[i], i+2)
+		}
+	}
+
+	if digits == strings.ToLower(digits) || digits == strings.ToUpper(digits) {
+		return nil
+	}
+
+	if expected := eip55Checksum(address); address != expected {
+		return fmt.Errorf("%w: EIP-55 checksum mismatch, expected %s", ErrInvalidAddress, expected)
+	}
+
+	return nil
+}
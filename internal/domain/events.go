@@ -11,6 +11,7 @@ const (
 	EventAMLCheckCompleted = "aml.check.completed"
 	EventAMLReportReady    = "aml.report.ready"
 	EventAMLCheckFailed    = "aml.check.failed"
+	EventAMLCaseClosed     = "aml.case.closed"
 )
 
 type Event struct {
@@ -22,16 +23,30 @@ type Event struct {
 
 type AMLCheckRequestedPayload struct {
 	CheckID  string `json:"check_id"`
+	TenantID string `json:"tenant_id,omitempty"`
 	Address  string `json:"address"`
 	Currency string `json:"currency"`
+	Chain    string `json:"chain"`
+	// skip cached provider results and refresh them
+	BypassCache bool `json:"bypass_cache,omitempty"`
+	// destination tag, memo or comment, not used by the lookups
+	Tag string `json:"tag,omitempty"`
+	// tells consumers a check is on a test network, the lookups go by chain
+	NetworkType NetworkType `json:"network_type,omitempty"`
 }
 
 type AMLCheckCompletedPayload struct {
-	CheckID    string           `json:"check_id"`
-	RiskScore  int              `json:"risk_score"`
-	RiskLevel  RiskLevel        `json:"risk_level"`
-	Categories []string         `json:"categories"`
-	Sanctions  *SanctionsResult `json:"sanctions"`
+	CheckID      string           `json:"check_id"`
+	RiskScore    int              `json:"risk_score"`
+	RiskLevel    RiskLevel        `json:"risk_level"`
+	Categories   []string         `json:"categories"`
+	Sanctions    *SanctionsResult `json:"sanctions"`
+	Providers    []ProviderResult `json:"providers"`
+	Watchlist    *WatchlistMatch  `json:"watchlist,omitempty"`
+	Decision     Decision         `json:"decision"`
+	MatchedRules []MatchedRule    `json:"matched_rules"`
+	// outcome of each provider lookup, an unavailable sanctions lookup is not a clean one
+	ProviderStatuses []ProviderStatus `json:"provider_statuses"`
 }
 
 type AMLReportReadyPayload struct {
@@ -44,6 +59,16 @@ type AMLCheckFailedPayload struct {
 	ErrorMessage string `json:"error_message"`
 }
 
+type AMLCaseClosedPayload struct {
+	CaseID      string          `json:"case_id"`
+	CheckID     string          `json:"check_id"`
+	Address     string          `json:"address"`
+	Currency    string          `json:"currency"`
+	Disposition CaseDisposition `json:"disposition"`
+	Assignee    string          `json:"assignee"`
+	ClosedAt    time.Time       `json:"closed_at"`
+}
+
 func NewEvent(eventType string, payload any) *Event {
 	return &Event{
 		ID:        time.Now().Format("20060102150405.000000"),
@@ -0,0 +1,34 @@
+package domain
+
+import (
+	"fmt"
+	"strings"
+)
+
+// the ledger an address lives on, checks on test networks never reach the paid providers
+type NetworkType string
+
+const (
+	NetworkMainnet NetworkType = "mainnet"
+	NetworkTestnet NetworkType = "testnet"
+	NetworkRegtest NetworkType = "regtest"
+)
+
+// empty means mainnet, signet shares testnet's address format
+func ParseNetworkType(value string) (NetworkType, error) {
+	switch strings.ToLower(strings.TrimSpace(value)) {
+	case "", string(NetworkMainnet):
+		return NetworkMainnet, nil
+	case string(NetworkTestnet), "signet":
+		return NetworkTestnet, nil
+	case string(NetworkRegtest):
+		return NetworkRegtest, nil
+	default:
+		return "", fmt.Errorf("unknown network type %q, want mainnet, testnet or regtest", value)
+	}
+}
+
+// checks stored before network types existed have none and are mainnet
+func (n NetworkType) IsTest() bool {
+	return n != "" && n != NetworkMainnet
+}
@@ -0,0 +1,33 @@
+package domain
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// an event waiting to be relayed to the message bus, persisted together
+// with the state change that produced it
+type OutboxMessage struct {
+	ID          string
+	RoutingKey  string
+	Event       *Event
+	Attempts    int
+	LastError   string
+	CreatedAt   time.Time
+	LockedUntil *time.Time
+	SentAt      *time.Time
+}
+
+func NewOutboxMessage(routingKey string, event *Event) *OutboxMessage {
+	return &OutboxMessage{
+		ID:         uuid.New().String(),
+		RoutingKey: routingKey,
+		Event:      event,
+		CreatedAt:  time.Now().UTC(),
+	}
+}
+
+func (m *OutboxMessage) IsSent() bool {
+	return m.SentAt != nil
+}
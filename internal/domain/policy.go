@@ -0,0 +1,253 @@
+package domain
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+var ErrInvalidPolicy = errors.New("invalid policy")
+
+type Decision string
+
+const (
+	DecisionApprove Decision = "approve"
+	DecisionReview  Decision = "review"
+	DecisionReject  Decision = "reject"
+)
+
+// orders decisions from least to most restrictive
+func (d Decision) Rank() int {
+	switch d {
+	case DecisionApprove:
+		return 1
+	case DecisionReview:
+		return 2
+	case DecisionReject:
+		return 3
+	}
+	return 0
+}
+
+func ParseDecision(value string) (Decision, error) {
+	decision := Decision(strings.ToLower(strings.TrimSpace(value)))
+	if decision.Rank() == 0 {
+		return "", fmt.Errorf("%w: unknown decision %q", ErrInvalidPolicy, value)
+	}
+	return decision, nil
+}
+
+// a rule that contributed to a decision
+type MatchedRule struct {
+	ID          string   `json:"id"`
+	Description string   `json:"description,omitempty"`
+	Decision    Decision `json:"decision"`
+}
+
+// every set condition must hold for the rule to match, empty conditions always match
+type RuleCondition struct {
+	MinScore      *int     `json:"min_score,omitempty" yaml:"min_score,omitempty"`
+	MaxScore      *int     `json:"max_score,omitempty" yaml:"max_score,omitempty"`
+	RiskLevels    []string `json:"risk_levels,omitempty" yaml:"risk_levels,omitempty"`
+	CategoriesAny []string `json:"categories_any,omitempty" yaml:"categories_any,omitempty"`
+	Sanctioned    *bool    `json:"sanctioned,omitempty" yaml:"sanctioned,omitempty"`
+	Assets        []string `json:"assets,omitempty" yaml:"assets,omitempty"`
+	Chains        []string `json:"chains,omitempty" yaml:"chains,omitempty"`
+	Watchlist     []string `json:"watchlist,omitempty" yaml:"watchlist,omitempty"`
+}
+
+type PolicyRule struct {
+	ID          string        `json:"id" yaml:"id"`
+	Description string        `json:"description,omitempty" yaml:"description,omitempty"`
+	Decision    Decision      `json:"decision" yaml:"decision"`
+	When        RuleCondition `json:"when" yaml:"when"`
+}
+
+// declarative risk appetite, the most restrictive matching rule wins
+type Policy struct {
+	Version         string       `json:"version,omitempty" yaml:"version,omitempty"`
+	DefaultDecision Decision     `json:"default_decision" yaml:"default_decision"`
+	Rules           []PolicyRule `json:"rules" yaml:"rules"`
+	// fail checks whose sanctions screening was skipped or errored instead of deciding without it
+	RequireSanctionsScreening bool `json:"require_sanctions_screening,omitempty" yaml:"require_sanctions_screening,omitempty"`
+}
+
+// the signals a policy is evaluated against
+type PolicyInput struct {
+	RiskScore  int
+	RiskLevel  RiskLevel
+	Categories []string
+	Sanctions  *SanctionsResult
+	Asset      string
+	Chain      string
+	Watchlist  *WatchlistMatch
+}
+
+type PolicyResult struct {
+	Decision     Decision
+	MatchedRules []MatchedRule
+}
+
+// mirrors the historic risk level thresholds
+func DefaultPolicy() *Policy {
+	sanctioned := true
+	return &Policy{
+		Version:         "default",
+		DefaultDecision: DecisionApprove,
+		Rules: []PolicyRule{
+			{
+				ID:          "sanctions-hit",
+				Description: "Address is on a sanctions list",
+				Decision:    DecisionReject,
+				When:        RuleCondition{Sanctioned: &sanctioned},
+			},
+			{
+				ID:          "internal-blocklist",
+				Description: "Address is on the internal blocklist",
+				Decision:    DecisionReject,
+				When:        RuleCondition{Watchlist: []string{string(WatchlistBlocklist)}},
+			},
+			{
+				ID:          "critical-risk",
+				Description: "Critical risk level",
+				Decision:    DecisionReject,
+				When:        RuleCondition{RiskLevels: []string{string(RiskLevelCritical)}},
+			},
+			{
+				ID:          "high-risk",
+				Description: "High risk level",
+				Decision:    DecisionReview,
+				When:        RuleCondition{RiskLevels: []string{string(RiskLevelHigh)}},
+			},
+		},
+	}
+}
+
+func (p *Policy) Validate() error {
+	if p.DefaultDecision == "" {
+		p.DefaultDecision = DecisionApprove
+	}
+	if p.DefaultDecision.Rank() == 0 {
+		return fmt.Errorf("%w: unknown default decision %q", ErrInvalidPolicy, p.DefaultDecision)
+	}
+
+	seen := make(map[string]bool, len(p.Rules))
+	for i, rule := range p.Rules {
+		if rule.ID == "" {
+			return fmt.Errorf("%w: rule %d has no id", ErrInvalidPolicy, i)
+		}
+		if seen[rule.ID] {
+			return fmt.Errorf("%w: duplicate rule id %q", ErrInvalidPolicy, rule.ID)
+		}
+		seen[rule.ID] = true
+
+		decision, err := ParseDecision(string(rule.Decision))
+		if err != nil {
+			return fmt.Errorf("rule %q: %w", rule.ID, err)
+		}
+		p.Rules[i].Decision = decision
+
+		for _, level := range rule.When.RiskLevels {
+			if RiskLevel(level).Rank() == 0 {
+				return fmt.Errorf("%w: rule %q has unknown risk level %q", ErrInvalidPolicy, rule.ID, level)
+			}
+		}
+
+		for _, listType := range rule.When.Watchlist {
+			if _, err := ParseWatchlistType(listType); err != nil {
+				return fmt.Errorf("%w: rule %q has unknown watchlist type %q", ErrInvalidPolicy, rule.ID, listType)
+			}
+		}
+	}
+
+	return nil
+}
+
+func (p *Policy) Evaluate(input PolicyInput) PolicyResult {
+	result := PolicyResult{
+		Decision:     p.DefaultDecision,
+		MatchedRules: []MatchedRule{},
+	}
+
+	var strictest Decision
+	for _, rule := range p.Rules {
+		if !rule.When.matches(input) {
+			continue
+		}
+
+		result.MatchedRules = append(result.MatchedRules, MatchedRule{
+			ID:          rule.ID,
+			Description: rule.Description,
+			Decision:    rule.Decision,
+		})
+
+		if rule.Decision.Rank() > strictest.Rank() {
+			strictest = rule.Decision
+		}
+	}
+
+	if strictest != "" {
+		result.Decision = strictest
+	}
+
+	return result
+}
+
+func (c RuleCondition) matches(input PolicyInput) bool {
+	if c.MinScore != nil && input.RiskScore < *c.MinScore {
+		return false
+	}
+
+	if c.MaxScore != nil && input.RiskScore > *c.MaxScore {
+		return false
+	}
+
+	if len(c.RiskLevels) > 0 && !containsFold(c.RiskLevels, string(input.RiskLevel)) {
+		return false
+	}
+
+	if len(c.CategoriesAny) > 0 && !anyContainsFold(c.CategoriesAny, input.Categories) {
+		return false
+	}
+
+	if c.Sanctioned != nil {
+		hit := input.Sanctions != nil && input.Sanctions.Hit
+		if hit != *c.Sanctioned {
+			return false
+		}
+	}
+
+	if len(c.Assets) > 0 && !containsFold(c.Assets, input.Asset) {
+		return false
+	}
+
+	if len(c.Chains) > 0 && !containsFold(c.Chains, input.Chain) {
+		return false
+	}
+
+	if len(c.Watchlist) > 0 {
+		if input.Watchlist == nil || !containsFold(c.Watchlist, string(input.Watchlist.ListType)) {
+			return false
+		}
+	}
+
+	return true
+}
+
+func containsFold(values []string, target string) bool {
+	for _, value := range values {
+		if strings.EqualFold(value, target) {
+			return true
+		}
+	}
+	return false
+}
+
+func anyContainsFold(values, targets []string) bool {
+	for _, target := range targets {
+		if containsFold(values, target) {
+			return true
+		}
+	}
+	return false
+}
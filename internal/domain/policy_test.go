@@ -0,0 +1,144 @@
+package domain
+
+import (
+	"errors"
+	"testing"
+)
+
+func intPtr(v int) *int { return &v }
+
+func TestPolicyEvaluate(t *testing.T) {
+	sanctioned := true
+	policy := &Policy{
+		DefaultDecision: DecisionApprove,
+		Rules: []PolicyRule{
+			{ID: "sanctions", Decision: DecisionReject, When: RuleCondition{Sanctioned: &sanctioned}},
+			{ID: "darknet", Decision: DecisionReject, When: RuleCondition{CategoriesAny: []string{"Darknet"}}},
+			{ID: "usdt-elevated", Decision: DecisionReview, When: RuleCondition{Assets: []string{"USDT"}, MinScore: intPtr(50)}},
+			{ID: "high", Decision: DecisionReview, When: RuleCondition{RiskLevels: []string{"High"}}},
+			{ID: "blocklist", Decision: DecisionReject, When: RuleCondition{Watchlist: []string{"blocklist"}}},
+			{ID: "tron-low", Decision: DecisionApprove, When: RuleCondition{Chains: []string{"tron"}, MaxScore: intPtr(10)}},
+		},
+	}
+	if err := policy.Validate(); err != nil {
+		t.Fatalf("Validate() error = %v", err)
+	}
+
+	tests := []struct {
+		name         string
+		input        PolicyInput
+		wantDecision Decision
+		wantRules    []string
+	}{
+		{
+			name:         "no rule matches",
+			input:        PolicyInput{RiskScore: 10, RiskLevel: RiskLevelLow, Asset: "BTC", Chain: "bitcoin"},
+			wantDecision: DecisionApprove,
+			wantRules:    []string{},
+		},
+		{
+			name:         "category match is case-insensitive",
+			input:        PolicyInput{RiskScore: 20, RiskLevel: RiskLevelLow, Categories: []string{"darknet"}},
+			wantDecision: DecisionReject,
+			wantRules:    []string{"darknet"},
+		},
+		{
+			name:         "all conditions must hold",
+			input:        PolicyInput{RiskScore: 40, RiskLevel: RiskLevelMedium, Asset: "USDT"},
+			wantDecision: DecisionApprove,
+			wantRules:    []string{},
+		},
+		{
+			name:         "asset and score",
+			input:        PolicyInput{RiskScore: 55, RiskLevel: RiskLevelMedium, Asset: "USDT"},
+			wantDecision: DecisionReview,
+			wantRules:    []string{"usdt-elevated"},
+		},
+		{
+			name: "most restrictive decision wins",
+			input: PolicyInput{
+				RiskScore: 65,
+				RiskLevel: RiskLevelHigh,
+				Sanctions: &SanctionsResult{Hit: true},
+			},
+			wantDecision: DecisionReject,
+			wantRules:    []string{"sanctions", "high"},
+		},
+		{
+			name:         "watchlist",
+			input:        PolicyInput{RiskScore: 100, RiskLevel: RiskLevelCritical, Watchlist: &WatchlistMatch{ListType: WatchlistBlocklist}},
+			wantDecision: DecisionReject,
+			wantRules:    []string{"blocklist"},
+		},
+		{
+			name:         "chain and max score",
+			input:        PolicyInput{RiskScore: 5, RiskLevel: RiskLevelLow, Chain: "tron"},
+			wantDecision: DecisionApprove,
+			wantRules:    []string{"tron-low"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := policy.Evaluate(tt.input)
+			if result.Decision != tt.wantDecision {
+				t.Errorf("Evaluate() Decision = %v, want %v", result.Decision, tt.wantDecision)
+			}
+
+			if len(result.MatchedRules) != len(tt.wantRules) {
+				t.Fatalf("Evaluate() MatchedRules = %v, want %v", result.MatchedRules, tt.wantRules)
+			}
+			for i, id := range tt.wantRules {
+				if result.MatchedRules[i].ID != id {
+					t.Errorf("Evaluate() MatchedRules[%d] = %v, want %v", i, result.MatchedRules[i].ID, id)
+				}
+			}
+		})
+	}
+}
+
+func TestDefaultPolicy(t *testing.T) {
+	policy := DefaultPolicy()
+	if err := policy.Validate(); err != nil {
+		t.Fatalf("Validate() error = %v", err)
+	}
+
+	tests := []struct {
+		score int
+		want  Decision
+	}{
+		{0, DecisionApprove},
+		{45, DecisionApprove},
+		{65, DecisionReview},
+		{90, DecisionReject},
+	}
+
+	for _, tt := range tests {
+		result := policy.Evaluate(PolicyInput{RiskScore: tt.score, RiskLevel: DeriveRiskLevel(tt.score)})
+		if result.Decision != tt.want {
+			t.Errorf("Evaluate(%d) Decision = %v, want %v", tt.score, result.Decision, tt.want)
+		}
+	}
+}
+
+func TestPolicyValidate(t *testing.T) {
+	tests := []struct {
+		name   string
+		policy Policy
+	}{
+		{"missing id", Policy{Rules: []PolicyRule{{Decision: DecisionReject}}}},
+		{"duplicate id", Policy{Rules: []PolicyRule{{ID: "a", Decision: DecisionReject}, {ID: "a", Decision: DecisionReview}}}},
+		{"unknown decision", Policy{Rules: []PolicyRule{{ID: "a", Decision: "block"}}}},
+		{"unknown default", Policy{DefaultDecision: "maybe"}},
+		{"unknown risk level", Policy{Rules: []PolicyRule{{ID: "a", Decision: DecisionReject, When: RuleCondition{RiskLevels: []string{"Severe"}}}}}},
+		{"unknown watchlist", Policy{Rules: []PolicyRule{{ID: "a", Decision: DecisionReject, When: RuleCondition{Watchlist: []string{"greylist"}}}}}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := tt.policy.Validate(); !errors.Is(err, ErrInvalidPolicy) {
+				t.Errorf("Validate() error = %v, want %v", err, ErrInvalidPolicy)
+			}
+		})
+	}
+}
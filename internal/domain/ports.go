@@ -6,7 +6,8 @@ import (
 )
 
 type AMLProvider interface {
-	CheckAddress(ctx context.Context, address, currency string) (*AMLResult, error)
+	// chain tells multi-chain tokens apart, e.g. USDT on ethereum or tron
+	CheckAddress(ctx context.Context, address, currency, chain string) (*AMLResult, error)
 	Name() string
 }
 
@@ -14,10 +15,18 @@ type AMLResult struct {
 	RiskScore  int
 	RiskLevel  RiskLevel
 	Categories []string
+	// hash of the raw provider response, empty when there was none
+	PayloadHash string
+	// per provider breakdown, empty when a single provider answered
+	Providers []ProviderResult
+	// set when the answer came from cache
+	Cache *CacheInfo
+	// answered by a fallback while the provider was unavailable, never cached under its name
+	Degraded bool
 }
 
 type SanctionsProvider interface {
-	CheckAddress(ctx context.Context, address string) (*SanctionsResult, error)
+	CheckAddress(ctx context.Context, address, chain string) (*SanctionsResult, error)
 	Name() string
 }
 
@@ -32,6 +41,87 @@ type AMLCheckRepository interface {
 	Get(ctx context.Context, checkID string) (*AMLCheck, error)
 	Update(ctx context.Context, check *AMLCheck) error
 	CleanupExpired(ctx context.Context, now time.Time) (int, error)
+	// persist the check and enqueue the outbox messages atomically
+	CreateWithEvents(ctx context.Context, check *AMLCheck, messages ...*OutboxMessage) error
+	UpdateWithEvents(ctx context.Context, check *AMLCheck, messages ...*OutboxMessage) error
+}
+
+type Outbox interface {
+	Enqueue(ctx context.Context, messages ...*OutboxMessage) error
+	// claims up to limit unsent messages for the lease duration, oldest first
+	ClaimPending(ctx context.Context, limit int, lease time.Duration) ([]*OutboxMessage, error)
+	MarkSent(ctx context.Context, messageID string) error
+	// records a failed attempt, the message becomes claimable again when its lease expires
+	MarkFailed(ctx context.Context, messageID string, errorMessage string) error
+	CleanupSent(ctx context.Context, before time.Time) (int, error)
+}
+
+type WatchlistRepository interface {
+	Create(ctx context.Context, entry *WatchlistEntry) error
+	Get(ctx context.Context, entryID string) (*WatchlistEntry, error)
+	Update(ctx context.Context, entry *WatchlistEntry) error
+	Delete(ctx context.Context, entryID string) error
+	List(ctx context.Context, filter WatchlistFilter) ([]*WatchlistEntry, error)
+	// returns the active entry for the address, blocklist entries take precedence
+	Match(ctx context.Context, chain, address string, now time.Time) (*WatchlistEntry, error)
+}
+
+type ReviewCaseRepository interface {
+	// returns ErrCaseExists when the check already has a case
+	Create(ctx context.Context, reviewCase *ReviewCase) error
+	Get(ctx context.Context, caseID string) (*ReviewCase, error)
+	List(ctx context.Context, filter CaseFilter) ([]*ReviewCase, error)
+	// saves the case if nobody else changed it since it was read and bumps its version,
+	// returns ErrCaseConflict otherwise
+	Update(ctx context.Context, reviewCase *ReviewCase) error
+	UpdateWithEvents(ctx context.Context, reviewCase *ReviewCase, messages ...*OutboxMessage) error
+}
+
+type WebhookDeliveryRepository interface {
+	// returns ErrWebhookDeliveryExists when the check already has a delivery for the event
+	Create(ctx context.Context, delivery *WebhookDelivery) error
+	Get(ctx context.Context, deliveryID string) (*WebhookDelivery, error)
+	List(ctx context.Context, filter WebhookDeliveryFilter) ([]*WebhookDelivery, error)
+	Update(ctx context.Context, delivery *WebhookDelivery) error
+	// claims up to limit pending deliveries that are due and pushes their next attempt out by lease,
+	// so a crashed dispatcher's deliveries are picked up again
+	ClaimDue(ctx context.Context, now time.Time, limit int, lease time.Duration) ([]*WebhookDelivery, error)
+}
+
+type WebhookSender interface {
+	// returns the response status code, an error for transport failures and non-2xx responses
+	Send(ctx context.Context, delivery *WebhookDelivery) (int, error)
+}
+
+type BatchRepository interface {
+	Create(ctx context.Context, batch *Batch) error
+	Get(ctx context.Context, batchID string) (*Batch, error)
+	CleanupExpired(ctx context.Context, now time.Time) (int, error)
+}
+
+// publishes check status changes to waiters in the same process
+type CheckNotifier interface {
+	Notify(checkID string, status AMLCheckStatus)
+	// the channel receives statuses published after the call, unsubscribe releases it
+	Subscribe(checkID string) (updates <-chan AMLCheckStatus, unsubscribe func())
+}
+
+type APIKeyRepository interface {
+	Create(ctx context.Context, key *APIKey) error
+	Get(ctx context.Context, keyID string) (*APIKey, error)
+	GetByHash(ctx context.Context, hash string) (*APIKey, error)
+	List(ctx context.Context, filter APIKeyFilter) ([]*APIKey, error)
+	Update(ctx context.Context, key *APIKey) error
+}
+
+// append-only, entries are never updated or deleted
+type AuditLog interface {
+	// seals the entry onto the end of the chain
+	Append(ctx context.Context, entry *AuditEntry) error
+	// matching entries in sequence order
+	List(ctx context.Context, filter AuditFilter) ([]*AuditEntry, error)
+	// up to limit entries following the given sequence, used to walk the chain
+	ListAfter(ctx context.Context, sequence int64, limit int) ([]*AuditEntry, error)
 }
 
 type ReportStorage interface {
@@ -44,3 +134,10 @@ type ReportStorage interface {
 type BillingHook interface {
 	OnCheckCompleted(ctx context.Context, check *AMLCheck) error
 }
+
+// expiring key-value store for provider results
+type Cache interface {
+	// returns nil when the key is missing or expired
+	Get(ctx context.Context, key string) ([]byte, error)
+	Set(ctx context.Context, key string, value []byte, ttl time.Duration) error
+}
@@ -0,0 +1,110 @@
+package domain
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+)
+
+var ErrInvalidRole = errors.New("invalid role")
+
+// what a credential is for, permissions are granted through roles only
+type Role string
+
+const (
+	// client systems submitting checks
+	RoleIntegrator Role = "integrator"
+	// compliance staff working review cases
+	RoleAnalyst Role = "analyst"
+	// read-only access to checks, cases and their history
+	RoleAuditor Role = "auditor"
+	// everything within its tenant, including its api keys
+	RoleTenantAdmin Role = "tenant_admin"
+	// operators only, held by ADMIN_API_KEY and never by a tenant's key
+	RoleAdmin Role = "admin"
+)
+
+type Permission string
+
+const (
+	PermissionChecksWrite    Permission = "checks:write"
+	PermissionChecksRead     Permission = "checks:read"
+	PermissionSanctionsRead  Permission = "sanctions:read"
+	PermissionCasesRead      Permission = "cases:read"
+	PermissionCasesWrite     Permission = "cases:write"
+	PermissionWebhooksRead   Permission = "webhooks:read"
+	PermissionWebhooksWrite  Permission = "webhooks:write"
+	PermissionWatchlistRead  Permission = "watchlist:read"
+	PermissionWatchlistWrite Permission = "watchlist:write"
+	PermissionAPIKeysManage  Permission = "api_keys:manage"
+	PermissionAuditRead      Permission = "audit:read"
+)
+
+var rolePermissions = map[Role][]Permission{
+	RoleIntegrator: {
+		PermissionChecksWrite,
+		PermissionChecksRead,
+		PermissionWebhooksRead,
+		PermissionWebhooksWrite,
+	},
+	RoleAnalyst: {
+		PermissionChecksRead,
+		PermissionSanctionsRead,
+		PermissionCasesRead,
+		PermissionCasesWrite,
+	},
+	RoleAuditor: {
+		PermissionChecksRead,
+		PermissionSanctionsRead,
+		PermissionCasesRead,
+		PermissionWebhooksRead,
+		PermissionAuditRead,
+	},
+	// the watchlist is shared by every tenant, so it stays with the operators
+	RoleTenantAdmin: {
+		PermissionChecksWrite,
+		PermissionChecksRead,
+		PermissionSanctionsRead,
+		PermissionCasesRead,
+		PermissionCasesWrite,
+		PermissionWebhooksRead,
+		PermissionWebhooksWrite,
+		PermissionAPIKeysManage,
+		PermissionAuditRead,
+	},
+	RoleAdmin: {
+		PermissionChecksWrite,
+		PermissionChecksRead,
+		PermissionSanctionsRead,
+		PermissionCasesRead,
+		PermissionCasesWrite,
+		PermissionWebhooksRead,
+		PermissionWebhooksWrite,
+		PermissionWatchlistRead,
+		PermissionWatchlistWrite,
+		PermissionAPIKeysManage,
+		PermissionAuditRead,
+	},
+}
+
+func ParseRole(value string) (Role, error) {
+	role := Role(value)
+	if _, ok := rolePermissions[role]; !ok {
+		return "", fmt.Errorf("%w: %q", ErrInvalidRole, value)
+	}
+	return role, nil
+}
+
+func (r Role) Can(permission Permission) bool {
+	return slices.Contains(rolePermissions[r], permission)
+}
+
+// true when any of the roles grants the permission
+func RolesCan(roles []Role, permission Permission) bool {
+	for _, role := range roles {
+		if role.Can(permission) {
+			return true
+		}
+	}
+	return false
+}
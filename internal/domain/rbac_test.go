@@ -0,0 +1,34 @@
+package domain
+
+import "testing"
+
+func TestRolesCan(t *testing.T) {
+	tests := []struct {
+		name       string
+		roles      []Role
+		permission Permission
+		want       bool
+	}{
+		{"integrator submits checks", []Role{RoleIntegrator}, PermissionChecksWrite, true},
+		{"integrator can't see sanctions details", []Role{RoleIntegrator}, PermissionSanctionsRead, false},
+		{"analyst works cases", []Role{RoleAnalyst}, PermissionCasesWrite, true},
+		{"analyst can't submit checks", []Role{RoleAnalyst}, PermissionChecksWrite, false},
+		{"auditor reads cases", []Role{RoleAuditor}, PermissionCasesRead, true},
+		{"auditor is read-only", []Role{RoleAuditor}, PermissionCasesWrite, false},
+		{"admin manages the watchlist", []Role{RoleAdmin}, PermissionWatchlistWrite, true},
+		{"tenant admin manages api keys", []Role{RoleTenantAdmin}, PermissionAPIKeysManage, true},
+		{"tenant admin can't touch the watchlist", []Role{RoleTenantAdmin}, PermissionWatchlistWrite, false},
+		{"analyst can't read the watchlist", []Role{RoleAnalyst}, PermissionWatchlistRead, false},
+		{"roles add up", []Role{RoleIntegrator, RoleAuditor}, PermissionSanctionsRead, true},
+		{"no roles", nil, PermissionChecksRead, false},
+		{"unknown role", []Role{"root"}, PermissionChecksRead, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := RolesCan(tt.roles, tt.permission); got != tt.want {
+				t.Errorf("RolesCan(%v, %s) = %v, want %v", tt.roles, tt.permission, got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,245 @@
+package domain
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+var (
+	ErrCaseNotFound          = errors.New("case not found")
+	ErrCaseExists            = errors.New("case already exists")
+	ErrCaseConflict          = errors.New("case was modified concurrently")
+	ErrInvalidCaseTransition = errors.New("invalid case transition")
+)
+
+type CaseStatus string
+
+const (
+	CaseStatusOpen      CaseStatus = "open"
+	CaseStatusInReview  CaseStatus = "in_review"
+	CaseStatusEscalated CaseStatus = "escalated"
+	CaseStatusClosed    CaseStatus = "closed"
+)
+
+func ParseCaseStatus(value string) (CaseStatus, error) {
+	switch status := CaseStatus(strings.ToLower(strings.TrimSpace(value))); status {
+	case CaseStatusOpen, CaseStatusInReview, CaseStatusEscalated, CaseStatusClosed:
+		return status, nil
+	}
+	return "", fmt.Errorf("%w: unknown status %q", ErrInvalidCaseTransition, value)
+}
+
+// the analyst's final call on a case
+type CaseDisposition string
+
+const (
+	// the exposure was reviewed and is acceptable
+	DispositionCleared CaseDisposition = "cleared"
+	// the exposure is real, the address must not be serviced
+	DispositionConfirmed CaseDisposition = "confirmed"
+	// confirmed and reported to the regulator
+	DispositionReported CaseDisposition = "reported"
+)
+
+func ParseCaseDisposition(value string) (CaseDisposition, error) {
+	switch disposition := CaseDisposition(strings.ToLower(strings.TrimSpace(value))); disposition {
+	case DispositionCleared, DispositionConfirmed, DispositionReported:
+		return disposition, nil
+	}
+	return "", fmt.Errorf("%w: unknown disposition %q", ErrInvalidCaseTransition, value)
+}
+
+type CaseNote struct {
+	Author    string    `json:"author"`
+	Body      string    `json:"body"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+type CaseHistoryEntry struct {
+	Action     string     `json:"action"`
+	Actor      string     `json:"actor"`
+	FromStatus CaseStatus `json:"from_status,omitempty"`
+	ToStatus   CaseStatus `json:"to_status,omitempty"`
+	Detail     string     `json:"detail,omitempty"`
+	At         time.Time  `json:"at"`
+}
+
+// case history actions
+const (
+	CaseActionOpened    = "opened"
+	CaseActionClaimed   = "claimed"
+	CaseActionCommented = "commented"
+	CaseActionEscalated = "escalated"
+	CaseActionResolved  = "resolved"
+)
+
+// an analyst review of a check, keeps a snapshot so it outlives the check itself
+type ReviewCase struct {
+	ID          string
+	CheckID     string
+	Address     string
+	Currency    string
+	RiskScore   int
+	RiskLevel   RiskLevel
+	Decision    Decision
+	Reasons     []string
+	Status      CaseStatus
+	Assignee    string
+	Disposition CaseDisposition
+	Notes       []CaseNote
+	History     []CaseHistoryEntry
+	// incremented on every write, guards against lost updates
+	Version   int
+	CreatedAt time.Time
+	UpdatedAt time.Time
+	ClosedAt  *time.Time
+	// the tenant of the check, empty for checks created before tenancy
+	TenantID string
+}
+
+// why a check needs an analyst, empty if it doesn't
+func ReviewReasons(check *AMLCheck) []string {
+	reasons := make([]string, 0)
+	if check.RiskLevel.Rank() >= RiskLevelHigh.Rank() {
+		reasons = append(reasons, fmt.Sprintf("%s risk", check.RiskLevel))
+	}
+	if check.Sanctions != nil && check.Sanctions.Hit {
+		reasons = append(reasons, "sanctions hit")
+	}
+	// policy rules can ask for a review whatever the risk level
+	if check.Decision == DecisionReview || check.Decision == DecisionReject {
+		reasons = append(reasons, fmt.Sprintf("policy decision %s", check.Decision))
+	}
+	return reasons
+}
+
+func NewReviewCase(check *AMLCheck, reasons []string) *ReviewCase {
+	now := time.Now().UTC()
+	return &ReviewCase{
+		ID:        uuid.New().String(),
+		CheckID:   check.ID,
+		Address:   check.Address,
+		Currency:  check.Currency,
+		RiskScore: check.RiskScore,
+		RiskLevel: check.RiskLevel,
+		Decision:  check.Decision,
+		Reasons:   reasons,
+		Status:    CaseStatusOpen,
+		Notes:     []CaseNote{},
+		History: []CaseHistoryEntry{{
+			Action:   CaseActionOpened,
+			Actor:    "system",
+			ToStatus: CaseStatusOpen,
+			Detail:   strings.Join(reasons, ", "),
+			At:       now,
+		}},
+		CreatedAt: now,
+		UpdatedAt: now,
+		TenantID:  check.TenantID,
+	}
+}
+
+func (c *ReviewCase) IsClosed() bool {
+	return c.Status == CaseStatusClosed
+}
+
+// assigns the case to the analyst, reassigning an in-review case is allowed
+func (c *ReviewCase) Claim(assignee string) error {
+	if c.IsClosed() {
+		return fmt.Errorf("%w: case is closed", ErrInvalidCaseTransition)
+	}
+
+	status := c.Status
+	if status == CaseStatusOpen {
+		status = CaseStatusInReview
+	}
+
+	detail := ""
+	if c.Assignee != "" && c.Assignee != assignee {
+		detail = fmt.Sprintf("reassigned from %s", c.Assignee)
+	}
+
+	c.Assignee = assignee
+	c.transition(CaseActionClaimed, assignee, status, detail)
+	return nil
+}
+
+func (c *ReviewCase) Comment(author, body string) error {
+	if c.IsClosed() {
+		return fmt.Errorf("%w: case is closed", ErrInvalidCaseTransition)
+	}
+
+	now := time.Now().UTC()
+	c.Notes = append(c.Notes, CaseNote{Author: author, Body: body, CreatedAt: now})
+	c.transition(CaseActionCommented, author, c.Status, "")
+	return nil
+}
+
+func (c *ReviewCase) Escalate(actor, reason string) error {
+	if c.Status != CaseStatusInReview {
+		return fmt.Errorf("%w: only cases in review can be escalated", ErrInvalidCaseTransition)
+	}
+
+	c.transition(CaseActionEscalated, actor, CaseStatusEscalated, reason)
+	return nil
+}
+
+// closes the case, only the assignee may resolve it
+func (c *ReviewCase) Resolve(actor string, disposition CaseDisposition, summary string) error {
+	if c.IsClosed() {
+		return fmt.Errorf("%w: case is already closed", ErrInvalidCaseTransition)
+	}
+	if c.Assignee == "" {
+		return fmt.Errorf("%w: case must be claimed before it is resolved", ErrInvalidCaseTransition)
+	}
+	if c.Assignee != actor {
+		return fmt.Errorf("%w: case is assigned to %s", ErrInvalidCaseTransition, c.Assignee)
+	}
+
+	c.Disposition = disposition
+	c.transition(CaseActionResolved, actor, CaseStatusClosed, strings.TrimSpace(fmt.Sprintf("%s %s", disposition, summary)))
+	closedAt := c.UpdatedAt
+	c.ClosedAt = &closedAt
+	return nil
+}
+
+func (c *ReviewCase) transition(action, actor string, to CaseStatus, detail string) {
+	now := time.Now().UTC()
+	c.History = append(c.History, CaseHistoryEntry{
+		Action:     action,
+		Actor:      actor,
+		FromStatus: c.Status,
+		ToStatus:   to,
+		Detail:     detail,
+		At:         now,
+	})
+	c.Status = to
+	c.UpdatedAt = now
+}
+
+type CaseFilter struct {
+	Status   CaseStatus
+	Assignee string
+	CheckID  string
+	TenantID string
+}
+
+func (f CaseFilter) Matches(c *ReviewCase) bool {
+	if f.Status != "" && f.Status != c.Status {
+		return false
+	}
+	if f.Assignee != "" && f.Assignee != c.Assignee {
+		return false
+	}
+	if f.CheckID != "" && f.CheckID != c.CheckID {
+		return false
+	}
+	if f.TenantID != "" && f.TenantID != c.TenantID {
+		return false
+	}
+	return true
+}
@@ -0,0 +1,116 @@
+package domain
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestReviewReasons(t *testing.T) {
+	tests := []struct {
+		name      string
+		riskLevel RiskLevel
+		hit       bool
+		decision  Decision
+		want      int
+	}{
+		{"low", RiskLevelLow, false, DecisionApprove, 0},
+		{"medium", RiskLevelMedium, false, DecisionApprove, 0},
+		{"high", RiskLevelHigh, false, DecisionApprove, 1},
+		{"critical", RiskLevelCritical, false, DecisionApprove, 1},
+		{"sanctions only", RiskLevelLow, true, DecisionApprove, 1},
+		{"critical and sanctions", RiskLevelCritical, true, DecisionApprove, 2},
+		{"medium sent to review by policy", RiskLevelMedium, false, DecisionReview, 1},
+		{"medium rejected by policy", RiskLevelMedium, false, DecisionReject, 1},
+		{"critical rejected by policy", RiskLevelCritical, false, DecisionReject, 2},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			check := NewAMLCheck("address", "BTC", time.Hour)
+			check.RiskLevel = tt.riskLevel
+			check.Sanctions.Hit = tt.hit
+			check.Decision = tt.decision
+
+			if got := ReviewReasons(check); len(got) != tt.want {
+				t.Errorf("ReviewReasons() = %v, want %d reasons", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestReviewCaseLifecycle(t *testing.T) {
+	check := NewAMLCheck("address", "BTC", time.Hour)
+	check.RiskLevel = RiskLevelHigh
+	check.TenantID = "acme"
+	reviewCase := NewReviewCase(check, ReviewReasons(check))
+
+	if reviewCase.Status != CaseStatusOpen {
+		t.Fatalf("NewReviewCase() Status = %v, want %v", reviewCase.Status, CaseStatusOpen)
+	}
+	if reviewCase.TenantID != "acme" {
+		t.Errorf("NewReviewCase() TenantID = %q, want the check's tenant", reviewCase.TenantID)
+	}
+
+	if err := reviewCase.Escalate("alice", "too early"); !errors.Is(err, ErrInvalidCaseTransition) {
+		t.Errorf("Escalate() on open case error = %v, want %v", err, ErrInvalidCaseTransition)
+	}
+
+	if err := reviewCase.Resolve("alice", DispositionCleared, ""); !errors.Is(err, ErrInvalidCaseTransition) {
+		t.Errorf("Resolve() on unclaimed case error = %v, want %v", err, ErrInvalidCaseTransition)
+	}
+
+	if err := reviewCase.Claim("alice"); err != nil {
+		t.Fatalf("Claim() error = %v", err)
+	}
+	if reviewCase.Status != CaseStatusInReview || reviewCase.Assignee != "alice" {
+		t.Errorf("Claim() Status = %v, Assignee = %v", reviewCase.Status, reviewCase.Assignee)
+	}
+
+	if err := reviewCase.Comment("alice", "counterparty is a known mixer"); err != nil {
+		t.Fatalf("Comment() error = %v", err)
+	}
+
+	if err := reviewCase.Escalate("alice", "needs MLRO sign-off"); err != nil {
+		t.Fatalf("Escalate() error = %v", err)
+	}
+
+	if err := reviewCase.Claim("bob"); err != nil {
+		t.Fatalf("Claim() reassign error = %v", err)
+	}
+	if reviewCase.Status != CaseStatusEscalated {
+		t.Errorf("Claim() should keep escalated status, got %v", reviewCase.Status)
+	}
+
+	if err := reviewCase.Resolve("alice", DispositionConfirmed, ""); !errors.Is(err, ErrInvalidCaseTransition) {
+		t.Errorf("Resolve() by non-assignee error = %v, want %v", err, ErrInvalidCaseTransition)
+	}
+
+	if err := reviewCase.Resolve("bob", DispositionReported, "SAR filed"); err != nil {
+		t.Fatalf("Resolve() error = %v", err)
+	}
+	if !reviewCase.IsClosed() || reviewCase.ClosedAt == nil || reviewCase.Disposition != DispositionReported {
+		t.Errorf("Resolve() Status = %v, ClosedAt = %v, Disposition = %v", reviewCase.Status, reviewCase.ClosedAt, reviewCase.Disposition)
+	}
+
+	if err := reviewCase.Comment("bob", "late note"); !errors.Is(err, ErrInvalidCaseTransition) {
+		t.Errorf("Comment() on closed case error = %v, want %v", err, ErrInvalidCaseTransition)
+	}
+
+	wantActions := []string{
+		CaseActionOpened,
+		CaseActionClaimed,
+		CaseActionCommented,
+		CaseActionEscalated,
+		CaseActionClaimed,
+		CaseActionResolved,
+	}
+	if len(reviewCase.History) != len(wantActions) {
+		t.Fatalf("History length = %v, want %v", len(reviewCase.History), len(wantActions))
+	}
+	for i, action := range wantActions {
+		if reviewCase.History[i].Action != action {
+			t.Errorf("History[%d].Action = %v, want %v", i, reviewCase.History[i].Action, action)
+		}
+	}
+}
@@ -0,0 +1,45 @@
+package domain
+
+import (
+	"encoding/base32"
+	"encoding/binary"
+	"fmt"
+)
+
+// stellar strkey version byte of an ed25519 account id, it encodes to a G in front
+const strkeyAccountID = 6 << 3
+
+var strkeyEncoding = base32.StdEncoding.WithPadding(base32.NoPadding)
+
+// decodes a strkey of the given version into its payload, verifying the crc16 checksum
+func decodeStrkey(version byte, value string) ([]byte, error) {
+	decoded, err := strkeyEncoding.DecodeString(value)
+	if err != nil {
+		return nil, fmt.Errorf("%w: invalid base32: %v", ErrInvalidAddress, err)
+	}
+	if len(decoded) < 3 {
+		return nil, fmt.Errorf("%w: strkey is %d bytes, too short for a version byte and checksum", ErrInvalidAddress, len(decoded))
+	}
+
+	// base32 with leftover bits could decode two strings to the same bytes
+	if strkeyEncoding.EncodeToString(decoded) != value {
+		return nil, fmt.Errorf("%w: strkey is not canonical base32", ErrInvalidAddress)
+	}
+
+	body, checksum := decoded[:len(decoded)-2], decoded[len(decoded)-2:]
+	if crc16XModem(body) != binary.LittleEndian.Uint16(checksum) {
+		return nil, fmt.Errorf("%w: strkey checksum mismatch", ErrInvalidAddress)
+	}
+	if body[0] != version {
+		return nil, fmt.Errorf("%w: strkey version byte 0x%02x, want 0x%02x", ErrInvalidAddress, body[0], version)
+	}
+
+	return body[1:], nil
+}
+
+func encodeStrkey(version byte, payload []byte) string {
+	body := append([]byte{version}, payload...)
+	checksum := make([]byte, 2)
+	binary.LittleEndian.PutUint16(checksum, crc16XModem(body))
+	return strkeyEncoding.EncodeToString(append(body, checksum...))
+}
@@ -0,0 +1,98 @@
+package domain
+
+import (
+	"encoding/base64"
+	"encoding/binary"
+	"encoding/hex"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// flag bits of a user-friendly ton address
+const (
+	tonBounceable    = 0x11
+	tonNonBounceable = 0x51
+	tonTestnetFlag   = 0x80
+)
+
+// a ton account, the raw form workchain:hash is the same for every user-friendly form
+type tonAddress struct {
+	workchain int8
+	hash      []byte
+	testnet   bool
+}
+
+func (a tonAddress) raw() string {
+	return strconv.Itoa(int(a.workchain)) + ":" + hex.EncodeToString(a.hash)
+}
+
+// parses the raw form 0:<64 hex digits> or the 48 character base64 user-friendly form
+func parseTonAddress(address string) (tonAddress, error) {
+	if strings.Contains(address, ":") {
+		return parseRawTonAddress(address)
+	}
+	return parseFriendlyTonAddress(address)
+}
+
+func parseRawTonAddress(address string) (tonAddress, error) {
+	workchain, hash, _ := strings.Cut(address, ":")
+
+	id, err := strconv.ParseInt(workchain, 10, 8)
+	if err != nil {
+		return tonAddress{}, fmt.Errorf("%w: invalid ton workchain %q", ErrInvalidAddress, workchain)
+	}
+	if len(hash) != 64 {
+		return tonAddress{}, fmt.Errorf("%w: ton account hash has %d hex digits, want 64", ErrInvalidAddress, len(hash))
+	}
+	decoded, err := hex.DecodeString(hash)
+	if err != nil {
+		return tonAddress{}, fmt.Errorf("%w: invalid hex in ton account hash", ErrInvalidAddress)
+	}
+
+	return tonAddress{workchain: int8(id), hash: decoded}, nil
+}
+
+func parseFriendlyTonAddress(address string) (tonAddress, error) {
+	if len(address) != 48 {
+		return tonAddress{}, fmt.Errorf("%w: ton address is %d characters, want 48", ErrInvalidAddress, len(address))
+	}
+
+	// wallets use both the standard and the url-safe alphabet
+	encoding := base64.StdEncoding
+	if strings.ContainsAny(address, "-_") {
+		encoding = base64.URLEncoding
+	}
+	decoded, err := encoding.DecodeString(address)
+	if err != nil {
+		return tonAddress{}, fmt.Errorf("%w: invalid base64 in ton address", ErrInvalidAddress)
+	}
+
+	body, checksum := decoded[:34], decoded[34:]
+	if crc16XModem(body) != binary.BigEndian.Uint16(checksum) {
+		return tonAddress{}, fmt.Errorf("%w: ton address checksum mismatch", ErrInvalidAddress)
+	}
+
+	flags := body[0]
+	testnet := flags&tonTestnetFlag != 0
+	if flags &^= tonTestnetFlag; flags != tonBounceable && flags != tonNonBounceable {
+		return tonAddress{}, fmt.Errorf("%w: unknown ton address flags 0x%02x", ErrInvalidAddress, body[0])
+	}
+
+	return tonAddress{workchain: int8(body[1]), hash: body[2:], testnet: testnet}, nil
+}
+
+func (a tonAddress) friendly(bounceable bool) string {
+	flags := byte(tonNonBounceable)
+	if bounceable {
+		flags = tonBounceable
+	}
+	if a.testnet {
+		flags |= tonTestnetFlag
+	}
+
+	body := append([]byte{flags, byte(a.workchain)}, a.hash...)
+	checksum := make([]byte, 2)
+	binary.BigEndian.PutUint16(checksum, crc16XModem(body))
+	return base64.URLEncoding.EncodeToString(append(body, checksum...))
+}
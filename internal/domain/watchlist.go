@@ -0,0 +1,99 @@
+package domain
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+var (
+	ErrWatchlistEntryNotFound = errors.New("watchlist entry not found")
+	ErrInvalidWatchlistEntry  = errors.New("invalid watchlist entry")
+)
+
+type WatchlistType string
+
+const (
+	// addresses our own investigations flagged, always Critical
+	WatchlistBlocklist WatchlistType = "blocklist"
+	// known-good addresses such as our own hot wallets, never sent to providers
+	WatchlistAllowlist WatchlistType = "allowlist"
+)
+
+func ParseWatchlistType(value string) (WatchlistType, error) {
+	switch listType := WatchlistType(strings.ToLower(strings.TrimSpace(value))); listType {
+	case WatchlistBlocklist, WatchlistAllowlist:
+		return listType, nil
+	}
+	return "", fmt.Errorf("%w: unknown list type %q", ErrInvalidWatchlistEntry, value)
+}
+
+type WatchlistEntry struct {
+	ID        string
+	Address   string
+	Chain     string
+	ListType  WatchlistType
+	Reason    string
+	Author    string
+	ExpiresAt *time.Time
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
+
+func NewWatchlistEntry(address, chain string, listType WatchlistType, reason, author string, expiresAt *time.Time) *WatchlistEntry {
+	now := time.Now().UTC()
+	return &WatchlistEntry{
+		ID:        uuid.New().String(),
+		Address:   address,
+		Chain:     chain,
+		ListType:  listType,
+		Reason:    reason,
+		Author:    author,
+		ExpiresAt: expiresAt,
+		CreatedAt: now,
+		UpdatedAt: now,
+	}
+}
+
+func (e *WatchlistEntry) IsExpired(now time.Time) bool {
+	return e.ExpiresAt != nil && now.After(*e.ExpiresAt)
+}
+
+func (e *WatchlistEntry) Match() *WatchlistMatch {
+	return &WatchlistMatch{
+		EntryID:  e.ID,
+		ListType: e.ListType,
+		Reason:   e.Reason,
+		Author:   e.Author,
+	}
+}
+
+// records that a watchlist entry decided the outcome of a check
+type WatchlistMatch struct {
+	EntryID  string        `json:"entry_id"`
+	ListType WatchlistType `json:"list_type"`
+	Reason   string        `json:"reason"`
+	Author   string        `json:"author"`
+}
+
+type WatchlistFilter struct {
+	Address  string
+	Chain    string
+	ListType WatchlistType
+}
+
+func (f WatchlistFilter) Matches(entry *WatchlistEntry) bool {
+	if f.Address != "" && f.Address != entry.Address {
+		return false
+	}
+	if f.Chain != "" && f.Chain != entry.Chain {
+		return false
+	}
+	if f.ListType != "" && f.ListType != entry.ListType {
+		return false
+	}
+	return true
+}
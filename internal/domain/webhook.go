@@ -0,0 +1,160 @@
+package domain
+
+import (
+	"errors"
+	"fmt"
+	"net/netip"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+var (
+	ErrWebhookDeliveryNotFound = errors.New("webhook delivery not found")
+	ErrWebhookDeliveryExists   = errors.New("webhook delivery already exists")
+	ErrInvalidCallbackURL      = errors.New("invalid callback url")
+)
+
+// callbacks carry check results, they only go to public https endpoints. hostnames
+// are resolved when the delivery is made, the sender checks the address it dials
+func ValidateCallbackURL(rawURL string) error {
+	u, err := url.Parse(rawURL)
+	if err != nil || u.Host == "" {
+		return fmt.Errorf("%w: %q is not an absolute url", ErrInvalidCallbackURL, rawURL)
+	}
+	if u.Scheme != "https" {
+		return fmt.Errorf("%w: must use https", ErrInvalidCallbackURL)
+	}
+
+	host := strings.ToLower(strings.TrimSuffix(u.Hostname(), "."))
+	if host == "localhost" || strings.HasSuffix(host, ".localhost") {
+		return fmt.Errorf("%w: %s is not a public host", ErrInvalidCallbackURL, host)
+	}
+	if addr, err := netip.ParseAddr(host); err == nil && !IsPublicAddr(addr) {
+		return fmt.Errorf("%w: %s is not a public address", ErrInvalidCallbackURL, host)
+	}
+	return nil
+}
+
+// false for loopback, private, link-local, multicast and unspecified addresses
+func IsPublicAddr(addr netip.Addr) bool {
+	addr = addr.Unmap()
+	return addr.IsValid() &&
+		addr.IsGlobalUnicast() &&
+		!addr.IsPrivate() &&
+		!addr.IsLoopback() &&
+		!addr.IsLinkLocalUnicast() &&
+		!cgnatPrefix.Contains(addr)
+}
+
+// carrier-grade NAT, not routable from the internet but not covered by IsPrivate
+var cgnatPrefix = netip.MustParsePrefix("100.64.0.0/10")
+
+type WebhookDeliveryStatus string
+
+const (
+	// waiting for its first or next attempt
+	WebhookDeliveryPending   WebhookDeliveryStatus = "pending"
+	WebhookDeliveryDelivered WebhookDeliveryStatus = "delivered"
+	// gave up after the maximum number of attempts, can be redelivered manually
+	WebhookDeliveryFailed WebhookDeliveryStatus = "failed"
+)
+
+func ParseWebhookDeliveryStatus(value string) (WebhookDeliveryStatus, error) {
+	switch status := WebhookDeliveryStatus(strings.ToLower(strings.TrimSpace(value))); status {
+	case WebhookDeliveryPending, WebhookDeliveryDelivered, WebhookDeliveryFailed:
+		return status, nil
+	}
+	return "", fmt.Errorf("unknown webhook delivery status %q", value)
+}
+
+// one callback for one event, the payload is frozen when the event happens
+type WebhookDelivery struct {
+	ID             string
+	TenantID       string
+	CheckID        string
+	EventType      string
+	URL            string
+	Payload        []byte
+	Status         WebhookDeliveryStatus
+	Attempts       int
+	LastStatusCode int
+	LastError      string
+	NextAttemptAt  time.Time
+	CreatedAt      time.Time
+	UpdatedAt      time.Time
+	DeliveredAt    *time.Time
+}
+
+func NewWebhookDelivery(tenantID, checkID, eventType, url string, payload []byte) *WebhookDelivery {
+	now := time.Now().UTC()
+	return &WebhookDelivery{
+		ID:            uuid.New().String(),
+		TenantID:      tenantID,
+		CheckID:       checkID,
+		EventType:     eventType,
+		URL:           url,
+		Payload:       payload,
+		Status:        WebhookDeliveryPending,
+		NextAttemptAt: now,
+		CreatedAt:     now,
+		UpdatedAt:     now,
+	}
+}
+
+func (d *WebhookDelivery) MarkDelivered(statusCode int) {
+	now := time.Now().UTC()
+	d.Status = WebhookDeliveryDelivered
+	d.Attempts++
+	d.LastStatusCode = statusCode
+	d.LastError = ""
+	d.DeliveredAt = &now
+	d.UpdatedAt = now
+}
+
+// records a failed attempt, retryAt nil means no retries are left
+func (d *WebhookDelivery) MarkAttemptFailed(statusCode int, errorMessage string, retryAt *time.Time) {
+	d.Attempts++
+	d.LastStatusCode = statusCode
+	d.LastError = errorMessage
+	d.UpdatedAt = time.Now().UTC()
+
+	if retryAt == nil {
+		d.Status = WebhookDeliveryFailed
+		return
+	}
+
+	d.Status = WebhookDeliveryPending
+	d.NextAttemptAt = *retryAt
+}
+
+// queues the delivery again with a fresh retry budget
+func (d *WebhookDelivery) Reset() {
+	now := time.Now().UTC()
+	d.Status = WebhookDeliveryPending
+	d.Attempts = 0
+	d.NextAttemptAt = now
+	d.DeliveredAt = nil
+	d.UpdatedAt = now
+}
+
+type WebhookDeliveryFilter struct {
+	TenantID string
+	CheckID  string
+	Status   WebhookDeliveryStatus
+}
+
+func (f WebhookDeliveryFilter) Matches(d *WebhookDelivery) bool {
+	if f.CheckID != "" && f.CheckID != d.CheckID {
+		return false
+	}
+	if f.Status != "" && f.Status != d.Status {
+		return false
+	}
+	if f.TenantID != "" && f.TenantID != d.TenantID {
+		return false
+	}
+	return true
+}
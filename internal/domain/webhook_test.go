@@ -0,0 +1,41 @@
+package domain
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestValidateCallbackURL(t *testing.T) {
+	tests := []struct {
+		url   string
+		valid bool
+	}{
+		{"https://hooks.example.com/aml", true},
+		{"https://8.8.8.8/aml", true},
+		{"http://hooks.example.com/aml", false},
+		{"ftp://hooks.example.com/aml", false},
+		{"/aml", false},
+		{"https://localhost/aml", false},
+		{"https://api.localhost/aml", false},
+		{"https://127.0.0.1/aml", false},
+		{"https://10.1.2.3/aml", false},
+		{"https://192.168.0.10:8443/aml", false},
+		{"https://169.254.169.254/latest/meta-data", false},
+		{"https://100.64.0.1/aml", false},
+		{"https://0.0.0.0/aml", false},
+		{"https://[::1]/aml", false},
+		{"https://[fe80::1]/aml", false},
+		{"https://[fd00::1]/aml", false},
+		{"https://[::ffff:127.0.0.1]/aml", false},
+	}
+
+	for _, tt := range tests {
+		err := ValidateCallbackURL(tt.url)
+		if tt.valid && err != nil {
+			t.Errorf("ValidateCallbackURL(%q) error = %v", tt.url, err)
+		}
+		if !tt.valid && !errors.Is(err, ErrInvalidCallbackURL) {
+			t.Errorf("ValidateCallbackURL(%q) error = %v, want %v", tt.url, err, ErrInvalidCallbackURL)
+		}
+	}
+}
@@ -0,0 +1,73 @@
+package domain
+
+import (
+	"encoding/binary"
+	"fmt"
+	"strconv"
+)
+
+// the XRP ledger's own base58 alphabet, classic addresses start with r
+var rippleBase58 = newBase58Alphabet("rpshnaf39wBUDNEGHJKLM4PQRST7VWXYZ2bcdeCg65jkm8oFqi1tuvAxyz")
+
+// version bytes of an X-address (XLS-5d) on mainnet, they encode to an X in front
+const (
+	xAddressMainnet0 = 0x05
+	xAddressMainnet1 = 0x44
+)
+
+// decodes a classic r... address into its 20-byte account id
+func decodeClassicAddress(address string) ([]byte, error) {
+	version, accountID, err := rippleBase58.decodeCheck(address)
+	if err != nil {
+		return nil, err
+	}
+	if version != 0x00 {
+		return nil, fmt.Errorf("%w: version byte 0x%02x, want 0x00", ErrInvalidAddress, version)
+	}
+	if len(accountID) != 20 {
+		return nil, fmt.Errorf("%w: account id is %d bytes, want 20", ErrInvalidAddress, len(accountID))
+	}
+	return accountID, nil
+}
+
+// decodes an X-address into the account id and the destination tag it carries,
+// the tag is empty when the address has none
+func decodeXAddress(address string) ([]byte, string, error) {
+	version, payload, err := rippleBase58.decodeCheck(address)
+	if err != nil {
+		return nil, "", err
+	}
+	// second version byte, account id, flags and a 64-bit tag
+	if len(payload) != 1+20+1+8 {
+		return nil, "", fmt.Errorf("%w: x-address payload is %d bytes, want 30", ErrInvalidAddress, len(payload))
+	}
+	if version != xAddressMainnet0 || payload[0] != xAddressMainnet1 {
+		return nil, "", fmt.Errorf("%w: x-address prefix 0x%02x%02x is not mainnet", ErrInvalidAddress, version, payload[0])
+	}
+
+	accountID, flags, rawTag := payload[1:21], payload[21], binary.LittleEndian.Uint64(payload[22:])
+	switch {
+	case flags == 0 && rawTag == 0:
+		return accountID, "", nil
+	case flags == 1 && rawTag <= 0xffffffff:
+		return accountID, strconv.FormatUint(rawTag, 10), nil
+	}
+	return nil, "", fmt.Errorf("%w: x-address has an invalid tag flag %d", ErrInvalidAddress, flags)
+}
+
+func encodeXAddress(accountID []byte, tag *uint32) string {
+	payload := make([]byte, 0, 30)
+	payload = append(payload, xAddressMainnet1)
+	payload = append(payload, accountID...)
+
+	var rawTag [8]byte
+	if tag != nil {
+		payload = append(payload, 1)
+		binary.LittleEndian.PutUint32(rawTag[:], *tag)
+	} else {
+		payload = append(payload, 0)
+	}
+	payload = append(payload, rawTag[:]...)
+
+	return rippleBase58.encodeCheck(xAddressMainnet0, payload)
+}
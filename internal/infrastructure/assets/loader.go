@@ -0,0 +1,62 @@
+package assets
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/Beka01247/bitpanda-aml/internal/domain"
+	"gopkg.in/yaml.v3"
+)
+
+// reads a YAML or JSON asset file, the format is picked by extension
+func Load(path string) (*domain.AssetConfig, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read asset file: %w", err)
+	}
+
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".yaml", ".yml":
+		return ParseYAML(data)
+	case ".json":
+		return ParseJSON(data)
+	}
+
+	return nil, fmt.Errorf("unsupported asset file extension %q", filepath.Ext(path))
+}
+
+func ParseYAML(data []byte) (*domain.AssetConfig, error) {
+	var config domain.AssetConfig
+
+	decoder := yaml.NewDecoder(bytes.NewReader(data))
+	decoder.KnownFields(true)
+	if err := decoder.Decode(&config); err != nil {
+		return nil, fmt.Errorf("failed to parse asset config: %w", err)
+	}
+
+	if err := config.Validate(); err != nil {
+		return nil, err
+	}
+
+	return &config, nil
+}
+
+func ParseJSON(data []byte) (*domain.AssetConfig, error) {
+	var config domain.AssetConfig
+
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.DisallowUnknownFields()
+	if err := decoder.Decode(&config); err != nil {
+		return nil, fmt.Errorf("failed to parse asset config: %w", err)
+	}
+
+	if err := config.Validate(); err != nil {
+		return nil, err
+	}
+
+	return &config, nil
+}
@@ -0,0 +1,84 @@
+package assets
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"github.com/Beka01247/bitpanda-aml/internal/domain"
+)
+
+func TestLoad(t *testing.T) {
+	yamlConfig := `
+evm_chains:
+  - id: 1
+    name: ethereum
+    symbol: ETH
+  - id: 137
+    name: polygon
+    symbol: POL
+tokens:
+  - symbol: USDT
+    chain: polygon
+    contract: "0xc2132D05D31c914a87C6611C10748AEb04B58e8F"
+    decimals: 6
+`
+	jsonConfig := `{
+  "evm_chains": [{"id": 56, "name": "bsc", "symbol": "BNB"}],
+  "tokens": []
+}`
+
+	tests := []struct {
+		name       string
+		file       string
+		content    string
+		wantChains int
+		wantTokens int
+		wantErr    bool
+	}{
+		{"yaml", "assets.yaml", yamlConfig, 2, 1, false},
+		{"yml", "assets.yml", yamlConfig, 2, 1, false},
+		{"json", "assets.json", jsonConfig, 1, 0, false},
+		{"unknown yaml field", "assets.yaml", "evm_chains:\n  - id: 1\n    name: ethereum\n    symbol: ETH\n    rpc: http://node\n", 0, 0, true},
+		{"unknown json field", "assets.json", `{"evm_chains": [], "extra": 1}`, 0, 0, true},
+		{"token on unknown chain", "assets.yaml", "tokens:\n  - symbol: USDT\n    chain: ethereum\n    contract: \"0xdac17f958d2ee523a2206206994597c13d831ec7\"\n", 0, 0, true},
+		{"unsupported extension", "assets.toml", "", 0, 0, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			path := filepath.Join(t.TempDir(), tt.file)
+			if err := os.WriteFile(path, []byte(tt.content), 0o600); err != nil {
+				t.Fatalf("failed to write asset config: %v", err)
+			}
+
+			config, err := Load(path)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Load() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr {
+				return
+			}
+
+			if len(config.EVMChains) != tt.wantChains {
+				t.Errorf("Load() evm chains = %v, want %v", len(config.EVMChains), tt.wantChains)
+			}
+			if len(config.Tokens) != tt.wantTokens {
+				t.Errorf("Load() tokens = %v, want %v", len(config.Tokens), tt.wantTokens)
+			}
+		})
+	}
+}
+
+// the example file documents the built-in defaults and must not drift from them
+func TestLoadExampleAssets(t *testing.T) {
+	config, err := Load("../../../assets.example.yaml")
+	if err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+
+	if !reflect.DeepEqual(*config, domain.DefaultAssetConfig()) {
+		t.Errorf("Load() = %+v, want DefaultAssetConfig()", *config)
+	}
+}
@@ -0,0 +1,65 @@
+package cache
+
+import (
+	"context"
+	"testing"
+	"time"
+)
+
+func TestMemoryCacheExpiresEntries(t *testing.T) {
+	now := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	cache := NewMemoryCache(10)
+	cache.now = func() time.Time { return now }
+	ctx := context.Background()
+
+	if err := cache.Set(ctx, "a", []byte("1"), time.Minute); err != nil {
+		t.Fatalf("Set() error = %v", err)
+	}
+
+	if value, _ := cache.Get(ctx, "a"); string(value) != "1" {
+		t.Fatalf("Get() = %q, want 1", value)
+	}
+
+	now = now.Add(time.Minute)
+	if value, _ := cache.Get(ctx, "a"); value != nil {
+		t.Errorf("Get() after ttl = %q, want miss", value)
+	}
+	if cache.Len() != 0 {
+		t.Errorf("expired entry still held")
+	}
+}
+
+func TestMemoryCacheEvictsLeastRecentlyUsed(t *testing.T) {
+	cache := NewMemoryCache(2)
+	ctx := context.Background()
+
+	cache.Set(ctx, "a", []byte("1"), time.Hour)
+	cache.Set(ctx, "b", []byte("2"), time.Hour)
+	// touching a makes b the oldest
+	cache.Get(ctx, "a")
+	cache.Set(ctx, "c", []byte("3"), time.Hour)
+
+	if value, _ := cache.Get(ctx, "b"); value != nil {
+		t.Errorf("b = %q, want evicted", value)
+	}
+	for _, key := range []string{"a", "c"} {
+		if value, _ := cache.Get(ctx, key); value == nil {
+			t.Errorf("%s evicted, want kept", key)
+		}
+	}
+}
+
+func TestMemoryCacheOverwrites(t *testing.T) {
+	cache := NewMemoryCache(2)
+	ctx := context.Background()
+
+	cache.Set(ctx, "a", []byte("1"), time.Hour)
+	cache.Set(ctx, "a", []byte("2"), time.Hour)
+
+	if value, _ := cache.Get(ctx, "a"); string(value) != "2" {
+		t.Errorf("Get() = %q, want 2", value)
+	}
+	if cache.Len() != 1 {
+		t.Errorf("Len() = %d, want 1", cache.Len())
+	}
+}
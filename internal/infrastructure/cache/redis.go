@@ -0,0 +1,40 @@
+package cache
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/Beka01247/bitpanda-aml/internal/infrastructure/redis"
+)
+
+// shares cached results between replicas
+type RedisCache struct {
+	client *redis.Client
+	prefix string
+}
+
+func NewRedisCache(client *redis.Client) *RedisCache {
+	return &RedisCache{
+		client: client,
+		prefix: "cache:",
+	}
+}
+
+func (c *RedisCache) Get(ctx context.Context, key string) ([]byte, error) {
+	value, err := c.client.Get(ctx, c.prefix+key)
+	if errors.Is(err, redis.ErrNil) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return value, nil
+}
+
+func (c *RedisCache) Set(ctx context.Context, key string, value []byte, ttl time.Duration) error {
+	if ttl <= 0 {
+		return c.client.Del(ctx, c.prefix+key)
+	}
+	return c.client.Set(ctx, c.prefix+key, value, ttl)
+}
@@ -0,0 +1,48 @@
+package cache
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/Beka01247/bitpanda-aml/internal/infrastructure/redis"
+	"github.com/alicebob/miniredis/v2"
+)
+
+func TestRedisCache(t *testing.T) {
+	server := miniredis.RunT(t)
+	client := redis.NewClient(redis.Config{Addr: server.Addr()})
+	defer client.Close()
+
+	cache := NewRedisCache(client)
+	ctx := context.Background()
+
+	if value, err := cache.Get(ctx, "a"); err != nil || value != nil {
+		t.Fatalf("Get() missing = %q, %v, want a miss", value, err)
+	}
+
+	if err := cache.Set(ctx, "a", []byte(`{"hit":true}`), time.Minute); err != nil {
+		t.Fatalf("Set() error = %v", err)
+	}
+	if value, err := cache.Get(ctx, "a"); err != nil || string(value) != `{"hit":true}` {
+		t.Fatalf("Get() = %q, %v", value, err)
+	}
+	if !server.Exists("cache:a") {
+		t.Error("value is not stored under the cache: prefix")
+	}
+
+	server.FastForward(2 * time.Minute)
+	if value, err := cache.Get(ctx, "a"); err != nil || value != nil {
+		t.Errorf("Get() after ttl = %q, %v, want a miss", value, err)
+	}
+
+	if err := cache.Set(ctx, "b", []byte("x"), time.Minute); err != nil {
+		t.Fatalf("Set() error = %v", err)
+	}
+	if err := cache.Set(ctx, "b", []byte("x"), 0); err != nil {
+		t.Fatalf("Set() zero ttl error = %v", err)
+	}
+	if server.Exists("cache:b") {
+		t.Error("Set() with a zero ttl kept the value")
+	}
+}
@@ -0,0 +1,60 @@
+package notifier
+
+import (
+	"sync"
+
+	"github.com/Beka01247/bitpanda-aml/internal/domain"
+)
+
+// fans check status changes out to subscribers of the same process,
+// slow subscribers only ever miss intermediate statuses, never the latest one
+type InProcessNotifier struct {
+	subscribers map[string]map[chan domain.AMLCheckStatus]struct{}
+	mu          sync.Mutex
+}
+
+func NewInProcessNotifier() *InProcessNotifier {
+	return &InProcessNotifier{
+		subscribers: make(map[string]map[chan domain.AMLCheckStatus]struct{}),
+	}
+}
+
+func (n *InProcessNotifier) Subscribe(checkID string) (<-chan domain.AMLCheckStatus, func()) {
+	updates := make(chan domain.AMLCheckStatus, 1)
+
+	n.mu.Lock()
+	if n.subscribers[checkID] == nil {
+		n.subscribers[checkID] = make(map[chan domain.AMLCheckStatus]struct{})
+	}
+	n.subscribers[checkID][updates] = struct{}{}
+	n.mu.Unlock()
+
+	var once sync.Once
+	unsubscribe := func() {
+		once.Do(func() {
+			n.mu.Lock()
+			defer n.mu.Unlock()
+
+			delete(n.subscribers[checkID], updates)
+			if len(n.subscribers[checkID]) == 0 {
+				delete(n.subscribers, checkID)
+			}
+		})
+	}
+
+	return updates, unsubscribe
+}
+
+func (n *InProcessNotifier) Notify(checkID string, status domain.AMLCheckStatus) {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+
+	for updates := range n.subscribers[checkID] {
+		// replace an unread status so the send never blocks the publisher
+		select {
+		case <-updates:
+		default:
+		}
+		updates <- status
+	}
+}
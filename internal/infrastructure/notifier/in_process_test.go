@@ -0,0 +1,60 @@
+package notifier
+
+import (
+	"testing"
+
+	"github.com/Beka01247/bitpanda-aml/internal/domain"
+)
+
+func TestInProcessNotifier(t *testing.T) {
+	n := NewInProcessNotifier()
+
+	first, unsubscribeFirst := n.Subscribe("check-1")
+	second, unsubscribeSecond := n.Subscribe("check-1")
+	other, unsubscribeOther := n.Subscribe("check-2")
+	defer unsubscribeFirst()
+	defer unsubscribeOther()
+
+	n.Notify("check-1", domain.StatusCompleted)
+
+	for name, updates := range map[string]<-chan domain.AMLCheckStatus{"first": first, "second": second} {
+		select {
+		case status := <-updates:
+			if status != domain.StatusCompleted {
+				t.Errorf("%s subscriber got %s, want %s", name, status, domain.StatusCompleted)
+			}
+		default:
+			t.Errorf("%s subscriber got nothing", name)
+		}
+	}
+
+	select {
+	case status := <-other:
+		t.Errorf("subscriber of another check got %s", status)
+	default:
+	}
+
+	unsubscribeSecond()
+	unsubscribeSecond()
+	n.Notify("check-1", domain.StatusFailed)
+	select {
+	case status := <-second:
+		t.Errorf("unsubscribed channel got %s", status)
+	default:
+	}
+}
+
+func TestInProcessNotifier_KeepsLatestStatus(t *testing.T) {
+	n := NewInProcessNotifier()
+
+	updates, unsubscribe := n.Subscribe("check-1")
+	defer unsubscribe()
+
+	// nobody reads in between, the publisher must not block
+	n.Notify("check-1", domain.StatusProcessing)
+	n.Notify("check-1", domain.StatusFailed)
+
+	if status := <-updates; status != domain.StatusFailed {
+		t.Errorf("got %s, want latest status %s", status, domain.StatusFailed)
+	}
+}
@@ -0,0 +1,62 @@
+package policy
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/Beka01247/bitpanda-aml/internal/domain"
+	"gopkg.in/yaml.v3"
+)
+
+// reads a YAML or JSON policy file, the format is picked by extension
+func Load(path string) (*domain.Policy, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read policy file: %w", err)
+	}
+
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".yaml", ".yml":
+		return ParseYAML(data)
+	case ".json":
+		return ParseJSON(data)
+	}
+
+	return nil, fmt.Errorf("unsupported policy file extension %q", filepath.Ext(path))
+}
+
+func ParseYAML(data []byte) (*domain.Policy, error) {
+	var policy domain.Policy
+
+	decoder := yaml.NewDecoder(bytes.NewReader(data))
+	decoder.KnownFields(true)
+	if err := decoder.Decode(&policy); err != nil {
+		return nil, fmt.Errorf("failed to parse policy: %w", err)
+	}
+
+	if err := policy.Validate(); err != nil {
+		return nil, err
+	}
+
+	return &policy, nil
+}
+
+func ParseJSON(data []byte) (*domain.Policy, error) {
+	var policy domain.Policy
+
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.DisallowUnknownFields()
+	if err := decoder.Decode(&policy); err != nil {
+		return nil, fmt.Errorf("failed to parse policy: %w", err)
+	}
+
+	if err := policy.Validate(); err != nil {
+		return nil, err
+	}
+
+	return &policy, nil
+}
@@ -0,0 +1,88 @@
+package policy
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/Beka01247/bitpanda-aml/internal/domain"
+)
+
+func TestLoad(t *testing.T) {
+	yamlPolicy := `
+version: "1"
+default_decision: approve
+require_sanctions_screening: true
+rules:
+  - id: darknet
+    decision: reject
+    when:
+      categories_any: [Darknet]
+  - id: usdt-elevated
+    decision: Review
+    when:
+      assets: [USDT]
+      min_score: 50
+`
+	jsonPolicy := `{
+  "default_decision": "review",
+  "rules": [
+    {"id": "sanctions", "decision": "reject", "when": {"sanctioned": true}}
+  ]
+}`
+
+	tests := []struct {
+		name      string
+		file      string
+		content   string
+		wantRules int
+		wantErr   bool
+		// require_sanctions_screening
+		wantRequired bool
+	}{
+		{"yaml", "policy.yaml", yamlPolicy, 2, false, true},
+		{"yml", "policy.yml", yamlPolicy, 2, false, true},
+		{"json", "policy.json", jsonPolicy, 1, false, false},
+		{"unknown yaml field", "policy.yaml", "rules:\n  - id: a\n    decision: reject\n    when:\n      score_over: 10\n", 0, true, false},
+		{"unknown json field", "policy.json", `{"rules": [], "extra": 1}`, 0, true, false},
+		{"invalid decision", "policy.yaml", "rules:\n  - id: a\n    decision: block\n", 0, true, false},
+		{"unsupported extension", "policy.toml", "", 0, true, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			path := filepath.Join(t.TempDir(), tt.file)
+			if err := os.WriteFile(path, []byte(tt.content), 0o600); err != nil {
+				t.Fatalf("failed to write policy: %v", err)
+			}
+
+			policy, err := Load(path)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Load() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr {
+				return
+			}
+
+			if len(policy.Rules) != tt.wantRules {
+				t.Errorf("Load() rules = %v, want %v", len(policy.Rules), tt.wantRules)
+			}
+
+			if policy.RequireSanctionsScreening != tt.wantRequired {
+				t.Errorf("Load() RequireSanctionsScreening = %v, want %v", policy.RequireSanctionsScreening, tt.wantRequired)
+			}
+		})
+	}
+}
+
+func TestLoadExamplePolicy(t *testing.T) {
+	policy, err := Load("../../../policy.example.yaml")
+	if err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+
+	result := policy.Evaluate(domain.PolicyInput{RiskScore: 55, RiskLevel: domain.RiskLevelMedium, Asset: "USDT"})
+	if result.Decision != domain.DecisionReview {
+		t.Errorf("Evaluate() Decision = %v, want %v", result.Decision, domain.DecisionReview)
+	}
+}
@@ -0,0 +1,196 @@
+package providers
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/Beka01247/bitpanda-aml/internal/domain"
+	"go.uber.org/zap"
+)
+
+type AggregationStrategy string
+
+const (
+	// the riskiest provider wins
+	StrategyMax AggregationStrategy = "max"
+	// scores are averaged using the provider weights
+	StrategyWeightedAverage AggregationStrategy = "weighted_average"
+	// the highest risk reported by at least quorum providers wins
+	StrategyQuorum AggregationStrategy = "quorum"
+)
+
+var ErrNoProviderResults = errors.New("no aml provider returned a result")
+
+func ParseAggregationStrategy(value string) (AggregationStrategy, error) {
+	switch strategy := AggregationStrategy(strings.ToLower(strings.TrimSpace(value))); strategy {
+	case StrategyMax, StrategyWeightedAverage, StrategyQuorum:
+		return strategy, nil
+	}
+	return "", fmt.Errorf("unknown aggregation strategy: %s", value)
+}
+
+type WeightedAMLProvider struct {
+	Provider domain.AMLProvider
+	Weight   float64
+}
+
+// queries several AML providers in parallel and combines their results
+type AggregateAMLProvider struct {
+	providers []WeightedAMLProvider
+	strategy  AggregationStrategy
+	quorum    int
+	logger    *zap.SugaredLogger
+}
+
+func NewAggregateAMLProvider(providers []WeightedAMLProvider, strategy AggregationStrategy, quorum int, logger *zap.SugaredLogger) *AggregateAMLProvider {
+	if quorum < 1 {
+		quorum = 1
+	}
+	return &AggregateAMLProvider{
+		providers: providers,
+		strategy:  strategy,
+		quorum:    quorum,
+		logger:    logger,
+	}
+}
+
+func (p *AggregateAMLProvider) CheckAddress(ctx context.Context, address, currency, chain string) (*domain.AMLResult, error) {
+	breakdown := make([]domain.ProviderResult, len(p.providers))
+
+	var wg sync.WaitGroup
+	for i, weighted := range p.providers {
+		wg.Add(1)
+		go func(i int, weighted WeightedAMLProvider) {
+			defer wg.Done()
+
+			entry := domain.ProviderResult{
+				Provider:   weighted.Provider.Name(),
+				Weight:     weighted.Weight,
+				Categories: []string{},
+			}
+
+			result, err := weighted.Provider.CheckAddress(ctx, address, currency, chain)
+			if err != nil {
+				p.logger.Warnw("aml provider failed", "provider", entry.Provider, "error", err)
+				entry.Error = err.Error()
+			} else {
+				entry.RiskScore = result.RiskScore
+				entry.RiskLevel = result.RiskLevel
+				entry.PayloadHash = result.PayloadHash
+				entry.Cache = result.Cache
+				if result.Categories != nil {
+					entry.Categories = result.Categories
+				}
+			}
+
+			breakdown[i] = entry
+		}(i, weighted)
+	}
+	wg.Wait()
+
+	succeeded := make([]domain.ProviderResult, 0, len(breakdown))
+	failures := make([]string, 0)
+	for _, entry := range breakdown {
+		if entry.Error != "" {
+			failures = append(failures, fmt.Sprintf("%s: %s", entry.Provider, entry.Error))
+			continue
+		}
+		succeeded = append(succeeded, entry)
+	}
+
+	if len(succeeded) == 0 {
+		return nil, fmt.Errorf("%w: %s", ErrNoProviderResults, strings.Join(failures, "; "))
+	}
+
+	var (
+		score int
+		level domain.RiskLevel
+	)
+
+	switch p.strategy {
+	case StrategyWeightedAverage:
+		score, level = weightedAverage(succeeded)
+	case StrategyQuorum:
+		if len(succeeded) < p.quorum {
+			return nil, fmt.Errorf("quorum not reached: %d of %d providers answered, %d required", len(succeeded), len(p.providers), p.quorum)
+		}
+		score, level = quorumRisk(succeeded, p.quorum)
+	default:
+		score, level = maxRisk(succeeded)
+	}
+
+	return &domain.AMLResult{
+		RiskScore:  score,
+		RiskLevel:  level,
+		Categories: mergeCategories(succeeded),
+		Providers:  breakdown,
+	}, nil
+}
+
+func (p *AggregateAMLProvider) Name() string {
+	names := make([]string, 0, len(p.providers))
+	for _, weighted := range p.providers {
+		names = append(names, weighted.Provider.Name())
+	}
+	return fmt.Sprintf("Aggregate(%s:%s)", p.strategy, strings.Join(names, ","))
+}
+
+// the riskiest provider's score and level, taken together so they always agree
+func maxRisk(results []domain.ProviderResult) (int, domain.RiskLevel) {
+	riskiest := byRisk(results)[0]
+	return riskiest.RiskScore, riskiest.RiskLevel
+}
+
+func weightedAverage(results []domain.ProviderResult) (int, domain.RiskLevel) {
+	var sum, totalWeight float64
+	for _, result := range results {
+		weight := result.Weight
+		if weight <= 0 {
+			weight = 1
+		}
+		sum += float64(result.RiskScore) * weight
+		totalWeight += weight
+	}
+
+	score := int(math.Round(sum / totalWeight))
+	return score, domain.DeriveRiskLevel(score)
+}
+
+// the score and level of the quorum-th riskiest provider, i.e. the riskiest
+// answer that at least quorum providers agree on or exceed
+func quorumRisk(results []domain.ProviderResult, quorum int) (int, domain.RiskLevel) {
+	answer := byRisk(results)[quorum-1]
+	return answer.RiskScore, answer.RiskLevel
+}
+
+// a copy sorted by score, riskiest first, the level breaks ties
+func byRisk(results []domain.ProviderResult) []domain.ProviderResult {
+	sorted := append([]domain.ProviderResult(nil), results...)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		if sorted[i].RiskScore != sorted[j].RiskScore {
+			return sorted[i].RiskScore > sorted[j].RiskScore
+		}
+		return sorted[i].RiskLevel.Rank() > sorted[j].RiskLevel.Rank()
+	})
+	return sorted
+}
+
+func mergeCategories(results []domain.ProviderResult) []string {
+	seen := make(map[string]struct{})
+	categories := []string{}
+	for _, result := range results {
+		for _, category := range result.Categories {
+			if _, ok := seen[category]; ok {
+				continue
+			}
+			seen[category] = struct{}{}
+			categories = append(categories, category)
+		}
+	}
+	return categories
+}
@@ -0,0 +1,141 @@
+package providers
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/Beka01247/bitpanda-aml/internal/domain"
+	"go.uber.org/zap"
+)
+
+type stubAMLProvider struct {
+	name   string
+	result *domain.AMLResult
+	err    error
+}
+
+func (p *stubAMLProvider) CheckAddress(ctx context.Context, address, currency, chain string) (*domain.AMLResult, error) {
+	return p.result, p.err
+}
+
+func (p *stubAMLProvider) Name() string {
+	return p.name
+}
+
+func stubResult(name string, score int, categories ...string) WeightedAMLProvider {
+	return WeightedAMLProvider{
+		Provider: &stubAMLProvider{
+			name: name,
+			result: &domain.AMLResult{
+				RiskScore:  score,
+				RiskLevel:  domain.DeriveRiskLevel(score),
+				Categories: categories,
+			},
+		},
+		Weight: 1,
+	}
+}
+
+// a provider whose level doesn't follow from its score, as some providers report them
+func stubLevelResult(name string, score int, level domain.RiskLevel) WeightedAMLProvider {
+	provider := stubResult(name, score)
+	provider.Provider.(*stubAMLProvider).result.RiskLevel = level
+	return provider
+}
+
+func stubFailure(name string) WeightedAMLProvider {
+	return WeightedAMLProvider{
+		Provider: &stubAMLProvider{name: name, err: errors.New("provider down")},
+		Weight:   1,
+	}
+}
+
+func TestAggregateAMLProvider_Strategies(t *testing.T) {
+	weightedHeavy := stubResult("heavy", 90)
+	weightedHeavy.Weight = 3
+
+	tests := []struct {
+		name      string
+		providers []WeightedAMLProvider
+		strategy  AggregationStrategy
+		quorum    int
+		wantScore int
+		wantLevel domain.RiskLevel
+		wantErr   bool
+	}{
+		{"max", []WeightedAMLProvider{stubResult("a", 20), stubResult("b", 85), stubResult("c", 40)}, StrategyMax, 1, 85, domain.RiskLevelCritical, false},
+		{"weighted average", []WeightedAMLProvider{stubResult("a", 10), weightedHeavy}, StrategyWeightedAverage, 1, 70, domain.RiskLevelHigh, false},
+		{"quorum of two", []WeightedAMLProvider{stubResult("a", 90), stubResult("b", 65), stubResult("c", 10)}, StrategyQuorum, 2, 65, domain.RiskLevelHigh, false},
+		{"quorum not reached", []WeightedAMLProvider{stubResult("a", 90), stubFailure("b"), stubFailure("c")}, StrategyQuorum, 2, 0, "", true},
+		{"partial failure", []WeightedAMLProvider{stubResult("a", 50), stubFailure("b")}, StrategyMax, 1, 50, domain.RiskLevelMedium, false},
+		{"all failed", []WeightedAMLProvider{stubFailure("a"), stubFailure("b")}, StrategyMax, 1, 0, "", true},
+		// score and level come from the same provider
+		{"max keeps level with score", []WeightedAMLProvider{stubLevelResult("a", 90, domain.RiskLevelLow), stubLevelResult("b", 40, domain.RiskLevelCritical)}, StrategyMax, 1, 90, domain.RiskLevelLow, false},
+		{"quorum keeps level with score", []WeightedAMLProvider{stubLevelResult("a", 90, domain.RiskLevelLow), stubLevelResult("b", 60, domain.RiskLevelMedium), stubLevelResult("c", 10, domain.RiskLevelCritical)}, StrategyQuorum, 2, 60, domain.RiskLevelMedium, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			aggregate := NewAggregateAMLProvider(tt.providers, tt.strategy, tt.quorum, zap.NewNop().Sugar())
+
+			result, err := aggregate.CheckAddress(context.Background(), "address", "BTC", "bitcoin")
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("CheckAddress() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr {
+				return
+			}
+
+			if result.RiskScore != tt.wantScore {
+				t.Errorf("RiskScore = %v, want %v", result.RiskScore, tt.wantScore)
+			}
+
+			if result.RiskLevel != tt.wantLevel {
+				t.Errorf("RiskLevel = %v, want %v", result.RiskLevel, tt.wantLevel)
+			}
+
+			if len(result.Providers) != len(tt.providers) {
+				t.Errorf("Providers length = %v, want %v", len(result.Providers), len(tt.providers))
+			}
+		})
+	}
+}
+
+func TestAggregateAMLProvider_Breakdown(t *testing.T) {
+	aggregate := NewAggregateAMLProvider([]WeightedAMLProvider{
+		stubResult("a", 70, "Mixer", "Exchange"),
+		stubResult("b", 30, "Exchange"),
+		stubFailure("c"),
+	}, StrategyMax, 1, zap.NewNop().Sugar())
+
+	result, err := aggregate.CheckAddress(context.Background(), "address", "BTC", "bitcoin")
+	if err != nil {
+		t.Fatalf("CheckAddress() error = %v", err)
+	}
+
+	if len(result.Categories) != 2 {
+		t.Errorf("Categories = %v, want merged without duplicates", result.Categories)
+	}
+
+	// breakdown keeps the configured provider order
+	if result.Providers[0].Provider != "a" || result.Providers[0].RiskScore != 70 {
+		t.Errorf("Providers[0] = %+v, want provider a with score 70", result.Providers[0])
+	}
+
+	if result.Providers[2].Error == "" {
+		t.Error("Providers[2] should record the provider error")
+	}
+}
+
+func TestParseAggregationStrategy(t *testing.T) {
+	for _, value := range []string{"max", "weighted_average", " Quorum "} {
+		if _, err := ParseAggregationStrategy(value); err != nil {
+			t.Errorf("ParseAggregationStrategy(%q) error = %v", value, err)
+		}
+	}
+
+	if _, err := ParseAggregationStrategy("median"); err == nil {
+		t.Error("ParseAggregationStrategy(median) should return error")
+	}
+}
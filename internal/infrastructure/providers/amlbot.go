@@ -37,8 +37,8 @@ func NewAMLBotProvider(baseURL, apiKey string, logger *zap.SugaredLogger) *AMLBo
 	}
 }
 
-func (p *AMLBotProvider) CheckAddress(ctx context.Context, address, currency string) (*domain.AMLResult, error) {
-	url := fmt.Sprintf("%s/check?address=%s&currency=%s", p.baseURL, address, currency)
+func (p *AMLBotProvider) CheckAddress(ctx context.Context, address, currency, chain string) (*domain.AMLResult, error) {
+	url := fmt.Sprintf("%s/check?address=%s&currency=%s&chain=%s", p.baseURL, address, currency, chain)
 
 	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
 	if err != nil {
@@ -56,11 +56,17 @@ func (p *AMLBotProvider) CheckAddress(ctx context.Context, address, currency str
 
 	if resp.StatusCode != http.StatusOK {
 		body, _ := io.ReadAll(resp.Body)
-		return nil, fmt.Errorf("amlbot returned status %d: %s", resp.StatusCode, string(body))
+		return nil, &StatusError{Provider: "amlbot", StatusCode: resp.StatusCode, Body: string(body)}
+	}
+
+	// the raw body is hashed for the audit log
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read response: %w", err)
 	}
 
 	var amlbotResp AMLBotResponse
-	if err := json.NewDecoder(resp.Body).Decode(&amlbotResp); err != nil {
+	if err := json.Unmarshal(body, &amlbotResp); err != nil {
 		return nil, fmt.Errorf("failed to decode response: %w", err)
 	}
 
@@ -77,9 +83,10 @@ func (p *AMLBotProvider) CheckAddress(ctx context.Context, address, currency str
 	}
 
 	return &domain.AMLResult{
-		RiskScore:  amlbotResp.RiskScore,
-		RiskLevel:  riskLevel,
-		Categories: categories,
+		RiskScore:   amlbotResp.RiskScore,
+		RiskLevel:   riskLevel,
+		Categories:  categories,
+		PayloadHash: domain.HashPayload(body),
 	}, nil
 }
 
@@ -0,0 +1,178 @@
+package providers
+
+import (
+	"context"
+	"encoding/json"
+	"strings"
+	"time"
+
+	"github.com/Beka01247/bitpanda-aml/internal/domain"
+	"go.uber.org/zap"
+)
+
+type CacheTTLs struct {
+	// low and medium risk, no sanctions
+	Clean time.Duration
+	// high or critical risk, or a sanctions hit
+	Hit time.Duration
+}
+
+func (t CacheTTLs) forResult(hit bool) time.Duration {
+	if hit {
+		return t.Hit
+	}
+	return t.Clean
+}
+
+// what is stored per key
+type cachedResult[T any] struct {
+	Result   T         `json:"result"`
+	CachedAt time.Time `json:"cached_at"`
+}
+
+// serves repeated screenings of an address on a chain from cache, cache failures fall
+// through to the provider
+type CachedAMLProvider struct {
+	provider domain.AMLProvider
+	cache    domain.Cache
+	assets   domain.AssetRegistry
+	ttls     CacheTTLs
+	logger   *zap.SugaredLogger
+	now      func() time.Time
+}
+
+func NewCachedAMLProvider(provider domain.AMLProvider, cache domain.Cache, assets domain.AssetRegistry, ttls CacheTTLs, logger *zap.SugaredLogger) *CachedAMLProvider {
+	return &CachedAMLProvider{
+		provider: provider,
+		cache:    cache,
+		assets:   assets,
+		ttls:     ttls,
+		logger:   logger,
+		now:      time.Now,
+	}
+}
+
+func (p *CachedAMLProvider) CheckAddress(ctx context.Context, address, currency, chain string) (*domain.AMLResult, error) {
+	key := p.key(address, currency, chain)
+
+	if !domain.CacheBypassed(ctx) {
+		var cached cachedResult[domain.AMLResult]
+		if readCache(ctx, p.cache, key, &cached, p.logger) {
+			result := cached.Result
+			result.Cache = domain.NewCacheInfo(cached.CachedAt, p.now())
+			return &result, nil
+		}
+	}
+
+	result, err := p.provider.CheckAddress(ctx, address, currency, chain)
+	if err != nil {
+		return nil, err
+	}
+
+	// a fallback answer must not outlive the outage as this provider's verdict
+	if result.Degraded {
+		p.logger.Debugw("degraded aml result not cached", "provider", p.provider.Name(), "chain", chain)
+		return result, nil
+	}
+
+	hit := result.RiskLevel.Rank() >= domain.RiskLevelHigh.Rank()
+	writeCache(ctx, p.cache, key, cachedResult[domain.AMLResult]{Result: *result, CachedAt: p.now().UTC()}, p.ttls.forResult(hit), p.logger)
+
+	return result, nil
+}
+
+func (p *CachedAMLProvider) Name() string {
+	return p.provider.Name()
+}
+
+// provider, chain and normalized address, so the assets of one chain share an entry
+func (p *CachedAMLProvider) key(address, currency, chain string) string {
+	if asset, err := p.assets.Resolve(currency, chain); err == nil {
+		chain = asset.Chain()
+		address = asset.NormalizeAddress(address)
+	}
+	if chain == "" {
+		chain = strings.ToLower(currency)
+	}
+	return "aml:" + strings.ToLower(p.provider.Name()) + ":" + chain + ":" + address
+}
+
+type CachedSanctionsProvider struct {
+	provider domain.SanctionsProvider
+	cache    domain.Cache
+	ttls     CacheTTLs
+	logger   *zap.SugaredLogger
+	now      func() time.Time
+}
+
+func NewCachedSanctionsProvider(provider domain.SanctionsProvider, cache domain.Cache, ttls CacheTTLs, logger *zap.SugaredLogger) *CachedSanctionsProvider {
+	return &CachedSanctionsProvider{
+		provider: provider,
+		cache:    cache,
+		ttls:     ttls,
+		logger:   logger,
+		now:      time.Now,
+	}
+}
+
+func (p *CachedSanctionsProvider) CheckAddress(ctx context.Context, address, chain string) (*domain.SanctionsResult, error) {
+	// providers may screen per chain, the address is already normalized for its asset
+	key := "sanctions:" + strings.ToLower(p.provider.Name()) + ":" + chain + ":" + strings.TrimSpace(address)
+
+	if !domain.CacheBypassed(ctx) {
+		var cached cachedResult[domain.SanctionsResult]
+		if readCache(ctx, p.cache, key, &cached, p.logger) {
+			result := cached.Result
+			result.Cache = map[string]domain.CacheInfo{
+				p.provider.Name(): *domain.NewCacheInfo(cached.CachedAt, p.now()),
+			}
+			return &result, nil
+		}
+	}
+
+	result, err := p.provider.CheckAddress(ctx, address, chain)
+	if err != nil {
+		return nil, err
+	}
+
+	writeCache(ctx, p.cache, key, cachedResult[domain.SanctionsResult]{Result: *result, CachedAt: p.now().UTC()}, p.ttls.forResult(result.Hit), p.logger)
+
+	return result, nil
+}
+
+func (p *CachedSanctionsProvider) Name() string {
+	return p.provider.Name()
+}
+
+func readCache(ctx context.Context, cache domain.Cache, key string, target any, logger *zap.SugaredLogger) bool {
+	value, err := cache.Get(ctx, key)
+	if err != nil {
+		logger.Warnw("provider cache read failed", "key", key, "error", err)
+		return false
+	}
+	if value == nil {
+		return false
+	}
+
+	if err := json.Unmarshal(value, target); err != nil {
+		logger.Warnw("discarding unreadable provider cache entry", "key", key, "error", err)
+		return false
+	}
+	return true
+}
+
+func writeCache(ctx context.Context, cache domain.Cache, key string, entry any, ttl time.Duration, logger *zap.SugaredLogger) {
+	if ttl <= 0 {
+		return
+	}
+
+	value, err := json.Marshal(entry)
+	if err != nil {
+		logger.Warnw("failed to encode provider cache entry", "key", key, "error", err)
+		return
+	}
+
+	if err := cache.Set(ctx, key, value, ttl); err != nil {
+		logger.Warnw("provider cache write failed", "key", key, "error", err)
+	}
+}
@@ -0,0 +1,224 @@
+package providers
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/Beka01247/bitpanda-aml/internal/domain"
+	"go.uber.org/zap"
+)
+
+// remembers the ttl of every write, entries never expire
+type recordingCache struct {
+	values map[string][]byte
+	ttls   map[string]time.Duration
+	err    error
+}
+
+func newRecordingCache() *recordingCache {
+	return &recordingCache{values: make(map[string][]byte), ttls: make(map[string]time.Duration)}
+}
+
+func (c *recordingCache) Get(ctx context.Context, key string) ([]byte, error) {
+	return c.values[key], c.err
+}
+
+func (c *recordingCache) Set(ctx context.Context, key string, value []byte, ttl time.Duration) error {
+	if c.err != nil {
+		return c.err
+	}
+	c.values[key] = value
+	c.ttls[key] = ttl
+	return nil
+}
+
+type countingAMLProvider struct {
+	stubAMLProvider
+	calls int
+}
+
+func (p *countingAMLProvider) CheckAddress(ctx context.Context, address, currency, chain string) (*domain.AMLResult, error) {
+	p.calls++
+	return p.stubAMLProvider.CheckAddress(ctx, address, currency, chain)
+}
+
+type countingSanctionsProvider struct {
+	stubSanctionsProvider
+	calls int
+}
+
+func (p *countingSanctionsProvider) CheckAddress(ctx context.Context, address, chain string) (*domain.SanctionsResult, error) {
+	p.calls++
+	return p.stubSanctionsProvider.CheckAddress(ctx, address, chain)
+}
+
+var testCacheTTLs = CacheTTLs{Clean: 15 * time.Minute, Hit: 24 * time.Hour}
+
+func TestCachedAMLProvider(t *testing.T) {
+	upstream := &countingAMLProvider{stubAMLProvider: stubAMLProvider{name: "AMLBot", result: &domain.AMLResult{
+		RiskScore:   10,
+		RiskLevel:   domain.RiskLevelLow,
+		Categories:  []string{},
+		PayloadHash: "abc",
+	}}}
+	cache := newRecordingCache()
+	provider := NewCachedAMLProvider(upstream, cache, domain.NewDefaultAssetRegistry(), testCacheTTLs, zap.NewNop().Sugar())
+
+	now := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+	provider.now = func() time.Time { return now }
+	ctx := context.Background()
+
+	address := "0x742d35Cc6634C0532925a3b844Bc454e4438f44e"
+	first, err := provider.CheckAddress(ctx, address, "ETH", "ethereum")
+	if err != nil {
+		t.Fatalf("CheckAddress() error = %v", err)
+	}
+	if first.Cache != nil {
+		t.Errorf("live result marked as cached")
+	}
+
+	key := "aml:amlbot:ethereum:0x742d35cc6634c0532925a3b844bc454e4438f44e"
+	if cache.ttls[key] != testCacheTTLs.Clean {
+		t.Errorf("ttl = %v, want the clean ttl", cache.ttls[key])
+	}
+
+	now = now.Add(5 * time.Minute)
+	// USDT lives on the same chain and the address differs only in case
+	second, err := provider.CheckAddress(ctx, "0x742d35cc6634c0532925a3b844bc454e4438f44e", "USDT", "ethereum")
+	if err != nil {
+		t.Fatalf("CheckAddress() error = %v", err)
+	}
+	if upstream.calls != 1 {
+		t.Fatalf("provider called %d times, want 1", upstream.calls)
+	}
+	if second.Cache == nil || second.Cache.AgeSeconds != 300 {
+		t.Errorf("cache info = %+v, want 300s old", second.Cache)
+	}
+	if second.PayloadHash != "abc" {
+		t.Errorf("payload hash = %q, want the original response's", second.PayloadHash)
+	}
+
+	if _, err := provider.CheckAddress(domain.ContextWithCacheBypass(ctx), address, "ETH", "ethereum"); err != nil {
+		t.Fatalf("CheckAddress() error = %v", err)
+	}
+	if upstream.calls != 2 {
+		t.Errorf("bypass didn't call the provider")
+	}
+}
+
+func TestCachedAMLProviderChains(t *testing.T) {
+	upstream := &countingAMLProvider{stubAMLProvider: stubAMLProvider{name: "AMLBot", result: &domain.AMLResult{
+		RiskLevel: domain.RiskLevelLow,
+	}}}
+	cache := newRecordingCache()
+	provider := NewCachedAMLProvider(upstream, cache, domain.NewDefaultAssetRegistry(), testCacheTTLs, zap.NewNop().Sugar())
+
+	address := "TR7NHqjeKQxGTCi8q8ZY4pL8otSzgjLj6t"
+	provider.CheckAddress(context.Background(), address, "USDT", "tron")
+	provider.CheckAddress(context.Background(), address, "TRX", "tron")
+	if upstream.calls != 1 {
+		t.Errorf("provider called %d times, want 1 for two assets on tron", upstream.calls)
+	}
+	if _, ok := cache.ttls["aml:amlbot:tron:"+address]; !ok {
+		t.Errorf("no entry under the tron key, have %v", cache.ttls)
+	}
+}
+
+func TestCachedAMLProviderHitTTL(t *testing.T) {
+	upstream := &countingAMLProvider{stubAMLProvider: stubAMLProvider{name: "AMLBot", result: &domain.AMLResult{
+		RiskScore: 90,
+		RiskLevel: domain.RiskLevelCritical,
+	}}}
+	cache := newRecordingCache()
+	provider := NewCachedAMLProvider(upstream, cache, domain.NewDefaultAssetRegistry(), testCacheTTLs, zap.NewNop().Sugar())
+
+	provider.CheckAddress(context.Background(), "1A1zP1eP5QGefi2DMPTfTL5SLmv7DivfNa", "BTC", "bitcoin")
+
+	if ttl := cache.ttls["aml:amlbot:bitcoin:1A1zP1eP5QGefi2DMPTfTL5SLmv7DivfNa"]; ttl != testCacheTTLs.Hit {
+		t.Errorf("ttl = %v, want the hit ttl", ttl)
+	}
+}
+
+func TestCachedAMLProviderFallsThroughOnCacheErrors(t *testing.T) {
+	upstream := &countingAMLProvider{stubAMLProvider: stubAMLProvider{name: "AMLBot", result: &domain.AMLResult{RiskLevel: domain.RiskLevelLow}}}
+	cache := newRecordingCache()
+	cache.err = errors.New("redis down")
+	provider := NewCachedAMLProvider(upstream, cache, domain.NewDefaultAssetRegistry(), testCacheTTLs, zap.NewNop().Sugar())
+
+	for range 2 {
+		if _, err := provider.CheckAddress(context.Background(), "1A1zP1eP5QGefi2DMPTfTL5SLmv7DivfNa", "BTC", "bitcoin"); err != nil {
+			t.Fatalf("CheckAddress() error = %v", err)
+		}
+	}
+	if upstream.calls != 2 {
+		t.Errorf("provider called %d times, want 2", upstream.calls)
+	}
+}
+
+func TestCachedAMLProviderDoesNotCacheErrors(t *testing.T) {
+	upstream := &countingAMLProvider{stubAMLProvider: stubAMLProvider{name: "AMLBot", err: errors.New("timeout")}}
+	cache := newRecordingCache()
+	provider := NewCachedAMLProvider(upstream, cache, domain.NewDefaultAssetRegistry(), testCacheTTLs, zap.NewNop().Sugar())
+
+	provider.CheckAddress(context.Background(), "1A1zP1eP5QGefi2DMPTfTL5SLmv7DivfNa", "BTC", "bitcoin")
+
+	if len(cache.values) != 0 {
+		t.Errorf("failure was cached")
+	}
+}
+
+func TestCachedAMLProviderDoesNotCacheFallbackResults(t *testing.T) {
+	breaker := NewCircuitBreaker("AMLBot", 1, time.Minute)
+	breaker.Failure()
+	fallback := &stubAMLProvider{name: "MockAML", result: &domain.AMLResult{RiskLevel: domain.RiskLevelLow, Categories: []string{}}}
+	resilient, _ := newTestResilientAMLProvider(&flakyAMLProvider{name: "AMLBot"}, breaker, fallback)
+
+	cache := newRecordingCache()
+	provider := NewCachedAMLProvider(resilient, cache, domain.NewDefaultAssetRegistry(), testCacheTTLs, zap.NewNop().Sugar())
+
+	result, err := provider.CheckAddress(context.Background(), "1A1zP1eP5QGefi2DMPTfTL5SLmv7DivfNa", "BTC", "bitcoin")
+	if err != nil {
+		t.Fatalf("CheckAddress() error = %v", err)
+	}
+	if !result.Degraded {
+		t.Errorf("result is not marked degraded")
+	}
+	if len(cache.values) != 0 {
+		t.Errorf("fallback result was cached under %v", cache.values)
+	}
+}
+
+func TestCachedSanctionsProvider(t *testing.T) {
+	upstream := &countingSanctionsProvider{stubSanctionsProvider: stubSanctionsProvider{name: "Chainalysis", result: &domain.SanctionsResult{
+		Hit:             true,
+		Identifications: []domain.SanctionsIdentification{{Category: "sanctions", Name: "SDN"}},
+	}}}
+	cache := newRecordingCache()
+	provider := NewCachedSanctionsProvider(upstream, cache, testCacheTTLs, zap.NewNop().Sugar())
+
+	now := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+	provider.now = func() time.Time { return now }
+	ctx := context.Background()
+
+	provider.CheckAddress(ctx, "address", "bitcoin")
+	if ttl := cache.ttls["sanctions:chainalysis:bitcoin:address"]; ttl != testCacheTTLs.Hit {
+		t.Errorf("ttl = %v, want the hit ttl", ttl)
+	}
+
+	now = now.Add(time.Hour)
+	result, err := provider.CheckAddress(ctx, "address", "bitcoin")
+	if err != nil {
+		t.Fatalf("CheckAddress() error = %v", err)
+	}
+	if upstream.calls != 1 {
+		t.Fatalf("provider called %d times, want 1", upstream.calls)
+	}
+	if !result.Hit || len(result.Identifications) != 1 {
+		t.Errorf("cached result = %+v, want the hit", result)
+	}
+	if info, ok := result.Cache["Chainalysis"]; !ok || info.AgeSeconds != 3600 {
+		t.Errorf("cache info = %+v, want Chainalysis 3600s old", result.Cache)
+	}
+}
@@ -39,12 +39,15 @@ func NewChainalysisProvider(apiKey string, logger *zap.SugaredLogger) *Chainalys
 	}
 }
 
-func (p *ChainalysisProvider) CheckAddress(ctx context.Context, address string) (*domain.SanctionsResult, error) {
+func (p *ChainalysisProvider) CheckAddress(ctx context.Context, address, chain string) (*domain.SanctionsResult, error) {
 	if p.apiKey == "" {
-		p.logger.Warn("chainalysis api key not set, returning empty result")
+		p.logger.Warn("chainalysis api key not set, skipping sanctions screening")
 		return &domain.SanctionsResult{
 			Hit:             false,
 			Identifications: []domain.SanctionsIdentification{},
+			Status:          domain.SanctionsSkipped,
+			Provider:        p.Name(),
+			Reason:          "chainalysis api key not set",
 		}, nil
 	}
 
@@ -74,11 +77,17 @@ func (p *ChainalysisProvider) CheckAddress(ctx context.Context, address string)
 
 	if resp.StatusCode != http.StatusOK {
 		body, _ := io.ReadAll(resp.Body)
-		return nil, fmt.Errorf("chainalysis returned status %d: %s", resp.StatusCode, string(body))
+		return nil, &StatusError{Provider: "chainalysis", StatusCode: resp.StatusCode, Body: string(body)}
+	}
+
+	// the raw body is hashed for the audit log
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read response: %w", err)
 	}
 
 	var chainResp ChainalysisResponse
-	if err := json.NewDecoder(resp.Body).Decode(&chainResp); err != nil {
+	if err := json.Unmarshal(body, &chainResp); err != nil {
 		return nil, fmt.Errorf("failed to decode response: %w", err)
 	}
 
@@ -95,6 +104,7 @@ func (p *ChainalysisProvider) CheckAddress(ctx context.Context, address string)
 	return &domain.SanctionsResult{
 		Hit:             len(identifications) > 0,
 		Identifications: identifications,
+		PayloadHashes:   map[string]string{p.Name(): domain.HashPayload(body)},
 	}, nil
 }
 
@@ -1,9 +1,11 @@
 package providers
 
 import (
+	"context"
 	"testing"
 
 	"github.com/Beka01247/bitpanda-aml/internal/domain"
+	"go.uber.org/zap"
 )
 
 func TestChainalysisProvider_ParseResponse(t *testing.T) {
@@ -97,3 +99,16 @@ func TestChainalysisProvider_ParseResponse(t *testing.T) {
 		})
 	}
 }
+
+func TestChainalysisProvider_WithoutAPIKey(t *testing.T) {
+	provider := NewChainalysisProvider("", zap.NewNop().Sugar())
+
+	result, err := provider.CheckAddress(context.Background(), "address", "bitcoin")
+	if err != nil {
+		t.Fatalf("CheckAddress() error = %v", err)
+	}
+
+	if result.Status != domain.SanctionsSkipped || result.Screened() {
+		t.Errorf("Status = %q, want %q", result.Status, domain.SanctionsSkipped)
+	}
+}
@@ -0,0 +1,167 @@
+package providers
+
+import (
+	"errors"
+	"sort"
+	"sync"
+	"time"
+)
+
+var ErrCircuitOpen = errors.New("circuit breaker open")
+
+type BreakerState string
+
+const (
+	BreakerClosed BreakerState = "closed"
+	// calls fail fast until the open duration has passed
+	BreakerOpen BreakerState = "open"
+	// a single trial call decides whether to close again
+	BreakerHalfOpen BreakerState = "half_open"
+)
+
+type BreakerStatus struct {
+	Provider            string       `json:"provider"`
+	State               BreakerState `json:"state"`
+	ConsecutiveFailures int          `json:"consecutive_failures"`
+	OpenedAt            *time.Time   `json:"opened_at,omitempty"`
+}
+
+// opens after threshold consecutive failures and lets a trial call through once
+// openFor has passed
+type CircuitBreaker struct {
+	provider  string
+	threshold int
+	openFor   time.Duration
+	now       func() time.Time
+
+	mu       sync.Mutex
+	state    BreakerState
+	failures int
+	openedAt time.Time
+	// a half-open trial call is running, it's given up on after openFor
+	// in case its caller went away without reporting back
+	probing        bool
+	probeStartedAt time.Time
+}
+
+func NewCircuitBreaker(provider string, threshold int, openFor time.Duration) *CircuitBreaker {
+	if threshold < 1 {
+		threshold = 1
+	}
+	return &CircuitBreaker{
+		provider:  provider,
+		threshold: threshold,
+		openFor:   openFor,
+		now:       time.Now,
+		state:     BreakerClosed,
+	}
+}
+
+// returns ErrCircuitOpen when the call should not be made
+func (b *CircuitBreaker) Allow() error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	switch b.state {
+	case BreakerOpen:
+		if b.now().Sub(b.openedAt) < b.openFor {
+			return ErrCircuitOpen
+		}
+		b.state = BreakerHalfOpen
+		b.startProbe()
+		return nil
+	case BreakerHalfOpen:
+		if b.probing && b.now().Sub(b.probeStartedAt) < b.openFor {
+			return ErrCircuitOpen
+		}
+		b.startProbe()
+		return nil
+	default:
+		return nil
+	}
+}
+
+func (b *CircuitBreaker) startProbe() {
+	b.probing = true
+	b.probeStartedAt = b.now()
+}
+
+func (b *CircuitBreaker) Success() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.state = BreakerClosed
+	b.failures = 0
+	b.probing = false
+}
+
+func (b *CircuitBreaker) Failure() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.failures++
+	b.probing = false
+	if b.state == BreakerHalfOpen || b.failures >= b.threshold {
+		b.state = BreakerOpen
+		b.openedAt = b.now()
+	}
+}
+
+func (b *CircuitBreaker) Status() BreakerStatus {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	status := BreakerStatus{
+		Provider:            b.provider,
+		State:               b.state,
+		ConsecutiveFailures: b.failures,
+	}
+	if b.state != BreakerClosed {
+		openedAt := b.openedAt.UTC()
+		status.OpenedAt = &openedAt
+	}
+	return status
+}
+
+// one breaker per provider name, listed by the health check
+type BreakerRegistry struct {
+	threshold int
+	openFor   time.Duration
+
+	mu       sync.Mutex
+	breakers map[string]*CircuitBreaker
+}
+
+func NewBreakerRegistry(threshold int, openFor time.Duration) *BreakerRegistry {
+	return &BreakerRegistry{
+		threshold: threshold,
+		openFor:   openFor,
+		breakers:  make(map[string]*CircuitBreaker),
+	}
+}
+
+// returns the provider's breaker, creating it on first use
+func (r *BreakerRegistry) Get(provider string) *CircuitBreaker {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	breaker, ok := r.breakers[provider]
+	if !ok {
+		breaker = NewCircuitBreaker(provider, r.threshold, r.openFor)
+		r.breakers[provider] = breaker
+	}
+	return breaker
+}
+
+// ordered by provider name
+func (r *BreakerRegistry) Statuses() []BreakerStatus {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	statuses := make([]BreakerStatus, 0, len(r.breakers))
+	for _, breaker := range r.breakers {
+		statuses = append(statuses, breaker.Status())
+	}
+	sort.Slice(statuses, func(i, j int) bool { return statuses[i].Provider < statuses[j].Provider })
+	return statuses
+}
@@ -0,0 +1,75 @@
+package providers
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestCircuitBreaker(t *testing.T) {
+	now := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	breaker := NewCircuitBreaker("AMLBot", 3, 30*time.Second)
+	breaker.now = func() time.Time { return now }
+
+	for range 2 {
+		breaker.Failure()
+	}
+	if err := breaker.Allow(); err != nil {
+		t.Fatalf("Allow() after 2 failures = %v, want closed", err)
+	}
+
+	// a success resets the count
+	breaker.Success()
+	for range 2 {
+		breaker.Failure()
+	}
+	if breaker.Status().State != BreakerClosed {
+		t.Fatalf("state = %s, want closed", breaker.Status().State)
+	}
+
+	breaker.Failure()
+	if err := breaker.Allow(); !errors.Is(err, ErrCircuitOpen) {
+		t.Fatalf("Allow() after 3 failures = %v, want ErrCircuitOpen", err)
+	}
+	status := breaker.Status()
+	if status.State != BreakerOpen || status.OpenedAt == nil || status.ConsecutiveFailures != 3 {
+		t.Errorf("status = %+v, want open since now with 3 failures", status)
+	}
+
+	now = now.Add(30 * time.Second)
+	if err := breaker.Allow(); err != nil {
+		t.Fatalf("Allow() after open duration = %v, want a trial call", err)
+	}
+	if err := breaker.Allow(); !errors.Is(err, ErrCircuitOpen) {
+		t.Errorf("second call during the trial = %v, want ErrCircuitOpen", err)
+	}
+
+	// the trial failed, open again
+	breaker.Failure()
+	if err := breaker.Allow(); !errors.Is(err, ErrCircuitOpen) {
+		t.Fatalf("Allow() after failed trial = %v, want ErrCircuitOpen", err)
+	}
+
+	now = now.Add(30 * time.Second)
+	breaker.Allow()
+	breaker.Success()
+	if status := breaker.Status(); status.State != BreakerClosed || status.ConsecutiveFailures != 0 {
+		t.Errorf("status after successful trial = %+v, want closed", status)
+	}
+}
+
+func TestCircuitBreakerAbandonedTrial(t *testing.T) {
+	now := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	breaker := NewCircuitBreaker("AMLBot", 1, time.Minute)
+	breaker.now = func() time.Time { return now }
+
+	breaker.Failure()
+	now = now.Add(time.Minute)
+	// trial call whose caller never reports back
+	breaker.Allow()
+
+	now = now.Add(time.Minute)
+	if err := breaker.Allow(); err != nil {
+		t.Errorf("Allow() after an abandoned trial = %v, want a new trial", err)
+	}
+}
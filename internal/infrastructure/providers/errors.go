@@ -0,0 +1,35 @@
+package providers
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+)
+
+// non-200 answer from a provider API
+type StatusError struct {
+	Provider   string
+	StatusCode int
+	Body       string
+}
+
+func (e *StatusError) Error() string {
+	return fmt.Sprintf("%s returned status %d: %s", e.Provider, e.StatusCode, e.Body)
+}
+
+// 5xx, 429, timeouts and connection failures are worth another attempt,
+// anything else would fail the same way again
+func isTransient(err error) bool {
+	var statusErr *StatusError
+	if errors.As(err, &statusErr) {
+		return statusErr.StatusCode >= 500 || statusErr.StatusCode == 429
+	}
+
+	if errors.Is(err, context.DeadlineExceeded) {
+		return true
+	}
+
+	var netErr net.Error
+	return errors.As(err, &netErr)
+}
@@ -17,8 +17,8 @@ func NewMockAMLProvider(logger *zap.SugaredLogger) *MockAMLProvider {
 	}
 }
 
-func (p *MockAMLProvider) CheckAddress(ctx context.Context, address, currency string) (*domain.AMLResult, error) {
-	p.logger.Infow("mock aml check", "address", address, "currency", currency)
+func (p *MockAMLProvider) CheckAddress(ctx context.Context, address, currency, chain string) (*domain.AMLResult, error) {
+	p.logger.Infow("mock aml check", "address", address, "currency", currency, "chain", chain)
 
 	// generate deterministic-ish risk score based on address
 	score := 10 + (len(address) % 80)
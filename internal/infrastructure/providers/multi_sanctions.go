@@ -0,0 +1,115 @@
+package providers
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+
+	"github.com/Beka01247/bitpanda-aml/internal/domain"
+	"go.uber.org/zap"
+)
+
+// screens an address against several sanctions sources in parallel and
+// merges their identifications
+type MultiSanctionsProvider struct {
+	providers []domain.SanctionsProvider
+	logger    *zap.SugaredLogger
+}
+
+func NewMultiSanctionsProvider(providers []domain.SanctionsProvider, logger *zap.SugaredLogger) *MultiSanctionsProvider {
+	return &MultiSanctionsProvider{
+		providers: providers,
+		logger:    logger,
+	}
+}
+
+func (p *MultiSanctionsProvider) CheckAddress(ctx context.Context, address, chain string) (*domain.SanctionsResult, error) {
+	results := make([]*domain.SanctionsResult, len(p.providers))
+	errs := make([]error, len(p.providers))
+
+	var wg sync.WaitGroup
+	for i, provider := range p.providers {
+		wg.Add(1)
+		go func(i int, provider domain.SanctionsProvider) {
+			defer wg.Done()
+			results[i], errs[i] = provider.CheckAddress(ctx, address, chain)
+		}(i, provider)
+	}
+	wg.Wait()
+
+	merged := &domain.SanctionsResult{
+		Hit:             false,
+		Identifications: []domain.SanctionsIdentification{},
+	}
+
+	failures := make([]error, 0)
+	skipped := make([]string, 0)
+	for i, result := range results {
+		if errs[i] != nil {
+			p.logger.Warnw("sanctions provider failed", "provider", p.providers[i].Name(), "error", errs[i])
+			failures = append(failures, fmt.Errorf("%s: %w", p.providers[i].Name(), errs[i]))
+			if merged.Failures == nil {
+				merged.Failures = make(map[string]string)
+			}
+			merged.Failures[p.providers[i].Name()] = errs[i].Error()
+			continue
+		}
+
+		if !result.Screened() {
+			skipped = append(skipped, fmt.Sprintf("%s: %s", p.providers[i].Name(), result.Reason))
+			continue
+		}
+
+		merged.Hit = merged.Hit || result.Hit
+		merged.Identifications = append(merged.Identifications, result.Identifications...)
+		for provider, hash := range result.PayloadHashes {
+			if merged.PayloadHashes == nil {
+				merged.PayloadHashes = make(map[string]string)
+			}
+			merged.PayloadHashes[provider] = hash
+		}
+		for provider, info := range result.Cache {
+			if merged.Cache == nil {
+				merged.Cache = make(map[string]domain.CacheInfo)
+			}
+			merged.Cache[provider] = info
+		}
+	}
+
+	// a partial answer is still better than none, but no answer at all is an error
+	if len(failures) == len(p.providers) {
+		return nil, errors.Join(failures...)
+	}
+
+	// nothing was screened, a failed source makes it an error rather than a skip
+	if len(failures)+len(skipped) == len(p.providers) {
+		merged.Status = domain.SanctionsSkipped
+		merged.Provider = p.Name()
+		reasons := skipped
+		if len(failures) > 0 {
+			merged.Status = domain.SanctionsError
+			reasons = append(errorMessages(failures), skipped...)
+		}
+		merged.Reason = strings.Join(reasons, "; ")
+	}
+
+	return merged, nil
+}
+
+func errorMessages(errs []error) []string {
+	messages := make([]string, 0, len(errs))
+	for _, err := range errs {
+		messages = append(messages, err.Error())
+	}
+	return messages
+}
+
+func (p *MultiSanctionsProvider) Name() string {
+	names := make([]string, 0, len(p.providers))
+	for _, provider := range p.providers {
+		names = append(names, provider.Name())
+	}
+	return strings.Join(names, "+")
+}
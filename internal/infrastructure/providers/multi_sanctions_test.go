@@ -0,0 +1,119 @@
+package providers
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/Beka01247/bitpanda-aml/internal/domain"
+	"go.uber.org/zap"
+)
+
+type stubSanctionsProvider struct {
+	name   string
+	result *domain.SanctionsResult
+	err    error
+}
+
+func (p *stubSanctionsProvider) CheckAddress(ctx context.Context, address, chain string) (*domain.SanctionsResult, error) {
+	return p.result, p.err
+}
+
+func (p *stubSanctionsProvider) Name() string {
+	return p.name
+}
+
+func TestMultiSanctionsProvider_CheckAddress(t *testing.T) {
+	clean := &stubSanctionsProvider{name: "clean", result: &domain.SanctionsResult{Identifications: []domain.SanctionsIdentification{}}}
+	hit := &stubSanctionsProvider{name: "hit", result: &domain.SanctionsResult{
+		Hit:             true,
+		Identifications: []domain.SanctionsIdentification{{Category: "sanctions", Name: "SDN"}},
+	}}
+	down := &stubSanctionsProvider{name: "down", err: errors.New("unavailable")}
+
+	tests := []struct {
+		name      string
+		providers []domain.SanctionsProvider
+		wantHit   bool
+		wantLen   int
+		wantErr   bool
+		// sources reported as failed in a partial answer
+		wantFailures int
+	}{
+		{"all clean", []domain.SanctionsProvider{clean, clean}, false, 0, false, 0},
+		{"one hit", []domain.SanctionsProvider{clean, hit}, true, 1, false, 0},
+		{"hit with partial failure", []domain.SanctionsProvider{down, hit}, true, 1, false, 1},
+		{"all failed", []domain.SanctionsProvider{down, down}, false, 0, true, 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			provider := NewMultiSanctionsProvider(tt.providers, zap.NewNop().Sugar())
+
+			result, err := provider.CheckAddress(context.Background(), "address", "bitcoin")
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("CheckAddress() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr {
+				return
+			}
+
+			if result.Hit != tt.wantHit {
+				t.Errorf("Hit = %v, want %v", result.Hit, tt.wantHit)
+			}
+
+			if len(result.Identifications) != tt.wantLen {
+				t.Errorf("Identifications length = %v, want %v", len(result.Identifications), tt.wantLen)
+			}
+
+			if len(result.Failures) != tt.wantFailures {
+				t.Errorf("Failures = %v, want %d", result.Failures, tt.wantFailures)
+			}
+		})
+	}
+}
+
+func TestMultiSanctionsProvider_Skipped(t *testing.T) {
+	clean := &stubSanctionsProvider{name: "clean", result: &domain.SanctionsResult{Identifications: []domain.SanctionsIdentification{}}}
+	skipped := &stubSanctionsProvider{name: "skipped", result: &domain.SanctionsResult{
+		Identifications: []domain.SanctionsIdentification{},
+		Status:          domain.SanctionsSkipped,
+		Reason:          "api key not set",
+	}}
+	down := &stubSanctionsProvider{name: "down", err: errors.New("unavailable")}
+
+	tests := []struct {
+		name         string
+		providers    []domain.SanctionsProvider
+		wantScreened bool
+		wantStatus   domain.SanctionsStatus
+	}{
+		{"one source screened", []domain.SanctionsProvider{skipped, clean}, true, ""},
+		{"all skipped", []domain.SanctionsProvider{skipped, skipped}, false, domain.SanctionsSkipped},
+		// a failure must not pass for a deliberate skip
+		{"skipped and failed", []domain.SanctionsProvider{skipped, down}, false, domain.SanctionsError},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			provider := NewMultiSanctionsProvider(tt.providers, zap.NewNop().Sugar())
+
+			result, err := provider.CheckAddress(context.Background(), "address", "bitcoin")
+			if err != nil {
+				t.Fatalf("CheckAddress() error = %v", err)
+			}
+
+			if result.Screened() != tt.wantScreened {
+				t.Errorf("Screened() = %v, want %v (status %q)", result.Screened(), tt.wantScreened, result.Status)
+			}
+
+			if result.Status != tt.wantStatus {
+				t.Errorf("Status = %q, want %q", result.Status, tt.wantStatus)
+			}
+
+			if !tt.wantScreened && result.Reason == "" {
+				t.Error("Reason is empty for an unscreened result")
+			}
+		})
+	}
+}
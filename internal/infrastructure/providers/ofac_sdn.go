@@ -0,0 +1,395 @@
+package providers
+
+import (
+	"context"
+	"encoding/csv"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/Beka01247/bitpanda-aml/internal/domain"
+	"go.uber.org/zap"
+)
+
+const (
+	ofacDigitalCurrencyPrefix = "Digital Currency Address - "
+	ofacDetailsURL            = "https://sanctionssearch.ofac.treas.gov/Details.aspx?id=%s"
+)
+
+// matches "Digital Currency Address - XBT 1abc..." in the sdn.csv remarks column
+var ofacRemarksAddressPattern = regexp.MustCompile(`Digital Currency Address - ([A-Za-z0-9]+)\s+([A-Za-z0-9:]+)`)
+
+// EVM chains share one address space, a designation on any of them applies to all
+const ofacEVMChain = "evm"
+
+// maps OFAC currency tickers to the chain names used by the asset registry
+var ofacTickerChains = map[string]string{
+	"XBT":  "bitcoin",
+	"ETH":  ofacEVMChain,
+	"USDC": ofacEVMChain,
+	"TRX":  "tron",
+	"LTC":  "litecoin",
+	"BCH":  "bitcoin-cash",
+	"BSV":  "bitcoin-sv",
+	"BTG":  "bitcoin-gold",
+	"DASH": "dash",
+	"XMR":  "monero",
+	"ZEC":  "zcash",
+	"ETC":  ofacEVMChain,
+	"XVG":  "verge",
+	"ARB":  ofacEVMChain,
+	"BSC":  ofacEVMChain,
+	"SOL":  "solana",
+	"XRP":  "ripple",
+	"DOGE": "dogecoin",
+	"XLM":  "stellar",
+	"TON":  "ton",
+}
+
+type sdnEntry struct {
+	uid  string
+	name string
+}
+
+// chain -> normalized address -> sanctioned parties
+type sdnIndex map[string]map[string][]sdnEntry
+
+func (idx sdnIndex) add(ticker, address string, entry sdnEntry) {
+	address = strings.TrimSpace(address)
+	if address == "" {
+		return
+	}
+
+	chain := ofacChain(ticker, address)
+	if idx[chain] == nil {
+		idx[chain] = make(map[string][]sdnEntry)
+	}
+
+	normalized := normalizeChainAddress(chain, address)
+	idx[chain][normalized] = append(idx[chain][normalized], entry)
+}
+
+func (idx sdnIndex) size() int {
+	count := 0
+	for _, addresses := range idx {
+		count += len(addresses)
+	}
+	return count
+}
+
+// screens addresses against a local copy of the OFAC SDN list (advanced XML or sdn.csv)
+type OFACSDNProvider struct {
+	path    string
+	index   sdnIndex
+	modTime time.Time
+	mu      sync.RWMutex
+	logger  *zap.SugaredLogger
+}
+
+func NewOFACSDNProvider(path string, logger *zap.SugaredLogger) (*OFACSDNProvider, error) {
+	p := &OFACSDNProvider{
+		path:   path,
+		logger: logger,
+	}
+
+	if err := p.Reload(); err != nil {
+		return nil, err
+	}
+
+	return p, nil
+}
+
+// only the listings of the address's own chain are searched
+func (p *OFACSDNProvider) CheckAddress(ctx context.Context, address, chain string) (*domain.SanctionsResult, error) {
+	chain = ofacIndexChain(chain, address)
+	normalized := normalizeChainAddress(chain, address)
+
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+
+	identifications := []domain.SanctionsIdentification{}
+	seen := make(map[string]struct{})
+	for _, entry := range p.index[chain][normalized] {
+		if _, ok := seen[entry.uid]; ok {
+			continue
+		}
+		seen[entry.uid] = struct{}{}
+		identifications = append(identifications, entry.identification())
+	}
+
+	return &domain.SanctionsResult{
+		Hit:             len(identifications) > 0,
+		Identifications: identifications,
+	}, nil
+}
+
+func (p *OFACSDNProvider) Name() string {
+	return "OFAC SDN"
+}
+
+// re-reads the list file and swaps the index
+func (p *OFACSDNProvider) Reload() error {
+	info, err := os.Stat(p.path)
+	if err != nil {
+		return fmt.Errorf("failed to stat sdn list: %w", err)
+	}
+
+	file, err := os.Open(p.path)
+	if err != nil {
+		return fmt.Errorf("failed to open sdn list: %w", err)
+	}
+	defer file.Close()
+
+	var index sdnIndex
+	if strings.EqualFold(filepath.Ext(p.path), ".csv") {
+		index, err = parseSDNCSV(file)
+	} else {
+		index, err = parseSDNAdvancedXML(file)
+	}
+	if err != nil {
+		return fmt.Errorf("failed to parse sdn list: %w", err)
+	}
+
+	p.mu.Lock()
+	p.index = index
+	p.modTime = info.ModTime()
+	p.mu.Unlock()
+
+	p.logger.Infow("ofac sdn list loaded", "path", p.path, "addresses", index.size())
+
+	return nil
+}
+
+// starts a background loop reloading the list whenever the file changes
+func (p *OFACSDNProvider) StartWatchLoop(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	go func() {
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ctx.Done():
+				p.logger.Info("sdn watch loop stopped")
+				return
+			case <-ticker.C:
+				info, err := os.Stat(p.path)
+				if err != nil {
+					p.logger.Errorw("failed to stat sdn list", "path", p.path, "error", err)
+					continue
+				}
+
+				p.mu.RLock()
+				changed := !info.ModTime().Equal(p.modTime)
+				p.mu.RUnlock()
+
+				if !changed {
+					continue
+				}
+
+				// keep serving the previous index if the new file is broken
+				if err := p.Reload(); err != nil {
+					p.logger.Errorw("failed to reload sdn list", "path", p.path, "error", err)
+				}
+			}
+		}
+	}()
+}
+
+func (e sdnEntry) identification() domain.SanctionsIdentification {
+	return domain.SanctionsIdentification{
+		Category: "sanctions",
+		Name:     e.name,
+		URL:      fmt.Sprintf(ofacDetailsURL, e.uid),
+	}
+}
+
+// sdn.csv has no header: ent_num, SDN_Name, SDN_Type, Program, ..., Remarks (12th column)
+func parseSDNCSV(r io.Reader) (sdnIndex, error) {
+	reader := csv.NewReader(r)
+	reader.FieldsPerRecord = -1
+	reader.LazyQuotes = true
+
+	index := make(sdnIndex)
+	for {
+		record, err := reader.Read()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		if len(record) < 12 {
+			continue
+		}
+
+		entry := sdnEntry{
+			uid:  strings.TrimSpace(record[0]),
+			name: strings.TrimSpace(record[1]),
+		}
+
+		for _, match := range ofacRemarksAddressPattern.FindAllStringSubmatch(record[11], -1) {
+			index.add(match[1], match[2], entry)
+		}
+	}
+
+	return index, nil
+}
+
+type sdnFeatureType struct {
+	ID    string `xml:"ID,attr"`
+	Value string `xml:",chardata"`
+}
+
+type sdnDistinctParty struct {
+	FixedRef string `xml:"FixedRef,attr"`
+	Profiles []struct {
+		Identities []struct {
+			Aliases []struct {
+				Primary         string `xml:"Primary,attr"`
+				DocumentedNames []struct {
+					Parts []struct {
+						Value string `xml:"NamePartValue"`
+					} `xml:"DocumentedNamePart"`
+				} `xml:"DocumentedName"`
+			} `xml:"Alias"`
+		} `xml:"Identity"`
+		Features []struct {
+			FeatureTypeID string `xml:"FeatureTypeID,attr"`
+			Versions      []struct {
+				Details []string `xml:"VersionDetail"`
+			} `xml:"FeatureVersion"`
+		} `xml:"Feature"`
+	} `xml:"Profile"`
+}
+
+func (party *sdnDistinctParty) primaryName() string {
+	for _, profile := range party.Profiles {
+		for _, identity := range profile.Identities {
+			for _, alias := range identity.Aliases {
+				if alias.Primary != "true" {
+					continue
+				}
+				for _, name := range alias.DocumentedNames {
+					parts := make([]string, 0, len(name.Parts))
+					for _, part := range name.Parts {
+						parts = append(parts, strings.TrimSpace(part.Value))
+					}
+					return strings.Join(parts, " ")
+				}
+			}
+		}
+	}
+	return "OFAC SDN"
+}
+
+// streams the advanced XML export, only decoding the feature types and parties
+func parseSDNAdvancedXML(r io.Reader) (sdnIndex, error) {
+	decoder := xml.NewDecoder(r)
+
+	// FeatureTypeID -> currency ticker for the digital currency address features
+	tickers := make(map[string]string)
+	index := make(sdnIndex)
+
+	for {
+		token, err := decoder.Token()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		start, ok := token.(xml.StartElement)
+		if !ok {
+			continue
+		}
+
+		switch start.Name.Local {
+		case "FeatureType":
+			var featureType sdnFeatureType
+			if err := decoder.DecodeElement(&featureType, &start); err != nil {
+				return nil, err
+			}
+			if ticker, ok := strings.CutPrefix(strings.TrimSpace(featureType.Value), ofacDigitalCurrencyPrefix); ok {
+				tickers[featureType.ID] = strings.TrimSpace(ticker)
+			}
+
+		case "DistinctParty":
+			var party sdnDistinctParty
+			if err := decoder.DecodeElement(&party, &start); err != nil {
+				return nil, err
+			}
+
+			entry := sdnEntry{uid: party.FixedRef, name: party.primaryName()}
+			for _, profile := range party.Profiles {
+				for _, feature := range profile.Features {
+					ticker, ok := tickers[feature.FeatureTypeID]
+					if !ok {
+						continue
+					}
+					for _, version := range feature.Versions {
+						for _, detail := range version.Details {
+							index.add(ticker, detail, entry)
+						}
+					}
+				}
+			}
+		}
+	}
+
+	if len(tickers) == 0 {
+		return nil, fmt.Errorf("no digital currency address feature types found")
+	}
+
+	return index, nil
+}
+
+func ofacChain(ticker, address string) string {
+	ticker = strings.ToUpper(strings.TrimSpace(ticker))
+
+	// USDT is listed without a network, tell them apart by address format
+	if ticker == "USDT" {
+		if strings.HasPrefix(address, "T") {
+			return "tron"
+		}
+		return ofacEVMChain
+	}
+
+	if chain, ok := ofacTickerChains[ticker]; ok {
+		return chain
+	}
+	return strings.ToLower(ticker)
+}
+
+// EVM chains are configurable, their addresses are the only hex ones
+func ofacIndexChain(chain, address string) string {
+	if strings.HasPrefix(strings.ToLower(strings.TrimSpace(address)), "0x") {
+		return ofacEVMChain
+	}
+	return chain
+}
+
+// bitcoin cash is listed in legacy or CashAddr form, checks carry the CashAddr one
+func normalizeChainAddress(chain, address string) string {
+	if bch := (domain.BitcoinCash{}); chain == bch.Chain() {
+		return bch.NormalizeAddress(address)
+	}
+	return normalizeSanctionedAddress(address)
+}
+
+// hex and bech32 addresses are case-insensitive, base58 addresses are not
+func normalizeSanctionedAddress(address string) string {
+	address = strings.TrimSpace(address)
+	lower := strings.ToLower(address)
+	if strings.HasPrefix(lower, "0x") || strings.HasPrefix(lower, "bc1") || strings.HasPrefix(lower, "ltc1") {
+		return lower
+	}
+	return address
+}
@@ -0,0 +1,150 @@
+package providers
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+const testSDNCSV = `36,"AEROCARIBBEAN AIRLINES","-0- ","CUBA","-0- ","-0- ","-0- ","-0- ","-0- ","-0- ","-0- ","-0- "
+27304,"LAZARUS GROUP","-0- ","DPRK3","-0- ","-0- ","-0- ","-0- ","-0- ","-0- ","-0- ","a.k.a. 'APPLEWORM'; Digital Currency Address - ETH 0x098B716B8Aaf21512996dC57EB0615e2383E2f96; alt. Digital Currency Address - XBT 1ApNLFWbyqi6JSvJmS4xKXq7JQmKFn8fpP; alt. Digital Currency Address - BCH 1KXrWXciRDZUpQwQmuM1DbwsKDLYAYsVLR; Digital Currency Address - USDT TJDENsfBJs4RFETt1X1W8wMDc8M5XnJhCe."
+`
+
+const testSDNAdvancedXML = `<?xml version="1.0" encoding="utf-8"?>
+<Sanctions xmlns="https://sanctionslistservice.ofac.treas.gov/api/PublicationPreview/exports/ADVANCED_XML">
+  <ReferenceValueSets>
+    <FeatureTypeValues>
+      <FeatureType ID="8">Birthdate</FeatureType>
+      <FeatureType ID="344" FeatureTypeGroupID="1">Digital Currency Address - XBT</FeatureType>
+      <FeatureType ID="345" FeatureTypeGroupID="1">Digital Currency Address - ETH</FeatureType>
+    </FeatureTypeValues>
+  </ReferenceValueSets>
+  <DistinctParties>
+    <DistinctParty FixedRef="25459">
+      <Profile ID="25459" PartySubTypeID="4">
+        <Identity ID="11235" FixedRef="25459" Primary="true">
+          <Alias FixedRef="25459" AliasTypeID="1403" Primary="true">
+            <DocumentedName ID="49990" FixedRef="25459">
+              <DocumentedNamePart>
+                <NamePartValue NamePartGroupID="1">GARANTEX EUROPE OU</NamePartValue>
+              </DocumentedNamePart>
+            </DocumentedName>
+          </Alias>
+        </Identity>
+        <Feature ID="1" FeatureTypeID="8">
+          <FeatureVersion ID="1"><VersionDetail DetailTypeID="1432">1980</VersionDetail></FeatureVersion>
+        </Feature>
+        <Feature ID="2" FeatureTypeID="344">
+          <FeatureVersion ID="2"><VersionDetail DetailTypeID="1432">bc1qar0srrr7xfkvy5l643lydnw9re59gtzzwf5mdq</VersionDetail></FeatureVersion>
+        </Feature>
+        <Feature ID="3" FeatureTypeID="345">
+          <FeatureVersion ID="3"><VersionDetail DetailTypeID="1432">0x7FF9cFad3877F21d41Da833E2F775dB0569eE3D9</VersionDetail></FeatureVersion>
+        </Feature>
+      </Profile>
+    </DistinctParty>
+  </DistinctParties>
+</Sanctions>
+`
+
+func writeSDNFile(t *testing.T, name, content string) string {
+	t.Helper()
+
+	path := filepath.Join(t.TempDir(), name)
+	if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+		t.Fatalf("failed to write sdn file: %v", err)
+	}
+	return path
+}
+
+func TestOFACSDNProvider_CheckAddress(t *testing.T) {
+	tests := []struct {
+		name     string
+		file     string
+		content  string
+		address  string
+		chain    string
+		wantHit  bool
+		wantName string
+	}{
+		{"csv eth mixed case", "sdn.csv", testSDNCSV, "0x098b716b8aaf21512996dc57eb0615e2383e2f96", "ethereum", true, "LAZARUS GROUP"},
+		{"csv eth listing on another evm chain", "sdn.csv", testSDNCSV, "0x098b716b8aaf21512996dc57eb0615e2383e2f96", "polygon", true, "LAZARUS GROUP"},
+		{"csv btc", "sdn.csv", testSDNCSV, "1ApNLFWbyqi6JSvJmS4xKXq7JQmKFn8fpP", "bitcoin", true, "LAZARUS GROUP"},
+		{"csv usdt tron", "sdn.csv", testSDNCSV, "TJDENsfBJs4RFETt1X1W8wMDc8M5XnJhCe", "tron", true, "LAZARUS GROUP"},
+		{"csv bch legacy listing", "sdn.csv", testSDNCSV, "bitcoincash:qr95sy3j9xwd2ap32xkykttr4cvcu7as4y0qverfuy", "bitcoin-cash", true, "LAZARUS GROUP"},
+		{"csv base58 is case sensitive", "sdn.csv", testSDNCSV, "1apnlfwbyqi6jsvjms4xkxq7jqmkfn8fpp", "bitcoin", false, ""},
+		{"csv listing of another chain", "sdn.csv", testSDNCSV, "1ApNLFWbyqi6JSvJmS4xKXq7JQmKFn8fpP", "bitcoin-cash", false, ""},
+		{"csv clean", "sdn.csv", testSDNCSV, "0x742d35cc6634c0532925a3b844bc9e7595f0beb8", "ethereum", false, ""},
+		{"xml bech32", "sdn_advanced.xml", testSDNAdvancedXML, "BC1QAR0SRRR7XFKVY5L643LYDNW9RE59GTZZWF5MDQ", "bitcoin", true, "GARANTEX EUROPE OU"},
+		{"xml eth", "sdn_advanced.xml", testSDNAdvancedXML, "0x7ff9cfad3877f21d41da833e2f775db0569ee3d9", "ethereum", true, "GARANTEX EUROPE OU"},
+		{"xml ignores other features", "sdn_advanced.xml", testSDNAdvancedXML, "1980", "bitcoin", false, ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			provider, err := NewOFACSDNProvider(writeSDNFile(t, tt.file, tt.content), zap.NewNop().Sugar())
+			if err != nil {
+				t.Fatalf("NewOFACSDNProvider() error = %v", err)
+			}
+
+			result, err := provider.CheckAddress(context.Background(), tt.address, tt.chain)
+			if err != nil {
+				t.Fatalf("CheckAddress() error = %v", err)
+			}
+
+			if result.Hit != tt.wantHit {
+				t.Fatalf("Hit = %v, want %v", result.Hit, tt.wantHit)
+			}
+
+			if tt.wantHit && result.Identifications[0].Name != tt.wantName {
+				t.Errorf("Identifications[0].Name = %v, want %v", result.Identifications[0].Name, tt.wantName)
+			}
+		})
+	}
+}
+
+func TestOFACSDNProvider_ReloadOnChange(t *testing.T) {
+	path := writeSDNFile(t, "sdn.csv", "")
+
+	provider, err := NewOFACSDNProvider(path, zap.NewNop().Sugar())
+	if err != nil {
+		t.Fatalf("NewOFACSDNProvider() error = %v", err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	provider.StartWatchLoop(ctx, 10*time.Millisecond)
+
+	if err := os.WriteFile(path, []byte(testSDNCSV), 0644); err != nil {
+		t.Fatalf("failed to update sdn file: %v", err)
+	}
+	// make sure the modification time differs on coarse filesystems
+	future := time.Now().Add(time.Minute)
+	if err := os.Chtimes(path, future, future); err != nil {
+		t.Fatalf("failed to touch sdn file: %v", err)
+	}
+
+	deadline := time.Now().Add(2 * time.Second)
+	for time.Now().Before(deadline) {
+		result, _ := provider.CheckAddress(ctx, "1ApNLFWbyqi6JSvJmS4xKXq7JQmKFn8fpP", "bitcoin")
+		if result.Hit {
+			return
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+
+	t.Error("provider did not reload the changed sdn list")
+}
+
+func TestOFACSDNProvider_InvalidFile(t *testing.T) {
+	if _, err := NewOFACSDNProvider(filepath.Join(t.TempDir(), "missing.xml"), zap.NewNop().Sugar()); err == nil {
+		t.Error("NewOFACSDNProvider() should return error for a missing file")
+	}
+
+	if _, err := NewOFACSDNProvider(writeSDNFile(t, "sdn.xml", "<Sanctions></Sanctions>"), zap.NewNop().Sugar()); err == nil {
+		t.Error("NewOFACSDNProvider() should return error for an xml export without digital currency features")
+	}
+}
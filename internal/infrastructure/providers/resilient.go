@@ -0,0 +1,218 @@
+package providers
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/rand/v2"
+	"time"
+
+	"github.com/Beka01247/bitpanda-aml/internal/domain"
+	"go.uber.org/zap"
+)
+
+type ResilienceConfig struct {
+	// deadline of every attempt
+	CallTimeout time.Duration
+	MaxAttempts int
+	BackoffBase time.Duration
+	BackoffMax  time.Duration
+}
+
+// retries transient failures with jittered backoff, every attempt goes through the breaker
+type resiliencePolicy struct {
+	provider string
+	cfg      ResilienceConfig
+	breaker  *CircuitBreaker
+	logger   *zap.SugaredLogger
+	sleep    func(ctx context.Context, d time.Duration) error
+}
+
+func newResiliencePolicy(provider string, breaker *CircuitBreaker, cfg ResilienceConfig, logger *zap.SugaredLogger) *resiliencePolicy {
+	if cfg.MaxAttempts < 1 {
+		cfg.MaxAttempts = 1
+	}
+	return &resiliencePolicy{
+		provider: provider,
+		cfg:      cfg,
+		breaker:  breaker,
+		logger:   logger,
+		sleep:    sleepContext,
+	}
+}
+
+func (p *resiliencePolicy) do(ctx context.Context, call func(ctx context.Context) error) error {
+	var err error
+	for attempt := 1; attempt <= p.cfg.MaxAttempts; attempt++ {
+		if attempt > 1 {
+			if sleepErr := p.sleep(ctx, p.backoff(attempt-1)); sleepErr != nil {
+				return err
+			}
+		}
+
+		if openErr := p.breaker.Allow(); openErr != nil {
+			return fmt.Errorf("%s: %w", p.provider, openErr)
+		}
+
+		err = p.attempt(ctx, call)
+		if err == nil {
+			p.breaker.Success()
+			return nil
+		}
+
+		// the caller gave up, that says nothing about the provider
+		if ctx.Err() != nil {
+			return err
+		}
+
+		// the provider answered, it just didn't like the request
+		if !isTransient(err) {
+			p.breaker.Success()
+			return err
+		}
+
+		p.breaker.Failure()
+		p.logger.Warnw("provider attempt failed",
+			"provider", p.provider,
+			"attempt", attempt,
+			"max_attempts", p.cfg.MaxAttempts,
+			"error", err)
+	}
+	return err
+}
+
+func (p *resiliencePolicy) attempt(ctx context.Context, call func(ctx context.Context) error) error {
+	if p.cfg.CallTimeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, p.cfg.CallTimeout)
+		defer cancel()
+	}
+	return call(ctx)
+}
+
+// base, 2*base, 4*base, ... capped at max, each spread over its upper half
+// so retries from many checks don't line up
+func (p *resiliencePolicy) backoff(retry int) time.Duration {
+	delay := p.cfg.BackoffBase
+	for i := 1; i < retry && delay < p.cfg.BackoffMax; i++ {
+		delay *= 2
+	}
+	delay = min(delay, p.cfg.BackoffMax)
+	if delay <= 0 {
+		return 0
+	}
+	return delay/2 + rand.N(delay/2+1)
+}
+
+func sleepContext(ctx context.Context, d time.Duration) error {
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
+
+// while the breaker is open calls fail fast, or go to fallback when one is set
+type ResilientAMLProvider struct {
+	provider domain.AMLProvider
+	fallback domain.AMLProvider
+	policy   *resiliencePolicy
+	logger   *zap.SugaredLogger
+}
+
+func NewResilientAMLProvider(provider domain.AMLProvider, breaker *CircuitBreaker, cfg ResilienceConfig, fallback domain.AMLProvider, logger *zap.SugaredLogger) *ResilientAMLProvider {
+	return &ResilientAMLProvider{
+		provider: provider,
+		fallback: fallback,
+		policy:   newResiliencePolicy(provider.Name(), breaker, cfg, logger),
+		logger:   logger,
+	}
+}
+
+func (p *ResilientAMLProvider) CheckAddress(ctx context.Context, address, currency, chain string) (*domain.AMLResult, error) {
+	var result *domain.AMLResult
+	err := p.policy.do(ctx, func(ctx context.Context) error {
+		var err error
+		result, err = p.provider.CheckAddress(ctx, address, currency, chain)
+		return err
+	})
+
+	if errors.Is(err, ErrCircuitOpen) && p.fallback != nil {
+		return p.checkFallback(ctx, address, currency, chain, err)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// the breakdown names both providers so the report shows who actually answered
+func (p *ResilientAMLProvider) checkFallback(ctx context.Context, address, currency, chain string, openErr error) (*domain.AMLResult, error) {
+	p.logger.Warnw("aml provider circuit open, using fallback", "provider", p.Name(), "fallback", p.fallback.Name())
+
+	result, err := p.fallback.CheckAddress(ctx, address, currency, chain)
+	if err != nil {
+		return nil, fmt.Errorf("%w, fallback %s failed: %v", openErr, p.fallback.Name(), err)
+	}
+
+	breakdown := []domain.ProviderResult{{
+		Provider:   p.Name(),
+		Categories: []string{},
+		Error:      openErr.Error(),
+	}}
+	if len(result.Providers) > 0 {
+		breakdown = append(breakdown, result.Providers...)
+	} else {
+		breakdown = append(breakdown, domain.ProviderResult{
+			Provider:    p.fallback.Name(),
+			RiskScore:   result.RiskScore,
+			RiskLevel:   result.RiskLevel,
+			Categories:  result.Categories,
+			PayloadHash: result.PayloadHash,
+			Cache:       result.Cache,
+		})
+	}
+
+	fallbackResult := *result
+	fallbackResult.Providers = breakdown
+	fallbackResult.Degraded = true
+	return &fallbackResult, nil
+}
+
+func (p *ResilientAMLProvider) Name() string {
+	return p.provider.Name()
+}
+
+// while the breaker is open calls fail fast, the pipeline already carries on without sanctions
+type ResilientSanctionsProvider struct {
+	provider domain.SanctionsProvider
+	policy   *resiliencePolicy
+}
+
+func NewResilientSanctionsProvider(provider domain.SanctionsProvider, breaker *CircuitBreaker, cfg ResilienceConfig, logger *zap.SugaredLogger) *ResilientSanctionsProvider {
+	return &ResilientSanctionsProvider{
+		provider: provider,
+		policy:   newResiliencePolicy(provider.Name(), breaker, cfg, logger),
+	}
+}
+
+func (p *ResilientSanctionsProvider) CheckAddress(ctx context.Context, address, chain string) (*domain.SanctionsResult, error) {
+	var result *domain.SanctionsResult
+	err := p.policy.do(ctx, func(ctx context.Context) error {
+		var err error
+		result, err = p.provider.CheckAddress(ctx, address, chain)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+func (p *ResilientSanctionsProvider) Name() string {
+	return p.provider.Name()
+}
@@ -0,0 +1,164 @@
+package providers
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/Beka01247/bitpanda-aml/internal/domain"
+	"go.uber.org/zap"
+)
+
+// fails with the queued errors before answering
+type flakyAMLProvider struct {
+	name  string
+	errs  []error
+	calls int
+}
+
+func (p *flakyAMLProvider) CheckAddress(ctx context.Context, address, currency, chain string) (*domain.AMLResult, error) {
+	p.calls++
+	if len(p.errs) > 0 {
+		err := p.errs[0]
+		p.errs = p.errs[1:]
+		return nil, err
+	}
+	return &domain.AMLResult{RiskScore: 10, RiskLevel: domain.RiskLevelLow, Categories: []string{}}, nil
+}
+
+func (p *flakyAMLProvider) Name() string {
+	return p.name
+}
+
+var testResilience = ResilienceConfig{
+	CallTimeout: time.Second,
+	MaxAttempts: 3,
+	BackoffBase: 100 * time.Millisecond,
+	BackoffMax:  time.Second,
+}
+
+func newTestResilientAMLProvider(upstream domain.AMLProvider, breaker *CircuitBreaker, fallback domain.AMLProvider) (*ResilientAMLProvider, *[]time.Duration) {
+	provider := NewResilientAMLProvider(upstream, breaker, testResilience, fallback, zap.NewNop().Sugar())
+
+	var sleeps []time.Duration
+	provider.policy.sleep = func(ctx context.Context, d time.Duration) error {
+		sleeps = append(sleeps, d)
+		return nil
+	}
+	return provider, &sleeps
+}
+
+func TestResilientAMLProviderRetriesTransientErrors(t *testing.T) {
+	upstream := &flakyAMLProvider{name: "AMLBot", errs: []error{
+		&StatusError{Provider: "amlbot", StatusCode: http.StatusBadGateway},
+		context.DeadlineExceeded,
+	}}
+	provider, sleeps := newTestResilientAMLProvider(upstream, NewCircuitBreaker("AMLBot", 5, time.Minute), nil)
+
+	result, err := provider.CheckAddress(context.Background(), "address", "BTC", "bitcoin")
+	if err != nil {
+		t.Fatalf("CheckAddress() error = %v", err)
+	}
+	if result.RiskScore != 10 || upstream.calls != 3 {
+		t.Errorf("result = %+v after %d calls, want the third attempt's answer", result, upstream.calls)
+	}
+
+	if len(*sleeps) != 2 {
+		t.Fatalf("slept %d times, want 2", len(*sleeps))
+	}
+	for i, max := range []time.Duration{100 * time.Millisecond, 200 * time.Millisecond} {
+		if d := (*sleeps)[i]; d < max/2 || d > max {
+			t.Errorf("backoff %d = %v, want within [%v, %v]", i+1, d, max/2, max)
+		}
+	}
+}
+
+func TestResilientAMLProviderDoesNotRetryClientErrors(t *testing.T) {
+	upstream := &flakyAMLProvider{name: "AMLBot", errs: []error{&StatusError{Provider: "amlbot", StatusCode: http.StatusBadRequest}}}
+	breaker := NewCircuitBreaker("AMLBot", 1, time.Minute)
+	provider, _ := newTestResilientAMLProvider(upstream, breaker, nil)
+
+	if _, err := provider.CheckAddress(context.Background(), "address", "BTC", "bitcoin"); err == nil {
+		t.Fatal("CheckAddress() want error")
+	}
+	if upstream.calls != 1 {
+		t.Errorf("provider called %d times, want 1", upstream.calls)
+	}
+	if breaker.Status().State != BreakerClosed {
+		t.Errorf("a 400 opened the breaker")
+	}
+}
+
+func TestResilientAMLProviderFailsFastWhenOpen(t *testing.T) {
+	unavailable := &StatusError{Provider: "amlbot", StatusCode: http.StatusServiceUnavailable}
+	upstream := &flakyAMLProvider{name: "AMLBot", errs: []error{unavailable, unavailable, unavailable, unavailable}}
+	breaker := NewCircuitBreaker("AMLBot", 2, time.Minute)
+	provider, _ := newTestResilientAMLProvider(upstream, breaker, nil)
+
+	// the breaker opens on the second attempt and stops the retries
+	_, err := provider.CheckAddress(context.Background(), "address", "BTC", "bitcoin")
+	if !errors.Is(err, ErrCircuitOpen) || upstream.calls != 2 {
+		t.Fatalf("CheckAddress() = %v after %d calls, want ErrCircuitOpen after 2", err, upstream.calls)
+	}
+
+	if _, err := provider.CheckAddress(context.Background(), "address", "BTC", "bitcoin"); !errors.Is(err, ErrCircuitOpen) {
+		t.Errorf("CheckAddress() while open = %v, want ErrCircuitOpen", err)
+	}
+	if upstream.calls != 2 {
+		t.Errorf("provider called while the breaker was open")
+	}
+}
+
+func TestResilientAMLProviderFallback(t *testing.T) {
+	breaker := NewCircuitBreaker("AMLBot", 1, time.Minute)
+	breaker.Failure()
+
+	upstream := &flakyAMLProvider{name: "AMLBot"}
+	fallback := &stubAMLProvider{name: "Mock", result: &domain.AMLResult{RiskScore: 40, RiskLevel: domain.RiskLevelMedium, Categories: []string{"Exchange"}}}
+	provider, _ := newTestResilientAMLProvider(upstream, breaker, fallback)
+
+	result, err := provider.CheckAddress(context.Background(), "address", "BTC", "bitcoin")
+	if err != nil {
+		t.Fatalf("CheckAddress() error = %v", err)
+	}
+	if result.RiskScore != 40 {
+		t.Errorf("risk score = %d, want the fallback's", result.RiskScore)
+	}
+	if len(result.Providers) != 2 || result.Providers[0].Provider != "AMLBot" || result.Providers[0].Error == "" || result.Providers[1].Provider != "Mock" {
+		t.Errorf("breakdown = %+v, want the failed primary and the fallback", result.Providers)
+	}
+	if !result.Degraded {
+		t.Errorf("fallback result is not marked degraded")
+	}
+	if fallback.result.Providers != nil || fallback.result.Degraded {
+		t.Errorf("fallback's own result was modified")
+	}
+}
+
+func TestResilientAMLProviderCallTimeout(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		<-r.Context().Done()
+	}))
+	defer server.Close()
+
+	cfg := testResilience
+	cfg.CallTimeout = 50 * time.Millisecond
+	cfg.MaxAttempts = 2
+	upstream := NewAMLBotProvider(server.URL, "key", zap.NewNop().Sugar())
+	provider := NewResilientAMLProvider(upstream, NewCircuitBreaker("AMLBot", 5, time.Minute), cfg, nil, zap.NewNop().Sugar())
+	provider.policy.sleep = func(ctx context.Context, d time.Duration) error { return nil }
+
+	start := time.Now()
+	if _, err := provider.CheckAddress(context.Background(), "address", "BTC", "bitcoin"); err == nil {
+		t.Fatal("CheckAddress() want error")
+	}
+	if elapsed := time.Since(start); elapsed > time.Second {
+		t.Errorf("took %v, want each attempt cut off after 50ms", elapsed)
+	}
+	if status := provider.policy.breaker.Status(); status.ConsecutiveFailures != 2 {
+		t.Errorf("breaker failures = %d, want both timeouts counted", status.ConsecutiveFailures)
+	}
+}
@@ -0,0 +1,91 @@
+package redis
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	goredis "github.com/redis/go-redis/v9"
+)
+
+// returned when a key is missing
+var ErrNil = goredis.Nil
+
+type Config struct {
+	Addr     string
+	Password string
+	DB       int
+	// bounds dialing and every command without a context deadline
+	Timeout  time.Duration
+	PoolSize int
+}
+
+// the few commands the cache and the rate limiter need, on top of go-redis,
+// connections are dialed lazily so the API starts while redis is down
+type Client struct {
+	rdb     *goredis.Client
+	scripts sync.Map
+}
+
+func NewClient(cfg Config) *Client {
+	if cfg.Timeout <= 0 {
+		cfg.Timeout = time.Second
+	}
+	if cfg.PoolSize <= 0 {
+		cfg.PoolSize = 10
+	}
+	return &Client{
+		rdb: goredis.NewClient(&goredis.Options{
+			Addr:         cfg.Addr,
+			Password:     cfg.Password,
+			DB:           cfg.DB,
+			DialTimeout:  cfg.Timeout,
+			ReadTimeout:  cfg.Timeout,
+			WriteTimeout: cfg.Timeout,
+			PoolSize:     cfg.PoolSize,
+			// honour the caller's context deadline, e.g. RATE_LIMITER_REDIS_TIMEOUT_MS
+			ContextTimeoutEnabled: true,
+		}),
+	}
+}
+
+func (c *Client) Ping(ctx context.Context) error {
+	return c.rdb.Ping(ctx).Err()
+}
+
+// returns ErrNil when the key is missing
+func (c *Client) Get(ctx context.Context, key string) ([]byte, error) {
+	return c.rdb.Get(ctx, key).Bytes()
+}
+
+func (c *Client) Set(ctx context.Context, key string, value []byte, ttl time.Duration) error {
+	return c.rdb.Set(ctx, key, value, ttl).Err()
+}
+
+func (c *Client) Del(ctx context.Context, keys ...string) error {
+	return c.rdb.Del(ctx, keys...).Err()
+}
+
+// runs a Lua script through EVALSHA, loading it with EVAL when the server doesn't know it yet,
+// replies are int64, string or []any for arrays, a nil reply comes back as ErrNil
+func (c *Client) Eval(ctx context.Context, script string, keys []string, args ...string) (any, error) {
+	values := make([]any, len(args))
+	for i, arg := range args {
+		values[i] = arg
+	}
+
+	return c.script(script).Run(ctx, c.rdb, keys, values...).Result()
+}
+
+func (c *Client) Close() error {
+	return c.rdb.Close()
+}
+
+// scripts are hashed once, the rate limiter runs the same two on every request
+func (c *Client) script(source string) *goredis.Script {
+	if script, ok := c.scripts.Load(source); ok {
+		return script.(*goredis.Script)
+	}
+	script, _ := c.scripts.LoadOrStore(source, goredis.NewScript(source))
+	return script.(*goredis.Script)
+}
@@ -0,0 +1,116 @@
+package redis
+
+import (
+	"context"
+	"errors"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/alicebob/miniredis/v2"
+)
+
+func newTestClient(t *testing.T, cfg Config) (*Client, *miniredis.Miniredis) {
+	t.Helper()
+
+	server := miniredis.RunT(t)
+	cfg.Addr = server.Addr()
+	client := NewClient(cfg)
+	t.Cleanup(func() { client.Close() })
+	return client, server
+}
+
+func TestClientGetSet(t *testing.T) {
+	client, server := newTestClient(t, Config{})
+	ctx := context.Background()
+
+	if err := client.Ping(ctx); err != nil {
+		t.Fatalf("Ping() error = %v", err)
+	}
+
+	if _, err := client.Get(ctx, "missing"); !errors.Is(err, ErrNil) {
+		t.Errorf("Get() missing error = %v, want ErrNil", err)
+	}
+
+	if err := client.Set(ctx, "greeting", []byte("hello"), time.Minute); err != nil {
+		t.Fatalf("Set() error = %v", err)
+	}
+	if value, err := client.Get(ctx, "greeting"); err != nil || string(value) != "hello" {
+		t.Errorf("Get() = %q, %v, want hello", value, err)
+	}
+	if ttl := server.TTL("greeting"); ttl != time.Minute {
+		t.Errorf("TTL = %v, want %v", ttl, time.Minute)
+	}
+
+	if err := client.Del(ctx, "greeting"); err != nil {
+		t.Fatalf("Del() error = %v", err)
+	}
+	if server.Exists("greeting") {
+		t.Error("key still exists after Del()")
+	}
+}
+
+func TestClientEval(t *testing.T) {
+	client, server := newTestClient(t, Config{})
+	ctx := context.Background()
+	script := "redis.call('SET', KEYS[1], ARGV[1]) return {redis.call('INCR', KEYS[1]), ARGV[1]}"
+
+	// the first call loads the script, the second runs it by hash
+	for _, want := range []int64{8, 8} {
+		reply, err := client.Eval(ctx, script, []string{"counter"}, "7")
+		if err != nil {
+			t.Fatalf("Eval() error = %v", err)
+		}
+		items, ok := reply.([]any)
+		if !ok || len(items) != 2 || items[0] != want || items[1] != "7" {
+			t.Errorf("Eval() = %#v, want [%d 7]", reply, want)
+		}
+	}
+
+	if value, _ := server.Get("counter"); value != "8" {
+		t.Errorf("counter = %q, want 8", value)
+	}
+
+	if _, err := client.Eval(ctx, "return nil", nil); !errors.Is(err, ErrNil) {
+		t.Errorf("Eval() nil reply error = %v, want ErrNil", err)
+	}
+	if _, err := client.Eval(ctx, "return redis.error_reply('boom')", nil); err == nil {
+		t.Error("Eval() error reply want error")
+	}
+}
+
+func TestClientAuthenticates(t *testing.T) {
+	server := miniredis.RunT(t)
+	server.RequireAuth("secret")
+
+	client := NewClient(Config{Addr: server.Addr(), Password: "secret", DB: 2})
+	defer client.Close()
+	if err := client.Set(context.Background(), "a", []byte("1"), time.Minute); err != nil {
+		t.Fatalf("Set() error = %v", err)
+	}
+	server.Select(2)
+	if !server.Exists("a") {
+		t.Error("key was not written to db 2")
+	}
+
+	wrong := NewClient(Config{Addr: server.Addr(), Password: "wrong"})
+	defer wrong.Close()
+	if err := wrong.Ping(context.Background()); err == nil {
+		t.Error("Ping() with a wrong password want error")
+	}
+}
+
+func TestClientUnreachable(t *testing.T) {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("failed to listen: %v", err)
+	}
+	addr := listener.Addr().String()
+	listener.Close()
+
+	client := NewClient(Config{Addr: addr, Timeout: 100 * time.Millisecond})
+	defer client.Close()
+	if err := client.Ping(context.Background()); err == nil {
+		t.Error("Ping() against a closed port want error")
+	}
+}
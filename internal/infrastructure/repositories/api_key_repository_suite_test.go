@@ -0,0 +1,92 @@
+package repositories
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/Beka01247/bitpanda-aml/internal/domain"
+	"github.com/google/uuid"
+)
+
+// shared behaviour every domain.APIKeyRepository implementation must satisfy
+
+func testAPIKeyRepositoryLifecycle(t *testing.T, repo domain.APIKeyRepository) {
+	ctx := context.Background()
+
+	key, secret, err := domain.NewAPIKey("acme", "backend", "https://client.example/hook", []domain.Role{domain.RoleAnalyst, domain.RoleAuditor})
+	if err != nil {
+		t.Fatalf("NewAPIKey() error = %v", err)
+	}
+	if err := repo.Create(ctx, key); err != nil {
+		t.Fatalf("Create() error = %v", err)
+	}
+
+	got, err := repo.GetByHash(ctx, domain.HashAPIKey(secret))
+	if err != nil {
+		t.Fatalf("GetByHash() error = %v", err)
+	}
+	if got == nil || got.ID != key.ID || got.TenantID != "acme" || got.CallbackURL != "https://client.example/hook" || len(got.Roles) != 2 {
+		t.Fatalf("GetByHash() = %+v", got)
+	}
+
+	got.ExpireAt(time.Now().UTC().Add(time.Hour))
+	got.Revoke()
+	if err := repo.Update(ctx, got); err != nil {
+		t.Fatalf("Update() error = %v", err)
+	}
+
+	got, err = repo.Get(ctx, key.ID)
+	if err != nil {
+		t.Fatalf("Get() error = %v", err)
+	}
+	if got == nil || got.RevokedAt == nil || got.ExpiresAt == nil {
+		t.Fatalf("Get() = %+v, want revoked and expiring", got)
+	}
+
+	missing, err := repo.Get(ctx, uuid.New().String())
+	if err != nil || missing != nil {
+		t.Errorf("Get() missing = %+v, %v, want nil, nil", missing, err)
+	}
+
+	missing, err = repo.GetByHash(ctx, domain.HashAPIKey("unknown"))
+	if err != nil || missing != nil {
+		t.Errorf("GetByHash() missing = %+v, %v, want nil, nil", missing, err)
+	}
+
+	unknown, _, _ := domain.NewAPIKey("acme", "unknown", "", nil)
+	if err := repo.Update(ctx, unknown); !errors.Is(err, domain.ErrAPIKeyNotFound) {
+		t.Errorf("Update() unknown error = %v, want %v", err, domain.ErrAPIKeyNotFound)
+	}
+}
+
+func testAPIKeyRepositoryList(t *testing.T, repo domain.APIKeyRepository) {
+	ctx := context.Background()
+
+	for _, tenantID := range []string{"acme", "acme", "globex"} {
+		key, _, err := domain.NewAPIKey(tenantID, "key", "", nil)
+		if err != nil {
+			t.Fatalf("NewAPIKey() error = %v", err)
+		}
+		if err := repo.Create(ctx, key); err != nil {
+			t.Fatalf("Create() error = %v", err)
+		}
+	}
+
+	all, err := repo.List(ctx, domain.APIKeyFilter{})
+	if err != nil {
+		t.Fatalf("List() error = %v", err)
+	}
+	if len(all) != 3 {
+		t.Errorf("List() = %d keys, want 3", len(all))
+	}
+
+	acme, err := repo.List(ctx, domain.APIKeyFilter{TenantID: "acme"})
+	if err != nil {
+		t.Fatalf("List() error = %v", err)
+	}
+	if len(acme) != 2 {
+		t.Errorf("List(acme) = %d keys, want 2", len(acme))
+	}
+}
@@ -0,0 +1,96 @@
+package repositories
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/Beka01247/bitpanda-aml/internal/domain"
+)
+
+// shared behaviour every domain.AuditLog implementation must satisfy
+
+func testAuditLogChain(t *testing.T, auditLog domain.AuditLog) {
+	ctx := context.Background()
+
+	for _, checkID := range []string{"check-1", "check-2", "check-1"} {
+		entry := domain.NewAuditEntry(domain.AuditCheckCreated, "key", "acme", checkID, "address-"+checkID, map[string]string{"currency": "BTC"})
+		if err := auditLog.Append(ctx, entry); err != nil {
+			t.Fatalf("Append() error = %v", err)
+		}
+	}
+
+	entries, err := auditLog.ListAfter(ctx, 0, 10)
+	if err != nil {
+		t.Fatalf("ListAfter() error = %v", err)
+	}
+	if len(entries) != 3 {
+		t.Fatalf("ListAfter() = %d entries, want 3", len(entries))
+	}
+	if err := domain.VerifyAuditChain(nil, entries); err != nil {
+		t.Errorf("VerifyAuditChain() error = %v", err)
+	}
+
+	rest, err := auditLog.ListAfter(ctx, 1, 1)
+	if err != nil {
+		t.Fatalf("ListAfter() error = %v", err)
+	}
+	if len(rest) != 1 || rest[0].Sequence != 2 {
+		t.Errorf("ListAfter(1, 1) = %+v, want sequence 2", rest)
+	}
+}
+
+func testAuditLogList(t *testing.T, auditLog domain.AuditLog) {
+	ctx := context.Background()
+
+	for _, checkID := range []string{"check-1", "check-2", "check-1"} {
+		entry := domain.NewAuditEntry(domain.AuditCheckCreated, "key", "acme", checkID, "address-"+checkID, nil)
+		if err := auditLog.Append(ctx, entry); err != nil {
+			t.Fatalf("Append() error = %v", err)
+		}
+	}
+	if err := auditLog.Append(ctx, domain.NewAuditEntry(domain.AuditCheckCreated, "key", "globex", "check-3", "address-check-3", nil)); err != nil {
+		t.Fatalf("Append() error = %v", err)
+	}
+
+	byCheck, err := auditLog.List(ctx, domain.AuditFilter{CheckID: "check-1"})
+	if err != nil {
+		t.Fatalf("List() error = %v", err)
+	}
+	if len(byCheck) != 2 || byCheck[0].Sequence > byCheck[1].Sequence {
+		t.Errorf("List(check-1) = %+v, want 2 entries in sequence order", byCheck)
+	}
+
+	byAddress, err := auditLog.List(ctx, domain.AuditFilter{Address: "address-check-2"})
+	if err != nil {
+		t.Fatalf("List() error = %v", err)
+	}
+	if len(byAddress) != 1 {
+		t.Errorf("List(address) = %d entries, want 1", len(byAddress))
+	}
+
+	byTenant, err := auditLog.List(ctx, domain.AuditFilter{TenantID: "globex"})
+	if err != nil {
+		t.Fatalf("List() error = %v", err)
+	}
+	if len(byTenant) != 1 || byTenant[0].CheckID != "check-3" {
+		t.Errorf("List(tenant) = %+v, want only the globex entry", byTenant)
+	}
+
+	future := time.Now().UTC().Add(time.Hour)
+	later, err := auditLog.List(ctx, domain.AuditFilter{From: &future})
+	if err != nil {
+		t.Fatalf("List() error = %v", err)
+	}
+	if len(later) != 0 {
+		t.Errorf("List(from future) = %d entries, want 0", len(later))
+	}
+
+	limited, err := auditLog.List(ctx, domain.AuditFilter{Limit: 2})
+	if err != nil {
+		t.Fatalf("List() error = %v", err)
+	}
+	if len(limited) != 2 {
+		t.Errorf("List(limit 2) = %d entries, want 2", len(limited))
+	}
+}
@@ -0,0 +1,64 @@
+package repositories
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/Beka01247/bitpanda-aml/internal/domain"
+	"github.com/google/uuid"
+)
+
+// shared behaviour every domain.BatchRepository implementation must satisfy
+
+func testBatchRepositoryLifecycle(t *testing.T, repo domain.BatchRepository) {
+	ctx := context.Background()
+
+	batch := domain.NewBatch("acme", []domain.BatchItem{
+		{Address: "addr-1", Currency: "BTC", CheckID: uuid.New().String()},
+		{Address: "bad", Currency: "BTC", Error: "invalid address"},
+	}, time.Hour)
+	if err := repo.Create(ctx, batch); err != nil {
+		t.Fatalf("Create() error = %v", err)
+	}
+
+	got, err := repo.Get(ctx, batch.ID)
+	if err != nil {
+		t.Fatalf("Get() error = %v", err)
+	}
+	if got == nil || got.TenantID != "acme" || len(got.Items) != 2 || got.Items[1].Error != "invalid address" {
+		t.Fatalf("Get() = %+v", got)
+	}
+	if checkIDs := got.CheckIDs(); len(checkIDs) != 1 || checkIDs[0] != batch.Items[0].CheckID {
+		t.Errorf("CheckIDs() = %v", checkIDs)
+	}
+
+	missing, err := repo.Get(ctx, uuid.New().String())
+	if err != nil || missing != nil {
+		t.Errorf("Get() missing = %+v, %v, want nil, nil", missing, err)
+	}
+}
+
+func testBatchRepositoryCleanupExpired(t *testing.T, repo domain.BatchRepository) {
+	ctx := context.Background()
+
+	expired := domain.NewBatch("acme", []domain.BatchItem{}, -time.Minute)
+	active := domain.NewBatch("acme", []domain.BatchItem{}, time.Hour)
+	for _, batch := range []*domain.Batch{expired, active} {
+		if err := repo.Create(ctx, batch); err != nil {
+			t.Fatalf("Create() error = %v", err)
+		}
+	}
+
+	count, err := repo.CleanupExpired(ctx, time.Now().UTC())
+	if err != nil {
+		t.Fatalf("CleanupExpired() error = %v", err)
+	}
+	if count != 1 {
+		t.Errorf("CleanupExpired() = %d, want 1", count)
+	}
+
+	if got, _ := repo.Get(ctx, active.ID); got == nil {
+		t.Error("active batch was cleaned up")
+	}
+}
@@ -0,0 +1,138 @@
+package repositories
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/Beka01247/bitpanda-aml/internal/domain"
+)
+
+// shared behaviour every domain.ReviewCaseRepository implementation must satisfy
+
+func newTestReviewCase(riskLevel domain.RiskLevel) *domain.ReviewCase {
+	check := domain.NewAMLCheck("test-address", "BTC", time.Hour)
+	check.RiskLevel = riskLevel
+	return domain.NewReviewCase(check, domain.ReviewReasons(check))
+}
+
+func testCaseRepositoryLifecycle(t *testing.T, repo domain.ReviewCaseRepository, outbox domain.Outbox) {
+	ctx := context.Background()
+
+	reviewCase := newTestReviewCase(domain.RiskLevelCritical)
+	if err := repo.Create(ctx, reviewCase); err != nil {
+		t.Fatalf("Create() error = %v", err)
+	}
+
+	duplicate := domain.NewReviewCase(&domain.AMLCheck{ID: reviewCase.CheckID}, nil)
+	if err := repo.Create(ctx, duplicate); !errors.Is(err, domain.ErrCaseExists) {
+		t.Errorf("Create() for same check error = %v, want %v", err, domain.ErrCaseExists)
+	}
+
+	got, err := repo.Get(ctx, reviewCase.ID)
+	if err != nil {
+		t.Fatalf("Get() error = %v", err)
+	}
+	if got == nil || got.CheckID != reviewCase.CheckID || len(got.History) != 1 {
+		t.Fatalf("Get() = %+v", got)
+	}
+
+	if err := got.Claim("alice"); err != nil {
+		t.Fatalf("Claim() error = %v", err)
+	}
+	if err := repo.Update(ctx, got); err != nil {
+		t.Fatalf("Update() error = %v", err)
+	}
+
+	// a stale copy must not overwrite the claim
+	if err := reviewCase.Claim("bob"); err != nil {
+		t.Fatalf("Claim() error = %v", err)
+	}
+	if err := repo.Update(ctx, reviewCase); !errors.Is(err, domain.ErrCaseConflict) {
+		t.Errorf("Update() stale error = %v, want %v", err, domain.ErrCaseConflict)
+	}
+
+	if err := got.Resolve("alice", domain.DispositionCleared, "false positive"); err != nil {
+		t.Fatalf("Resolve() error = %v", err)
+	}
+	event := domain.NewEvent(domain.EventAMLCaseClosed, &domain.AMLCaseClosedPayload{CaseID: got.ID})
+	if err := repo.UpdateWithEvents(ctx, got, domain.NewOutboxMessage(domain.EventAMLCaseClosed, event)); err != nil {
+		t.Fatalf("UpdateWithEvents() error = %v", err)
+	}
+
+	closed, err := repo.Get(ctx, reviewCase.ID)
+	if err != nil {
+		t.Fatalf("Get() error = %v", err)
+	}
+	if closed.Status != domain.CaseStatusClosed || closed.Assignee != "alice" || closed.ClosedAt == nil {
+		t.Errorf("Get() after resolve = %+v", closed)
+	}
+	if len(closed.History) != 3 {
+		t.Errorf("Get() History length = %v, want 3", len(closed.History))
+	}
+
+	pending, err := outbox.ClaimPending(ctx, 10, time.Minute)
+	if err != nil {
+		t.Fatalf("ClaimPending() error = %v", err)
+	}
+	if len(pending) != 1 || pending[0].RoutingKey != domain.EventAMLCaseClosed {
+		t.Errorf("ClaimPending() = %v, want one %s message", pending, domain.EventAMLCaseClosed)
+	}
+
+	missing := newTestReviewCase(domain.RiskLevelHigh)
+	if err := repo.Update(ctx, missing); !errors.Is(err, domain.ErrCaseNotFound) {
+		t.Errorf("Update() missing error = %v, want %v", err, domain.ErrCaseNotFound)
+	}
+}
+
+func testCaseRepositoryList(t *testing.T, repo domain.ReviewCaseRepository) {
+	ctx := context.Background()
+
+	first := newTestReviewCase(domain.RiskLevelHigh)
+	second := newTestReviewCase(domain.RiskLevelCritical)
+	second.CreatedAt = first.CreatedAt.Add(time.Millisecond)
+	first.TenantID, second.TenantID = "acme", "globex"
+
+	for _, reviewCase := range []*domain.ReviewCase{first, second} {
+		if err := repo.Create(ctx, reviewCase); err != nil {
+			t.Fatalf("Create() error = %v", err)
+		}
+	}
+
+	if err := second.Claim("alice"); err != nil {
+		t.Fatalf("Claim() error = %v", err)
+	}
+	if err := repo.Update(ctx, second); err != nil {
+		t.Fatalf("Update() error = %v", err)
+	}
+
+	tests := []struct {
+		name   string
+		filter domain.CaseFilter
+		want   []string
+	}{
+		{name: "all oldest first", filter: domain.CaseFilter{}, want: []string{first.ID, second.ID}},
+		{name: "by status", filter: domain.CaseFilter{Status: domain.CaseStatusOpen}, want: []string{first.ID}},
+		{name: "by assignee", filter: domain.CaseFilter{Assignee: "alice"}, want: []string{second.ID}},
+		{name: "by check", filter: domain.CaseFilter{CheckID: first.CheckID}, want: []string{first.ID}},
+		{name: "by tenant", filter: domain.CaseFilter{TenantID: "globex"}, want: []string{second.ID}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cases, err := repo.List(ctx, tt.filter)
+			if err != nil {
+				t.Fatalf("List() error = %v", err)
+			}
+			if len(cases) != len(tt.want) {
+				t.Fatalf("List() length = %v, want %v", len(cases), len(tt.want))
+			}
+			for i, id := range tt.want {
+				if cases[i].ID != id {
+					t.Errorf("List()[%d] = %v, want %v", i, cases[i].ID, id)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,158 @@
+package repositories
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/Beka01247/bitpanda-aml/internal/domain"
+)
+
+// shared behaviour every domain.AMLCheckRepository implementation must satisfy
+
+func testCheckRepositoryCleanupExpired(t *testing.T, repo domain.AMLCheckRepository) {
+	ctx := context.Background()
+
+	// create checks with different TTLs
+	check1 := domain.NewAMLCheck("address1", "BTC", -1*time.Hour)    // Already expired
+	check2 := domain.NewAMLCheck("address2", "ETH", time.Hour)       // Not expired
+	check3 := domain.NewAMLCheck("address3", "USDT", -1*time.Minute) // Already expired
+
+	if err := repo.Create(ctx, check1); err != nil {
+		t.Fatalf("Create() error = %v", err)
+	}
+	if err := repo.Create(ctx, check2); err != nil {
+		t.Fatalf("Create() error = %v", err)
+	}
+	if err := repo.Create(ctx, check3); err != nil {
+		t.Fatalf("Create() error = %v", err)
+	}
+
+	// run cleanup
+	count, err := repo.CleanupExpired(ctx, time.Now().UTC())
+	if err != nil {
+		t.Fatalf("CleanupExpired() error = %v", err)
+	}
+
+	if count != 2 {
+		t.Errorf("CleanupExpired() count = %v, want 2", count)
+	}
+
+	// verify expired checks are removed
+	if check, _ := repo.Get(ctx, check1.ID); check != nil {
+		t.Error("Expired check1 should be removed")
+	}
+	if check, _ := repo.Get(ctx, check3.ID); check != nil {
+		t.Error("Expired check3 should be removed")
+	}
+
+	// verify non-expired check still exists
+	check, err := repo.Get(ctx, check2.ID)
+	if err != nil {
+		t.Errorf("Get() error = %v", err)
+	}
+	if check == nil {
+		t.Error("Non-expired check2 should still exist")
+	}
+}
+
+func testCheckRepositoryCRUD(t *testing.T, repo domain.AMLCheckRepository) {
+	ctx := context.Background()
+
+	t.Run("create and get", func(t *testing.T) {
+		check := domain.NewAMLCheck("test-address", "USDT", time.Hour)
+		check.Chain = "tron"
+		check.Tag = "12345"
+		check.NetworkType = domain.NetworkTestnet
+
+		err := repo.Create(ctx, check)
+		if err != nil {
+			t.Fatalf("Create() error = %v", err)
+		}
+
+		retrieved, err := repo.Get(ctx, check.ID)
+		if err != nil {
+			t.Fatalf("Get() error = %v", err)
+		}
+
+		if retrieved.ID != check.ID {
+			t.Errorf("Get() ID = %v, want %v", retrieved.ID, check.ID)
+		}
+
+		if retrieved.Chain != "tron" {
+			t.Errorf("Get() Chain = %v, want tron", retrieved.Chain)
+		}
+
+		if retrieved.Tag != "12345" {
+			t.Errorf("Get() Tag = %v, want 12345", retrieved.Tag)
+		}
+
+		if retrieved.NetworkType != domain.NetworkTestnet {
+			t.Errorf("Get() NetworkType = %v, want testnet", retrieved.NetworkType)
+		}
+	})
+
+	t.Run("duplicate create", func(t *testing.T) {
+		check := domain.NewAMLCheck("test-address", "BTC", time.Hour)
+
+		err := repo.Create(ctx, check)
+		if err != nil {
+			t.Fatalf("Create() error = %v", err)
+		}
+
+		err = repo.Create(ctx, check)
+		if err == nil {
+			t.Error("Create() should return error for duplicate")
+		}
+	})
+
+	t.Run("update", func(t *testing.T) {
+		check := domain.NewAMLCheck("test-address", "BTC", time.Hour)
+
+		err := repo.Create(ctx, check)
+		if err != nil {
+			t.Fatalf("Create() error = %v", err)
+		}
+
+		check.MarkCompleted(75, domain.RiskLevelHigh, []string{"Test"}, &domain.SanctionsResult{Hit: false, Identifications: []domain.SanctionsIdentification{}}, "report.pdf")
+		check.Decision = domain.DecisionReview
+		check.MatchedRules = []domain.MatchedRule{{ID: "high-risk", Decision: domain.DecisionReview}}
+		check.ProviderStatuses = []domain.ProviderStatus{{Provider: "Chainalysis", Kind: domain.ProviderKindSanctions, Status: domain.ProviderCallUnavailable, Error: "timeout"}}
+
+		err = repo.Update(ctx, check)
+		if err != nil {
+			t.Fatalf("Update() error = %v", err)
+		}
+
+		retrieved, err := repo.Get(ctx, check.ID)
+		if err != nil {
+			t.Fatalf("Get() error = %v", err)
+		}
+
+		if retrieved.Status != domain.StatusCompleted {
+			t.Errorf("Get() Status = %v, want %v", retrieved.Status, domain.StatusCompleted)
+		}
+
+		if retrieved.Decision != domain.DecisionReview {
+			t.Errorf("Get() Decision = %v, want %v", retrieved.Decision, domain.DecisionReview)
+		}
+
+		if len(retrieved.MatchedRules) != 1 || retrieved.MatchedRules[0].ID != "high-risk" {
+			t.Errorf("Get() MatchedRules = %v, want [high-risk]", retrieved.MatchedRules)
+		}
+
+		if len(retrieved.ProviderStatuses) != 1 || retrieved.ProviderStatuses[0].Status != domain.ProviderCallUnavailable {
+			t.Errorf("Get() ProviderStatuses = %v, want one unavailable", retrieved.ProviderStatuses)
+		}
+	})
+
+	t.Run("get not found", func(t *testing.T) {
+		check, err := repo.Get(ctx, "non-existent")
+		if err != nil {
+			t.Errorf("Get() error = %v", err)
+		}
+		if check != nil {
+			t.Error("Get() should return nil for non-existent ID")
+		}
+	})
+}
@@ -0,0 +1,103 @@
+package repositories
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"sync"
+
+	"github.com/Beka01247/bitpanda-aml/internal/domain"
+	"go.uber.org/zap"
+)
+
+type MemoryAPIKeyRepository struct {
+	keys   map[string]*domain.APIKey
+	mu     sync.RWMutex
+	logger *zap.SugaredLogger
+}
+
+func NewMemoryAPIKeyRepository(logger *zap.SugaredLogger) *MemoryAPIKeyRepository {
+	return &MemoryAPIKeyRepository{
+		keys:   make(map[string]*domain.APIKey),
+		logger: logger,
+	}
+}
+
+func (r *MemoryAPIKeyRepository) Create(ctx context.Context, key *domain.APIKey) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for _, existing := range r.keys {
+		if existing.ID == key.ID || existing.Hash == key.Hash {
+			return fmt.Errorf("api key already exists")
+		}
+	}
+
+	r.keys[key.ID] = cloneAPIKey(key)
+	r.logger.Debugw("api key created", "key_id", key.ID, "tenant_id", key.TenantID)
+
+	return nil
+}
+
+func (r *MemoryAPIKeyRepository) Get(ctx context.Context, keyID string) (*domain.APIKey, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	key, exists := r.keys[keyID]
+	if !exists {
+		return nil, nil
+	}
+
+	return cloneAPIKey(key), nil
+}
+
+func (r *MemoryAPIKeyRepository) GetByHash(ctx context.Context, hash string) (*domain.APIKey, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	for _, key := range r.keys {
+		if key.Hash == hash {
+			return cloneAPIKey(key), nil
+		}
+	}
+
+	return nil, nil
+}
+
+func (r *MemoryAPIKeyRepository) List(ctx context.Context, filter domain.APIKeyFilter) ([]*domain.APIKey, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	keys := make([]*domain.APIKey, 0)
+	for _, key := range r.keys {
+		if filter.Matches(key) {
+			keys = append(keys, cloneAPIKey(key))
+		}
+	}
+
+	sort.Slice(keys, func(i, j int) bool {
+		return keys[i].CreatedAt.Before(keys[j].CreatedAt)
+	})
+
+	return keys, nil
+}
+
+func (r *MemoryAPIKeyRepository) Update(ctx context.Context, key *domain.APIKey) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, exists := r.keys[key.ID]; !exists {
+		return domain.ErrAPIKeyNotFound
+	}
+
+	r.keys[key.ID] = cloneAPIKey(key)
+	r.logger.Debugw("api key updated", "key_id", key.ID, "tenant_id", key.TenantID)
+
+	return nil
+}
+
+func cloneAPIKey(key *domain.APIKey) *domain.APIKey {
+	clone := *key
+	clone.Roles = append([]domain.Role(nil), key.Roles...)
+	return &clone
+}
@@ -0,0 +1,15 @@
+package repositories
+
+import (
+	"testing"
+
+	"go.uber.org/zap"
+)
+
+func TestMemoryAPIKeyRepository_Lifecycle(t *testing.T) {
+	testAPIKeyRepositoryLifecycle(t, NewMemoryAPIKeyRepository(zap.NewNop().Sugar()))
+}
+
+func TestMemoryAPIKeyRepository_List(t *testing.T) {
+	testAPIKeyRepositoryList(t, NewMemoryAPIKeyRepository(zap.NewNop().Sugar()))
+}
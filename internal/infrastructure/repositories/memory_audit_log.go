@@ -0,0 +1,77 @@
+package repositories
+
+import (
+	"context"
+	"sync"
+
+	"github.com/Beka01247/bitpanda-aml/internal/domain"
+	"go.uber.org/zap"
+)
+
+type MemoryAuditLog struct {
+	entries []*domain.AuditEntry
+	mu      sync.RWMutex
+	logger  *zap.SugaredLogger
+}
+
+func NewMemoryAuditLog(logger *zap.SugaredLogger) *MemoryAuditLog {
+	return &MemoryAuditLog{
+		entries: make([]*domain.AuditEntry, 0),
+		logger:  logger,
+	}
+}
+
+func (l *MemoryAuditLog) Append(ctx context.Context, entry *domain.AuditEntry) error {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	prevHash := ""
+	if len(l.entries) > 0 {
+		prevHash = l.entries[len(l.entries)-1].Hash
+	}
+	entry.Seal(int64(len(l.entries))+1, prevHash)
+
+	l.entries = append(l.entries, cloneAuditEntry(entry))
+	l.logger.Debugw("audit entry appended", "sequence", entry.Sequence, "action", entry.Action, "check_id", entry.CheckID)
+
+	return nil
+}
+
+func (l *MemoryAuditLog) List(ctx context.Context, filter domain.AuditFilter) ([]*domain.AuditEntry, error) {
+	l.mu.RLock()
+	defer l.mu.RUnlock()
+
+	entries := make([]*domain.AuditEntry, 0)
+	for _, entry := range l.entries {
+		if filter.Limit > 0 && len(entries) == filter.Limit {
+			break
+		}
+		if filter.Matches(entry) {
+			entries = append(entries, cloneAuditEntry(entry))
+		}
+	}
+
+	return entries, nil
+}
+
+func (l *MemoryAuditLog) ListAfter(ctx context.Context, sequence int64, limit int) ([]*domain.AuditEntry, error) {
+	l.mu.RLock()
+	defer l.mu.RUnlock()
+
+	entries := make([]*domain.AuditEntry, 0, limit)
+	// sequences are 1-based positions in the slice
+	for i := max(sequence, 0); i < int64(len(l.entries)) && len(entries) < limit; i++ {
+		entries = append(entries, cloneAuditEntry(l.entries[i]))
+	}
+
+	return entries, nil
+}
+
+func cloneAuditEntry(entry *domain.AuditEntry) *domain.AuditEntry {
+	clone := *entry
+	clone.Details = make(map[string]string, len(entry.Details))
+	for key, value := range entry.Details {
+		clone.Details[key] = value
+	}
+	return &clone
+}
@@ -0,0 +1,15 @@
+package repositories
+
+import (
+	"testing"
+
+	"go.uber.org/zap"
+)
+
+func TestMemoryAuditLog_Chain(t *testing.T) {
+	testAuditLogChain(t, NewMemoryAuditLog(zap.NewNop().Sugar()))
+}
+
+func TestMemoryAuditLog_List(t *testing.T) {
+	testAuditLogList(t, NewMemoryAuditLog(zap.NewNop().Sugar()))
+}
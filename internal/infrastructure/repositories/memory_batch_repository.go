@@ -0,0 +1,90 @@
+package repositories
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/Beka01247/bitpanda-aml/internal/domain"
+	"go.uber.org/zap"
+)
+
+type MemoryBatchRepository struct {
+	batches map[string]*domain.Batch
+	mu      sync.RWMutex
+	logger  *zap.SugaredLogger
+}
+
+func NewMemoryBatchRepository(logger *zap.SugaredLogger) *MemoryBatchRepository {
+	return &MemoryBatchRepository{
+		batches: make(map[string]*domain.Batch),
+		logger:  logger,
+	}
+}
+
+func (r *MemoryBatchRepository) Create(ctx context.Context, batch *domain.Batch) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, exists := r.batches[batch.ID]; exists {
+		return fmt.Errorf("batch already exists")
+	}
+
+	r.batches[batch.ID] = batch
+	r.logger.Debugw("batch created", "batch_id", batch.ID, "items", len(batch.Items))
+
+	return nil
+}
+
+func (r *MemoryBatchRepository) Get(ctx context.Context, batchID string) (*domain.Batch, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	batch, exists := r.batches[batchID]
+	if !exists {
+		return nil, nil
+	}
+
+	return batch, nil
+}
+
+// removes expired batches
+func (r *MemoryBatchRepository) CleanupExpired(ctx context.Context, now time.Time) (int, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	count := 0
+	for id, batch := range r.batches {
+		if now.After(batch.ExpiresAt) {
+			delete(r.batches, id)
+			count++
+		}
+	}
+
+	if count > 0 {
+		r.logger.Infow("expired batches cleaned", "count", count)
+	}
+
+	return count, nil
+}
+
+// starts a background cleanup loop
+func (r *MemoryBatchRepository) StartCleanupLoop(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	go func() {
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ctx.Done():
+				r.logger.Info("batch cleanup loop stopped")
+				return
+			case <-ticker.C:
+				_, err := r.CleanupExpired(ctx, time.Now().UTC())
+				if err != nil {
+					r.logger.Errorw("batch cleanup failed", "error", err)
+				}
+			}
+		}
+	}()
+}
@@ -0,0 +1,15 @@
+package repositories
+
+import (
+	"testing"
+
+	"go.uber.org/zap"
+)
+
+func TestMemoryBatchRepository_Lifecycle(t *testing.T) {
+	testBatchRepositoryLifecycle(t, NewMemoryBatchRepository(zap.NewNop().Sugar()))
+}
+
+func TestMemoryBatchRepository_CleanupExpired(t *testing.T) {
+	testBatchRepositoryCleanupExpired(t, NewMemoryBatchRepository(zap.NewNop().Sugar()))
+}
@@ -0,0 +1,114 @@
+package repositories
+
+import (
+	"context"
+	"sort"
+	"sync"
+
+	"github.com/Beka01247/bitpanda-aml/internal/domain"
+	"go.uber.org/zap"
+)
+
+type MemoryCaseRepository struct {
+	cases  map[string]*domain.ReviewCase
+	outbox *MemoryOutbox
+	mu     sync.RWMutex
+	logger *zap.SugaredLogger
+}
+
+// events from UpdateWithEvents go to the given outbox, normally the check repository's
+func NewMemoryCaseRepository(outbox *MemoryOutbox, logger *zap.SugaredLogger) *MemoryCaseRepository {
+	return &MemoryCaseRepository{
+		cases:  make(map[string]*domain.ReviewCase),
+		outbox: outbox,
+		logger: logger,
+	}
+}
+
+func (r *MemoryCaseRepository) Create(ctx context.Context, reviewCase *domain.ReviewCase) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for _, existing := range r.cases {
+		if existing.ID == reviewCase.ID || existing.CheckID == reviewCase.CheckID {
+			return domain.ErrCaseExists
+		}
+	}
+
+	reviewCase.Version = 1
+	r.cases[reviewCase.ID] = cloneReviewCase(reviewCase)
+	r.logger.Debugw("case created", "case_id", reviewCase.ID, "check_id", reviewCase.CheckID)
+
+	return nil
+}
+
+func (r *MemoryCaseRepository) Get(ctx context.Context, caseID string) (*domain.ReviewCase, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	reviewCase, exists := r.cases[caseID]
+	if !exists {
+		return nil, nil
+	}
+
+	return cloneReviewCase(reviewCase), nil
+}
+
+func (r *MemoryCaseRepository) List(ctx context.Context, filter domain.CaseFilter) ([]*domain.ReviewCase, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	cases := make([]*domain.ReviewCase, 0)
+	for _, reviewCase := range r.cases {
+		if filter.Matches(reviewCase) {
+			cases = append(cases, cloneReviewCase(reviewCase))
+		}
+	}
+
+	sort.Slice(cases, func(i, j int) bool {
+		return cases[i].CreatedAt.Before(cases[j].CreatedAt)
+	})
+
+	return cases, nil
+}
+
+func (r *MemoryCaseRepository) Update(ctx context.Context, reviewCase *domain.ReviewCase) error {
+	return r.UpdateWithEvents(ctx, reviewCase)
+}
+
+func (r *MemoryCaseRepository) UpdateWithEvents(ctx context.Context, reviewCase *domain.ReviewCase, messages ...*domain.OutboxMessage) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	existing, exists := r.cases[reviewCase.ID]
+	if !exists {
+		return domain.ErrCaseNotFound
+	}
+
+	if existing.Version != reviewCase.Version {
+		return domain.ErrCaseConflict
+	}
+
+	if err := r.outbox.Enqueue(ctx, messages...); err != nil {
+		return err
+	}
+
+	reviewCase.Version++
+	r.cases[reviewCase.ID] = cloneReviewCase(reviewCase)
+	r.logger.Debugw("case updated", "case_id", reviewCase.ID, "status", reviewCase.Status, "events", len(messages))
+
+	return nil
+}
+
+// cases are stored by value so callers can't bypass the version check
+func cloneReviewCase(reviewCase *domain.ReviewCase) *domain.ReviewCase {
+	clone := *reviewCase
+	clone.Reasons = append([]string(nil), reviewCase.Reasons...)
+	clone.Notes = append([]domain.CaseNote(nil), reviewCase.Notes...)
+	clone.History = append([]domain.CaseHistoryEntry(nil), reviewCase.History...)
+	if reviewCase.ClosedAt != nil {
+		closedAt := *reviewCase.ClosedAt
+		clone.ClosedAt = &closedAt
+	}
+	return &clone
+}
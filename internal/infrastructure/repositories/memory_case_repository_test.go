@@ -0,0 +1,17 @@
+package repositories
+
+import (
+	"testing"
+
+	"go.uber.org/zap"
+)
+
+func TestMemoryCaseRepository_Lifecycle(t *testing.T) {
+	outbox := NewMemoryOutbox(zap.NewNop().Sugar())
+	testCaseRepositoryLifecycle(t, NewMemoryCaseRepository(outbox, zap.NewNop().Sugar()), outbox)
+}
+
+func TestMemoryCaseRepository_List(t *testing.T) {
+	outbox := NewMemoryOutbox(zap.NewNop().Sugar())
+	testCaseRepositoryList(t, NewMemoryCaseRepository(outbox, zap.NewNop().Sugar()))
+}
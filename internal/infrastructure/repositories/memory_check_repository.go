@@ -12,6 +12,7 @@ import (
 
 type MemoryCheckRepository struct {
 	checks map[string]*domain.AMLCheck
+	outbox *MemoryOutbox
 	mu     sync.RWMutex
 	logger *zap.SugaredLogger
 }
@@ -19,10 +20,16 @@ type MemoryCheckRepository struct {
 func NewMemoryCheckRepository(logger *zap.SugaredLogger) *MemoryCheckRepository {
 	return &MemoryCheckRepository{
 		checks: make(map[string]*domain.AMLCheck),
+		outbox: NewMemoryOutbox(logger),
 		logger: logger,
 	}
 }
 
+// returns the outbox that CreateWithEvents and UpdateWithEvents write to
+func (r *MemoryCheckRepository) Outbox() *MemoryOutbox {
+	return r.outbox
+}
+
 func (r *MemoryCheckRepository) Create(ctx context.Context, check *domain.AMLCheck) error {
 	r.mu.Lock()
 	defer r.mu.Unlock()
@@ -63,6 +70,42 @@ func (r *MemoryCheckRepository) Update(ctx context.Context, check *domain.AMLChe
 	return nil
 }
 
+func (r *MemoryCheckRepository) CreateWithEvents(ctx context.Context, check *domain.AMLCheck, messages ...*domain.OutboxMessage) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, exists := r.checks[check.ID]; exists {
+		return fmt.Errorf("check already exists")
+	}
+
+	if err := r.outbox.Enqueue(ctx, messages...); err != nil {
+		return err
+	}
+
+	r.checks[check.ID] = check
+	r.logger.Debugw("check created", "check_id", check.ID, "events", len(messages))
+
+	return nil
+}
+
+func (r *MemoryCheckRepository) UpdateWithEvents(ctx context.Context, check *domain.AMLCheck, messages ...*domain.OutboxMessage) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, exists := r.checks[check.ID]; !exists {
+		return fmt.Errorf("check not found")
+	}
+
+	if err := r.outbox.Enqueue(ctx, messages...); err != nil {
+		return err
+	}
+
+	r.checks[check.ID] = check
+	r.logger.Debugw("check updated", "check_id", check.ID, "status", check.Status, "events", len(messages))
+
+	return nil
+}
+
 // removes expired checks
 func (r *MemoryCheckRepository) CleanupExpired(ctx context.Context, now time.Time) (int, error) {
 	r.mu.Lock()
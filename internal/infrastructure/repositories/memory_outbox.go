@@ -0,0 +1,135 @@
+package repositories
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/Beka01247/bitpanda-aml/internal/domain"
+	"go.uber.org/zap"
+)
+
+type MemoryOutbox struct {
+	messages map[string]*domain.OutboxMessage
+	mu       sync.Mutex
+	logger   *zap.SugaredLogger
+}
+
+func NewMemoryOutbox(logger *zap.SugaredLogger) *MemoryOutbox {
+	return &MemoryOutbox{
+		messages: make(map[string]*domain.OutboxMessage),
+		logger:   logger,
+	}
+}
+
+func (o *MemoryOutbox) Enqueue(ctx context.Context, messages ...*domain.OutboxMessage) error {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+
+	for _, message := range messages {
+		if _, exists := o.messages[message.ID]; exists {
+			return fmt.Errorf("outbox message already exists")
+		}
+	}
+
+	for _, message := range messages {
+		o.messages[message.ID] = message
+		o.logger.Debugw("outbox message enqueued", "message_id", message.ID, "routing_key", message.RoutingKey)
+	}
+
+	return nil
+}
+
+func (o *MemoryOutbox) ClaimPending(ctx context.Context, limit int, lease time.Duration) ([]*domain.OutboxMessage, error) {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+
+	now := time.Now().UTC()
+
+	pending := make([]*domain.OutboxMessage, 0)
+	for _, message := range o.messages {
+		if message.IsSent() {
+			continue
+		}
+		if message.LockedUntil != nil && now.Before(*message.LockedUntil) {
+			continue
+		}
+		pending = append(pending, message)
+	}
+
+	sortOutboxMessages(pending)
+
+	if len(pending) > limit {
+		pending = pending[:limit]
+	}
+
+	lockedUntil := now.Add(lease)
+	claimed := make([]*domain.OutboxMessage, 0, len(pending))
+	for _, message := range pending {
+		message.LockedUntil = &lockedUntil
+		copied := *message
+		claimed = append(claimed, &copied)
+	}
+
+	return claimed, nil
+}
+
+func (o *MemoryOutbox) MarkSent(ctx context.Context, messageID string) error {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+
+	message, exists := o.messages[messageID]
+	if !exists {
+		return fmt.Errorf("outbox message not found")
+	}
+
+	sentAt := time.Now().UTC()
+	message.SentAt = &sentAt
+	message.LockedUntil = nil
+
+	return nil
+}
+
+func (o *MemoryOutbox) MarkFailed(ctx context.Context, messageID string, errorMessage string) error {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+
+	message, exists := o.messages[messageID]
+	if !exists {
+		return fmt.Errorf("outbox message not found")
+	}
+
+	// the lease is kept so the message is retried once it expires
+	message.Attempts++
+	message.LastError = errorMessage
+
+	return nil
+}
+
+func sortOutboxMessages(messages []*domain.OutboxMessage) {
+	sort.SliceStable(messages, func(i, j int) bool {
+		return messages[i].CreatedAt.Before(messages[j].CreatedAt)
+	})
+}
+
+// removes messages that were sent before the given time
+func (o *MemoryOutbox) CleanupSent(ctx context.Context, before time.Time) (int, error) {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+
+	count := 0
+	for id, message := range o.messages {
+		if message.IsSent() && message.SentAt.Before(before) {
+			delete(o.messages, id)
+			count++
+		}
+	}
+
+	if count > 0 {
+		o.logger.Infow("sent outbox messages cleaned", "count", count)
+	}
+
+	return count, nil
+}
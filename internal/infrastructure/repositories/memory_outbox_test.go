@@ -0,0 +1,15 @@
+package repositories
+
+import (
+	"testing"
+
+	"go.uber.org/zap"
+)
+
+func TestMemoryOutbox_Lifecycle(t *testing.T) {
+	testOutboxLifecycle(t, NewMemoryOutbox(zap.NewNop().Sugar()))
+}
+
+func TestMemoryOutbox_LeaseExpiry(t *testing.T) {
+	testOutboxLeaseExpiry(t, NewMemoryOutbox(zap.NewNop().Sugar()))
+}
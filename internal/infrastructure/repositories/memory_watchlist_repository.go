@@ -0,0 +1,114 @@
+package repositories
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/Beka01247/bitpanda-aml/internal/domain"
+	"go.uber.org/zap"
+)
+
+type MemoryWatchlistRepository struct {
+	entries map[string]*domain.WatchlistEntry
+	mu      sync.RWMutex
+	logger  *zap.SugaredLogger
+}
+
+func NewMemoryWatchlistRepository(logger *zap.SugaredLogger) *MemoryWatchlistRepository {
+	return &MemoryWatchlistRepository{
+		entries: make(map[string]*domain.WatchlistEntry),
+		logger:  logger,
+	}
+}
+
+func (r *MemoryWatchlistRepository) Create(ctx context.Context, entry *domain.WatchlistEntry) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, exists := r.entries[entry.ID]; exists {
+		return fmt.Errorf("watchlist entry already exists")
+	}
+
+	r.entries[entry.ID] = entry
+	r.logger.Debugw("watchlist entry created", "entry_id", entry.ID, "list_type", entry.ListType)
+
+	return nil
+}
+
+func (r *MemoryWatchlistRepository) Get(ctx context.Context, entryID string) (*domain.WatchlistEntry, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	entry, exists := r.entries[entryID]
+	if !exists {
+		return nil, nil
+	}
+
+	return entry, nil
+}
+
+func (r *MemoryWatchlistRepository) Update(ctx context.Context, entry *domain.WatchlistEntry) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, exists := r.entries[entry.ID]; !exists {
+		return domain.ErrWatchlistEntryNotFound
+	}
+
+	r.entries[entry.ID] = entry
+	r.logger.Debugw("watchlist entry updated", "entry_id", entry.ID)
+
+	return nil
+}
+
+func (r *MemoryWatchlistRepository) Delete(ctx context.Context, entryID string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, exists := r.entries[entryID]; !exists {
+		return domain.ErrWatchlistEntryNotFound
+	}
+
+	delete(r.entries, entryID)
+	r.logger.Debugw("watchlist entry deleted", "entry_id", entryID)
+
+	return nil
+}
+
+func (r *MemoryWatchlistRepository) List(ctx context.Context, filter domain.WatchlistFilter) ([]*domain.WatchlistEntry, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	entries := make([]*domain.WatchlistEntry, 0)
+	for _, entry := range r.entries {
+		if filter.Matches(entry) {
+			entries = append(entries, entry)
+		}
+	}
+
+	sort.Slice(entries, func(i, j int) bool {
+		return entries[i].CreatedAt.After(entries[j].CreatedAt)
+	})
+
+	return entries, nil
+}
+
+func (r *MemoryWatchlistRepository) Match(ctx context.Context, chain, address string, now time.Time) (*domain.WatchlistEntry, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	var match *domain.WatchlistEntry
+	for _, entry := range r.entries {
+		if entry.Chain != chain || entry.Address != address || entry.IsExpired(now) {
+			continue
+		}
+		if match == nil || entry.ListType == domain.WatchlistBlocklist {
+			match = entry
+		}
+	}
+
+	return match, nil
+}
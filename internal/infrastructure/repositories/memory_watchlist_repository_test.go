@@ -0,0 +1,15 @@
+package repositories
+
+import (
+	"testing"
+
+	"go.uber.org/zap"
+)
+
+func TestMemoryWatchlistRepository_CRUD(t *testing.T) {
+	testWatchlistRepositoryCRUD(t, NewMemoryWatchlistRepository(zap.NewNop().Sugar()))
+}
+
+func TestMemoryWatchlistRepository_ListAndMatch(t *testing.T) {
+	testWatchlistRepositoryListAndMatch(t, NewMemoryWatchlistRepository(zap.NewNop().Sugar()))
+}
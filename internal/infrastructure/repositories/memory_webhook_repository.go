@@ -0,0 +1,117 @@
+package repositories
+
+import (
+	"context"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/Beka01247/bitpanda-aml/internal/domain"
+	"go.uber.org/zap"
+)
+
+type MemoryWebhookRepository struct {
+	deliveries map[string]*domain.WebhookDelivery
+	mu         sync.Mutex
+	logger     *zap.SugaredLogger
+}
+
+func NewMemoryWebhookRepository(logger *zap.SugaredLogger) *MemoryWebhookRepository {
+	return &MemoryWebhookRepository{
+		deliveries: make(map[string]*domain.WebhookDelivery),
+		logger:     logger,
+	}
+}
+
+func (r *MemoryWebhookRepository) Create(ctx context.Context, delivery *domain.WebhookDelivery) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for _, existing := range r.deliveries {
+		if existing.ID == delivery.ID || (existing.CheckID == delivery.CheckID && existing.EventType == delivery.EventType) {
+			return domain.ErrWebhookDeliveryExists
+		}
+	}
+
+	copied := *delivery
+	r.deliveries[delivery.ID] = &copied
+	r.logger.Debugw("webhook delivery created", "delivery_id", delivery.ID, "check_id", delivery.CheckID)
+
+	return nil
+}
+
+func (r *MemoryWebhookRepository) Get(ctx context.Context, deliveryID string) (*domain.WebhookDelivery, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	delivery, exists := r.deliveries[deliveryID]
+	if !exists {
+		return nil, nil
+	}
+
+	copied := *delivery
+	return &copied, nil
+}
+
+func (r *MemoryWebhookRepository) List(ctx context.Context, filter domain.WebhookDeliveryFilter) ([]*domain.WebhookDelivery, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	deliveries := make([]*domain.WebhookDelivery, 0)
+	for _, delivery := range r.deliveries {
+		if filter.Matches(delivery) {
+			copied := *delivery
+			deliveries = append(deliveries, &copied)
+		}
+	}
+
+	sort.Slice(deliveries, func(i, j int) bool {
+		return deliveries[i].CreatedAt.After(deliveries[j].CreatedAt)
+	})
+
+	return deliveries, nil
+}
+
+func (r *MemoryWebhookRepository) Update(ctx context.Context, delivery *domain.WebhookDelivery) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, exists := r.deliveries[delivery.ID]; !exists {
+		return domain.ErrWebhookDeliveryNotFound
+	}
+
+	copied := *delivery
+	r.deliveries[delivery.ID] = &copied
+	r.logger.Debugw("webhook delivery updated", "delivery_id", delivery.ID, "status", delivery.Status)
+
+	return nil
+}
+
+func (r *MemoryWebhookRepository) ClaimDue(ctx context.Context, now time.Time, limit int, lease time.Duration) ([]*domain.WebhookDelivery, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	due := make([]*domain.WebhookDelivery, 0)
+	for _, delivery := range r.deliveries {
+		if delivery.Status == domain.WebhookDeliveryPending && !delivery.NextAttemptAt.After(now) {
+			due = append(due, delivery)
+		}
+	}
+
+	sort.Slice(due, func(i, j int) bool {
+		return due[i].NextAttemptAt.Before(due[j].NextAttemptAt)
+	})
+
+	if len(due) > limit {
+		due = due[:limit]
+	}
+
+	claimed := make([]*domain.WebhookDelivery, 0, len(due))
+	for _, delivery := range due {
+		delivery.NextAttemptAt = now.Add(lease)
+		copied := *delivery
+		claimed = append(claimed, &copied)
+	}
+
+	return claimed, nil
+}
@@ -0,0 +1,23 @@
+package repositories
+
+import (
+	"testing"
+
+	"go.uber.org/zap"
+)
+
+func TestMemoryWebhookRepository_Lifecycle(t *testing.T) {
+	testWebhookRepositoryLifecycle(t, NewMemoryWebhookRepository(zap.NewNop().Sugar()))
+}
+
+func TestMemoryWebhookRepository_CreateDuplicate(t *testing.T) {
+	testWebhookRepositoryCreateDuplicate(t, NewMemoryWebhookRepository(zap.NewNop().Sugar()))
+}
+
+func TestMemoryWebhookRepository_List(t *testing.T) {
+	testWebhookRepositoryList(t, NewMemoryWebhookRepository(zap.NewNop().Sugar()))
+}
+
+func TestMemoryWebhookRepository_ClaimDue(t *testing.T) {
+	testWebhookRepositoryClaimDue(t, NewMemoryWebhookRepository(zap.NewNop().Sugar()))
+}
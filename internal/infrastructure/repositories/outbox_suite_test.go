@@ -0,0 +1,159 @@
+package repositories
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/Beka01247/bitpanda-aml/internal/domain"
+)
+
+// shared behaviour every domain.Outbox implementation must satisfy
+
+func newTestOutboxMessage(checkID string) *domain.OutboxMessage {
+	event := domain.NewEvent(domain.EventAMLCheckRequested, &domain.AMLCheckRequestedPayload{
+		CheckID:  checkID,
+		Address:  "test-address",
+		Currency: "BTC",
+	})
+	return domain.NewOutboxMessage(domain.EventAMLCheckRequested, event)
+}
+
+func testOutboxLifecycle(t *testing.T, outbox domain.Outbox) {
+	ctx := context.Background()
+
+	first := newTestOutboxMessage("check-1")
+	second := newTestOutboxMessage("check-2")
+	second.CreatedAt = first.CreatedAt.Add(time.Millisecond)
+
+	if err := outbox.Enqueue(ctx, first, second); err != nil {
+		t.Fatalf("Enqueue() error = %v", err)
+	}
+
+	claimed, err := outbox.ClaimPending(ctx, 10, time.Minute)
+	if err != nil {
+		t.Fatalf("ClaimPending() error = %v", err)
+	}
+
+	if len(claimed) != 2 {
+		t.Fatalf("ClaimPending() length = %v, want 2", len(claimed))
+	}
+
+	if claimed[0].ID != first.ID || claimed[1].ID != second.ID {
+		t.Error("ClaimPending() should return oldest messages first")
+	}
+
+	if claimed[0].RoutingKey != domain.EventAMLCheckRequested {
+		t.Errorf("ClaimPending() RoutingKey = %v, want %v", claimed[0].RoutingKey, domain.EventAMLCheckRequested)
+	}
+
+	if claimed[0].Event == nil || claimed[0].Event.Type != domain.EventAMLCheckRequested {
+		t.Error("ClaimPending() Event not restored")
+	}
+
+	// leased messages are not handed out twice
+	again, err := outbox.ClaimPending(ctx, 10, time.Minute)
+	if err != nil {
+		t.Fatalf("ClaimPending() error = %v", err)
+	}
+	if len(again) != 0 {
+		t.Errorf("ClaimPending() while leased length = %v, want 0", len(again))
+	}
+
+	if err := outbox.MarkSent(ctx, first.ID); err != nil {
+		t.Fatalf("MarkSent() error = %v", err)
+	}
+	if err := outbox.MarkFailed(ctx, second.ID, "bus unavailable"); err != nil {
+		t.Fatalf("MarkFailed() error = %v", err)
+	}
+
+	if err := outbox.MarkSent(ctx, "00000000-0000-0000-0000-000000000000"); err == nil {
+		t.Error("MarkSent() should return error for unknown message")
+	}
+
+	// sent messages are cleaned up, pending ones are kept
+	count, err := outbox.CleanupSent(ctx, time.Now().UTC().Add(time.Minute))
+	if err != nil {
+		t.Fatalf("CleanupSent() error = %v", err)
+	}
+	if count != 1 {
+		t.Errorf("CleanupSent() count = %v, want 1", count)
+	}
+}
+
+func testOutboxLeaseExpiry(t *testing.T, outbox domain.Outbox) {
+	ctx := context.Background()
+
+	message := newTestOutboxMessage("check-lease")
+	if err := outbox.Enqueue(ctx, message); err != nil {
+		t.Fatalf("Enqueue() error = %v", err)
+	}
+
+	if _, err := outbox.ClaimPending(ctx, 10, -time.Second); err != nil {
+		t.Fatalf("ClaimPending() error = %v", err)
+	}
+
+	if err := outbox.MarkFailed(ctx, message.ID, "bus unavailable"); err != nil {
+		t.Fatalf("MarkFailed() error = %v", err)
+	}
+
+	// the lease has already expired so the message is retried
+	claimed, err := outbox.ClaimPending(ctx, 10, time.Minute)
+	if err != nil {
+		t.Fatalf("ClaimPending() error = %v", err)
+	}
+
+	if len(claimed) != 1 {
+		t.Fatalf("ClaimPending() length = %v, want 1", len(claimed))
+	}
+
+	if claimed[0].Attempts != 1 {
+		t.Errorf("ClaimPending() Attempts = %v, want 1", claimed[0].Attempts)
+	}
+
+	if claimed[0].LastError != "bus unavailable" {
+		t.Errorf("ClaimPending() LastError = %v, want bus unavailable", claimed[0].LastError)
+	}
+}
+
+func testCheckRepositoryWithEvents(t *testing.T, repo domain.AMLCheckRepository, outbox domain.Outbox) {
+	ctx := context.Background()
+
+	check := domain.NewAMLCheck("test-address", "BTC", time.Hour)
+
+	if err := repo.CreateWithEvents(ctx, check, newTestOutboxMessage(check.ID)); err != nil {
+		t.Fatalf("CreateWithEvents() error = %v", err)
+	}
+
+	// a duplicate check must not leave its event behind
+	if err := repo.CreateWithEvents(ctx, check, newTestOutboxMessage(check.ID)); err == nil {
+		t.Error("CreateWithEvents() should return error for duplicate")
+	}
+
+	check.MarkCompleted(10, domain.RiskLevelLow, []string{}, &domain.SanctionsResult{Identifications: []domain.SanctionsIdentification{}}, "report.pdf")
+	if err := repo.UpdateWithEvents(ctx, check, newTestOutboxMessage(check.ID)); err != nil {
+		t.Fatalf("UpdateWithEvents() error = %v", err)
+	}
+
+	missing := domain.NewAMLCheck("missing-address", "BTC", time.Hour)
+	if err := repo.UpdateWithEvents(ctx, missing, newTestOutboxMessage(missing.ID)); err == nil {
+		t.Error("UpdateWithEvents() should return error for missing check")
+	}
+
+	retrieved, err := repo.Get(ctx, check.ID)
+	if err != nil {
+		t.Fatalf("Get() error = %v", err)
+	}
+	if retrieved == nil || retrieved.Status != domain.StatusCompleted {
+		t.Error("UpdateWithEvents() did not persist the check")
+	}
+
+	claimed, err := outbox.ClaimPending(ctx, 10, time.Minute)
+	if err != nil {
+		t.Fatalf("ClaimPending() error = %v", err)
+	}
+
+	if len(claimed) != 2 {
+		t.Errorf("ClaimPending() length = %v, want 2", len(claimed))
+	}
+}
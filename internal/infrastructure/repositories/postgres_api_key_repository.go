@@ -0,0 +1,187 @@
+package repositories
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/Beka01247/bitpanda-aml/internal/domain"
+	"github.com/google/uuid"
+	"github.com/lib/pq"
+	"go.uber.org/zap"
+)
+
+const apiKeyColumns = `id, tenant_id, name, prefix, key_hash, callback_url, roles, created_at, expires_at, revoked_at`
+
+type PostgresAPIKeyRepository struct {
+	db     *sql.DB
+	logger *zap.SugaredLogger
+}
+
+func NewPostgresAPIKeyRepository(db *sql.DB, logger *zap.SugaredLogger) *PostgresAPIKeyRepository {
+	return &PostgresAPIKeyRepository{
+		db:     db,
+		logger: logger,
+	}
+}
+
+func (r *PostgresAPIKeyRepository) Create(ctx context.Context, key *domain.APIKey) error {
+	query := `
+		INSERT INTO api_keys (` + apiKeyColumns + `)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10)
+	`
+
+	ctx, cancel := context.WithTimeout(ctx, queryTimeoutDuration)
+	defer cancel()
+
+	_, err := r.db.ExecContext(
+		ctx,
+		query,
+		key.ID,
+		key.TenantID,
+		key.Name,
+		key.Prefix,
+		key.Hash,
+		key.CallbackURL,
+		pq.Array(roleStrings(key.Roles)),
+		key.CreatedAt,
+		key.ExpiresAt,
+		key.RevokedAt,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to insert api key: %w", err)
+	}
+
+	r.logger.Debugw("api key created", "key_id", key.ID, "tenant_id", key.TenantID)
+
+	return nil
+}
+
+func (r *PostgresAPIKeyRepository) Get(ctx context.Context, keyID string) (*domain.APIKey, error) {
+	if _, err := uuid.Parse(keyID); err != nil {
+		return nil, nil
+	}
+
+	return r.getBy(ctx, "id", keyID)
+}
+
+func (r *PostgresAPIKeyRepository) GetByHash(ctx context.Context, hash string) (*domain.APIKey, error) {
+	return r.getBy(ctx, "key_hash", hash)
+}
+
+func (r *PostgresAPIKeyRepository) getBy(ctx context.Context, column, value string) (*domain.APIKey, error) {
+	query := `SELECT ` + apiKeyColumns + ` FROM api_keys WHERE ` + column + ` = $1`
+
+	ctx, cancel := context.WithTimeout(ctx, queryTimeoutDuration)
+	defer cancel()
+
+	key, err := scanAPIKey(r.db.QueryRowContext(ctx, query, value))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to get api key: %w", err)
+	}
+
+	return key, nil
+}
+
+func (r *PostgresAPIKeyRepository) List(ctx context.Context, filter domain.APIKeyFilter) ([]*domain.APIKey, error) {
+	conditions := []string{"TRUE"}
+	args := []any{}
+
+	if filter.TenantID != "" {
+		args = append(args, filter.TenantID)
+		conditions = append(conditions, fmt.Sprintf("tenant_id = $%d", len(args)))
+	}
+
+	query := `SELECT ` + apiKeyColumns + ` FROM api_keys WHERE ` +
+		strings.Join(conditions, " AND ") + ` ORDER BY created_at ASC`
+
+	ctx, cancel := context.WithTimeout(ctx, queryTimeoutDuration)
+	defer cancel()
+
+	rows, err := r.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query api keys: %w", err)
+	}
+	defer rows.Close()
+
+	keys := make([]*domain.APIKey, 0)
+	for rows.Next() {
+		key, err := scanAPIKey(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan api key: %w", err)
+		}
+		keys = append(keys, key)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to query api keys: %w", err)
+	}
+
+	return keys, nil
+}
+
+func (r *PostgresAPIKeyRepository) Update(ctx context.Context, key *domain.APIKey) error {
+	query := `
+		UPDATE api_keys
+		SET name = $2, callback_url = $3, roles = $4, expires_at = $5, revoked_at = $6
+		WHERE id = $1
+	`
+
+	ctx, cancel := context.WithTimeout(ctx, queryTimeoutDuration)
+	defer cancel()
+
+	res, err := r.db.ExecContext(ctx, query, key.ID, key.Name, key.CallbackURL, pq.Array(roleStrings(key.Roles)), key.ExpiresAt, key.RevokedAt)
+	if err != nil {
+		return fmt.Errorf("failed to update api key: %w", err)
+	}
+
+	if err := requireAffected(res, "api key not found"); err != nil {
+		return domain.ErrAPIKeyNotFound
+	}
+
+	r.logger.Debugw("api key updated", "key_id", key.ID, "tenant_id", key.TenantID)
+
+	return nil
+}
+
+func scanAPIKey(row scanner) (*domain.APIKey, error) {
+	var (
+		key   domain.APIKey
+		roles []string
+	)
+
+	err := row.Scan(
+		&key.ID,
+		&key.TenantID,
+		&key.Name,
+		&key.Prefix,
+		&key.Hash,
+		&key.CallbackURL,
+		pq.Array(&roles),
+		&key.CreatedAt,
+		&key.ExpiresAt,
+		&key.RevokedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, role := range roles {
+		key.Roles = append(key.Roles, domain.Role(role))
+	}
+
+	return &key, nil
+}
+
+func roleStrings(roles []domain.Role) []string {
+	values := make([]string, 0, len(roles))
+	for _, role := range roles {
+		values = append(values, string(role))
+	}
+	return values
+}
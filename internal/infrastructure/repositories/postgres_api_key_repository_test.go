@@ -0,0 +1,27 @@
+package repositories
+
+import (
+	"context"
+	"testing"
+
+	"go.uber.org/zap"
+)
+
+func newTestPostgresAPIKeyRepository(t *testing.T) *PostgresAPIKeyRepository {
+	t.Helper()
+
+	conn := newTestDB(t)
+	if _, err := conn.ExecContext(context.Background(), "TRUNCATE api_keys"); err != nil {
+		t.Fatalf("failed to truncate api_keys: %v", err)
+	}
+
+	return NewPostgresAPIKeyRepository(conn, zap.NewNop().Sugar())
+}
+
+func TestPostgresAPIKeyRepository_Lifecycle(t *testing.T) {
+	testAPIKeyRepositoryLifecycle(t, newTestPostgresAPIKeyRepository(t))
+}
+
+func TestPostgresAPIKeyRepository_List(t *testing.T) {
+	testAPIKeyRepositoryList(t, newTestPostgresAPIKeyRepository(t))
+}
@@ -0,0 +1,183 @@
+package repositories
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/Beka01247/bitpanda-aml/internal/domain"
+	"go.uber.org/zap"
+)
+
+const auditEntryColumns = `sequence, id, action, actor, tenant_id, check_id, address, details, created_at, prev_hash, hash`
+
+type PostgresAuditLog struct {
+	db     *sql.DB
+	logger *zap.SugaredLogger
+}
+
+func NewPostgresAuditLog(db *sql.DB, logger *zap.SugaredLogger) *PostgresAuditLog {
+	return &PostgresAuditLog{
+		db:     db,
+		logger: logger,
+	}
+}
+
+func (l *PostgresAuditLog) Append(ctx context.Context, entry *domain.AuditEntry) error {
+	details, err := json.Marshal(entry.Details)
+	if err != nil {
+		return fmt.Errorf("failed to marshal audit details: %w", err)
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, queryTimeoutDuration)
+	defer cancel()
+
+	err = withTx(ctx, l.db, func(tx *sql.Tx) error {
+		// appends are serialized so every entry links to the one before it,
+		// readers are not blocked
+		if _, err := tx.ExecContext(ctx, `LOCK TABLE audit_log IN EXCLUSIVE MODE`); err != nil {
+			return fmt.Errorf("failed to lock audit log: %w", err)
+		}
+
+		var (
+			sequence int64
+			prevHash string
+		)
+		err := tx.QueryRowContext(ctx, `SELECT sequence, hash FROM audit_log ORDER BY sequence DESC LIMIT 1`).Scan(&sequence, &prevHash)
+		if err != nil && !errors.Is(err, sql.ErrNoRows) {
+			return fmt.Errorf("failed to get last audit entry: %w", err)
+		}
+
+		entry.Seal(sequence+1, prevHash)
+
+		query := `INSERT INTO audit_log (` + auditEntryColumns + `) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11)`
+		_, err = tx.ExecContext(
+			ctx,
+			query,
+			entry.Sequence,
+			entry.ID,
+			entry.Action,
+			entry.Actor,
+			entry.TenantID,
+			entry.CheckID,
+			entry.Address,
+			details,
+			entry.CreatedAt,
+			entry.PrevHash,
+			entry.Hash,
+		)
+		if err != nil {
+			return fmt.Errorf("failed to insert audit entry: %w", err)
+		}
+
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	l.logger.Debugw("audit entry appended", "sequence", entry.Sequence, "action", entry.Action, "check_id", entry.CheckID)
+
+	return nil
+}
+
+func (l *PostgresAuditLog) List(ctx context.Context, filter domain.AuditFilter) ([]*domain.AuditEntry, error) {
+	conditions := []string{"TRUE"}
+	args := []any{}
+
+	if filter.CheckID != "" {
+		args = append(args, filter.CheckID)
+		conditions = append(conditions, fmt.Sprintf("check_id = $%d", len(args)))
+	}
+	if filter.Address != "" {
+		args = append(args, filter.Address)
+		conditions = append(conditions, fmt.Sprintf("address = $%d", len(args)))
+	}
+	if filter.TenantID != "" {
+		args = append(args, filter.TenantID)
+		conditions = append(conditions, fmt.Sprintf("tenant_id = $%d", len(args)))
+	}
+	if filter.From != nil {
+		args = append(args, *filter.From)
+		conditions = append(conditions, fmt.Sprintf("created_at >= $%d", len(args)))
+	}
+	if filter.To != nil {
+		args = append(args, *filter.To)
+		conditions = append(conditions, fmt.Sprintf("created_at < $%d", len(args)))
+	}
+
+	query := `SELECT ` + auditEntryColumns + ` FROM audit_log WHERE ` +
+		strings.Join(conditions, " AND ") + ` ORDER BY sequence ASC`
+	if filter.Limit > 0 {
+		args = append(args, filter.Limit)
+		query += fmt.Sprintf(" LIMIT $%d", len(args))
+	}
+
+	return l.query(ctx, query, args...)
+}
+
+func (l *PostgresAuditLog) ListAfter(ctx context.Context, sequence int64, limit int) ([]*domain.AuditEntry, error) {
+	query := `SELECT ` + auditEntryColumns + ` FROM audit_log WHERE sequence > $1 ORDER BY sequence ASC LIMIT $2`
+
+	return l.query(ctx, query, sequence, limit)
+}
+
+func (l *PostgresAuditLog) query(ctx context.Context, query string, args ...any) ([]*domain.AuditEntry, error) {
+	ctx, cancel := context.WithTimeout(ctx, queryTimeoutDuration)
+	defer cancel()
+
+	rows, err := l.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query audit log: %w", err)
+	}
+	defer rows.Close()
+
+	entries := make([]*domain.AuditEntry, 0)
+	for rows.Next() {
+		entry, err := scanAuditEntry(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan audit entry: %w", err)
+		}
+		entries = append(entries, entry)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to query audit log: %w", err)
+	}
+
+	return entries, nil
+}
+
+func scanAuditEntry(row scanner) (*domain.AuditEntry, error) {
+	var (
+		entry   domain.AuditEntry
+		details []byte
+	)
+
+	err := row.Scan(
+		&entry.Sequence,
+		&entry.ID,
+		&entry.Action,
+		&entry.Actor,
+		&entry.TenantID,
+		&entry.CheckID,
+		&entry.Address,
+		&details,
+		&entry.CreatedAt,
+		&entry.PrevHash,
+		&entry.Hash,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := json.Unmarshal(details, &entry.Details); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal audit details: %w", err)
+	}
+	entry.CreatedAt = entry.CreatedAt.UTC()
+
+	return &entry, nil
+}
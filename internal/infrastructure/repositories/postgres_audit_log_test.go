@@ -0,0 +1,27 @@
+package repositories
+
+import (
+	"context"
+	"testing"
+
+	"go.uber.org/zap"
+)
+
+func newTestPostgresAuditLog(t *testing.T) *PostgresAuditLog {
+	t.Helper()
+
+	conn := newTestDB(t)
+	if _, err := conn.ExecContext(context.Background(), "TRUNCATE audit_log"); err != nil {
+		t.Fatalf("failed to truncate audit_log: %v", err)
+	}
+
+	return NewPostgresAuditLog(conn, zap.NewNop().Sugar())
+}
+
+func TestPostgresAuditLog_Chain(t *testing.T) {
+	testAuditLogChain(t, newTestPostgresAuditLog(t))
+}
+
+func TestPostgresAuditLog_List(t *testing.T) {
+	testAuditLogList(t, newTestPostgresAuditLog(t))
+}
@@ -0,0 +1,118 @@
+package repositories
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/Beka01247/bitpanda-aml/internal/domain"
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+)
+
+type PostgresBatchRepository struct {
+	db     *sql.DB
+	logger *zap.SugaredLogger
+}
+
+func NewPostgresBatchRepository(db *sql.DB, logger *zap.SugaredLogger) *PostgresBatchRepository {
+	return &PostgresBatchRepository{
+		db:     db,
+		logger: logger,
+	}
+}
+
+func (r *PostgresBatchRepository) Create(ctx context.Context, batch *domain.Batch) error {
+	query := `INSERT INTO batches (id, tenant_id, items, created_at, expires_at) VALUES ($1, $2, $3, $4, $5)`
+
+	items, err := json.Marshal(batch.Items)
+	if err != nil {
+		return fmt.Errorf("failed to marshal batch items: %w", err)
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, queryTimeoutDuration)
+	defer cancel()
+
+	if _, err := r.db.ExecContext(ctx, query, batch.ID, batch.TenantID, items, batch.CreatedAt, batch.ExpiresAt); err != nil {
+		return fmt.Errorf("failed to insert batch: %w", err)
+	}
+
+	r.logger.Debugw("batch created", "batch_id", batch.ID, "items", len(batch.Items))
+
+	return nil
+}
+
+func (r *PostgresBatchRepository) Get(ctx context.Context, batchID string) (*domain.Batch, error) {
+	if _, err := uuid.Parse(batchID); err != nil {
+		return nil, nil
+	}
+
+	query := `SELECT id, tenant_id, items, created_at, expires_at FROM batches WHERE id = $1`
+
+	ctx, cancel := context.WithTimeout(ctx, queryTimeoutDuration)
+	defer cancel()
+
+	var (
+		batch domain.Batch
+		items []byte
+	)
+	err := r.db.QueryRowContext(ctx, query, batchID).Scan(&batch.ID, &batch.TenantID, &items, &batch.CreatedAt, &batch.ExpiresAt)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to get batch: %w", err)
+	}
+
+	if err := json.Unmarshal(items, &batch.Items); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal batch items: %w", err)
+	}
+
+	return &batch, nil
+}
+
+func (r *PostgresBatchRepository) CleanupExpired(ctx context.Context, now time.Time) (int, error) {
+	query := `DELETE FROM batches WHERE expires_at < $1`
+
+	ctx, cancel := context.WithTimeout(ctx, queryTimeoutDuration)
+	defer cancel()
+
+	res, err := r.db.ExecContext(ctx, query, now)
+	if err != nil {
+		return 0, fmt.Errorf("failed to cleanup batches: %w", err)
+	}
+
+	count, err := res.RowsAffected()
+	if err != nil {
+		return 0, fmt.Errorf("failed to cleanup batches: %w", err)
+	}
+
+	if count > 0 {
+		r.logger.Infow("expired batches cleaned", "count", count)
+	}
+
+	return int(count), nil
+}
+
+// starts a background cleanup loop
+func (r *PostgresBatchRepository) StartCleanupLoop(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	go func() {
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ctx.Done():
+				r.logger.Info("batch cleanup loop stopped")
+				return
+			case <-ticker.C:
+				_, err := r.CleanupExpired(ctx, time.Now().UTC())
+				if err != nil {
+					r.logger.Errorw("batch cleanup failed", "error", err)
+				}
+			}
+		}
+	}()
+}
@@ -0,0 +1,27 @@
+package repositories
+
+import (
+	"context"
+	"testing"
+
+	"go.uber.org/zap"
+)
+
+func newTestPostgresBatchRepository(t *testing.T) *PostgresBatchRepository {
+	t.Helper()
+
+	conn := newTestDB(t)
+	if _, err := conn.ExecContext(context.Background(), "TRUNCATE batches"); err != nil {
+		t.Fatalf("failed to truncate batches: %v", err)
+	}
+
+	return NewPostgresBatchRepository(conn, zap.NewNop().Sugar())
+}
+
+func TestPostgresBatchRepository_Lifecycle(t *testing.T) {
+	testBatchRepositoryLifecycle(t, newTestPostgresBatchRepository(t))
+}
+
+func TestPostgresBatchRepository_CleanupExpired(t *testing.T) {
+	testBatchRepositoryCleanupExpired(t, newTestPostgresBatchRepository(t))
+}
@@ -0,0 +1,305 @@
+package repositories
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/Beka01247/bitpanda-aml/internal/domain"
+	"github.com/google/uuid"
+	"github.com/lib/pq"
+	"go.uber.org/zap"
+)
+
+const caseColumns = `id, check_id, address, currency, risk_score, risk_level, decision, reasons, status,
+	assignee, disposition, notes, history, version, created_at, updated_at, closed_at, tenant_id`
+
+type PostgresCaseRepository struct {
+	db     *sql.DB
+	logger *zap.SugaredLogger
+}
+
+func NewPostgresCaseRepository(db *sql.DB, logger *zap.SugaredLogger) *PostgresCaseRepository {
+	return &PostgresCaseRepository{
+		db:     db,
+		logger: logger,
+	}
+}
+
+func (r *PostgresCaseRepository) Create(ctx context.Context, reviewCase *domain.ReviewCase) error {
+	query := `
+		INSERT INTO review_cases (` + caseColumns + `)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, $16, $17, $18)
+	`
+
+	reasons, notes, history, err := marshalCaseLists(reviewCase)
+	if err != nil {
+		return err
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, queryTimeoutDuration)
+	defer cancel()
+
+	_, err = r.db.ExecContext(
+		ctx,
+		query,
+		reviewCase.ID,
+		reviewCase.CheckID,
+		reviewCase.Address,
+		reviewCase.Currency,
+		reviewCase.RiskScore,
+		reviewCase.RiskLevel,
+		reviewCase.Decision,
+		reasons,
+		reviewCase.Status,
+		reviewCase.Assignee,
+		reviewCase.Disposition,
+		notes,
+		history,
+		1,
+		reviewCase.CreatedAt,
+		reviewCase.UpdatedAt,
+		reviewCase.ClosedAt,
+		reviewCase.TenantID,
+	)
+	if err != nil {
+		var pqErr *pq.Error
+		if errors.As(err, &pqErr) && pqErr.Code == "23505" {
+			return domain.ErrCaseExists
+		}
+		return fmt.Errorf("failed to insert case: %w", err)
+	}
+
+	reviewCase.Version = 1
+	r.logger.Debugw("case created", "case_id", reviewCase.ID, "check_id", reviewCase.CheckID)
+
+	return nil
+}
+
+func (r *PostgresCaseRepository) Get(ctx context.Context, caseID string) (*domain.ReviewCase, error) {
+	if _, err := uuid.Parse(caseID); err != nil {
+		return nil, nil
+	}
+
+	query := `SELECT ` + caseColumns + ` FROM review_cases WHERE id = $1`
+
+	ctx, cancel := context.WithTimeout(ctx, queryTimeoutDuration)
+	defer cancel()
+
+	reviewCase, err := scanReviewCase(r.db.QueryRowContext(ctx, query, caseID))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to get case: %w", err)
+	}
+
+	return reviewCase, nil
+}
+
+func (r *PostgresCaseRepository) List(ctx context.Context, filter domain.CaseFilter) ([]*domain.ReviewCase, error) {
+	conditions := []string{"TRUE"}
+	args := []any{}
+
+	if filter.Status != "" {
+		args = append(args, filter.Status)
+		conditions = append(conditions, fmt.Sprintf("status = $%d", len(args)))
+	}
+	if filter.Assignee != "" {
+		args = append(args, filter.Assignee)
+		conditions = append(conditions, fmt.Sprintf("assignee = $%d", len(args)))
+	}
+	if filter.CheckID != "" {
+		if _, err := uuid.Parse(filter.CheckID); err != nil {
+			return []*domain.ReviewCase{}, nil
+		}
+		args = append(args, filter.CheckID)
+		conditions = append(conditions, fmt.Sprintf("check_id = $%d", len(args)))
+	}
+	if filter.TenantID != "" {
+		args = append(args, filter.TenantID)
+		conditions = append(conditions, fmt.Sprintf("tenant_id = $%d", len(args)))
+	}
+
+	query := `SELECT ` + caseColumns + ` FROM review_cases WHERE ` +
+		strings.Join(conditions, " AND ") + ` ORDER BY created_at ASC`
+
+	ctx, cancel := context.WithTimeout(ctx, queryTimeoutDuration)
+	defer cancel()
+
+	rows, err := r.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query cases: %w", err)
+	}
+	defer rows.Close()
+
+	cases := make([]*domain.ReviewCase, 0)
+	for rows.Next() {
+		reviewCase, err := scanReviewCase(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan case: %w", err)
+		}
+		cases = append(cases, reviewCase)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to query cases: %w", err)
+	}
+
+	return cases, nil
+}
+
+func (r *PostgresCaseRepository) Update(ctx context.Context, reviewCase *domain.ReviewCase) error {
+	ctx, cancel := context.WithTimeout(ctx, queryTimeoutDuration)
+	defer cancel()
+
+	if err := r.update(ctx, r.db, reviewCase); err != nil {
+		return err
+	}
+
+	reviewCase.Version++
+	r.logger.Debugw("case updated", "case_id", reviewCase.ID, "status", reviewCase.Status)
+
+	return nil
+}
+
+func (r *PostgresCaseRepository) UpdateWithEvents(ctx context.Context, reviewCase *domain.ReviewCase, messages ...*domain.OutboxMessage) error {
+	ctx, cancel := context.WithTimeout(ctx, queryTimeoutDuration)
+	defer cancel()
+
+	err := withTx(ctx, r.db, func(tx *sql.Tx) error {
+		if err := r.update(ctx, tx, reviewCase); err != nil {
+			return err
+		}
+		return insertOutboxMessages(ctx, tx, messages)
+	})
+	if err != nil {
+		return err
+	}
+
+	reviewCase.Version++
+	r.logger.Debugw("case updated", "case_id", reviewCase.ID, "status", reviewCase.Status, "events", len(messages))
+
+	return nil
+}
+
+func (r *PostgresCaseRepository) update(ctx context.Context, exec execer, reviewCase *domain.ReviewCase) error {
+	query := `
+		UPDATE review_cases
+		SET status = $3, assignee = $4, disposition = $5, notes = $6, history = $7,
+			version = version + 1, updated_at = $8, closed_at = $9
+		WHERE id = $1 AND version = $2
+	`
+
+	_, notes, history, err := marshalCaseLists(reviewCase)
+	if err != nil {
+		return err
+	}
+
+	res, err := exec.ExecContext(
+		ctx,
+		query,
+		reviewCase.ID,
+		reviewCase.Version,
+		reviewCase.Status,
+		reviewCase.Assignee,
+		reviewCase.Disposition,
+		notes,
+		history,
+		reviewCase.UpdatedAt,
+		reviewCase.ClosedAt,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to update case: %w", err)
+	}
+
+	rows, err := res.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to update case: %w", err)
+	}
+	if rows > 0 {
+		return nil
+	}
+
+	// tell a missing case apart from a stale version
+	var exists bool
+	if err := r.db.QueryRowContext(ctx, `SELECT EXISTS (SELECT 1 FROM review_cases WHERE id = $1)`, reviewCase.ID).Scan(&exists); err != nil {
+		return fmt.Errorf("failed to update case: %w", err)
+	}
+	if !exists {
+		return domain.ErrCaseNotFound
+	}
+	return domain.ErrCaseConflict
+}
+
+func marshalCaseLists(reviewCase *domain.ReviewCase) (reasons, notes, history []byte, err error) {
+	if reasons, err = json.Marshal(nonNilStrings(reviewCase.Reasons)); err != nil {
+		return nil, nil, nil, fmt.Errorf("failed to marshal reasons: %w", err)
+	}
+
+	caseNotes := reviewCase.Notes
+	if caseNotes == nil {
+		caseNotes = []domain.CaseNote{}
+	}
+	if notes, err = json.Marshal(caseNotes); err != nil {
+		return nil, nil, nil, fmt.Errorf("failed to marshal notes: %w", err)
+	}
+
+	caseHistory := reviewCase.History
+	if caseHistory == nil {
+		caseHistory = []domain.CaseHistoryEntry{}
+	}
+	if history, err = json.Marshal(caseHistory); err != nil {
+		return nil, nil, nil, fmt.Errorf("failed to marshal history: %w", err)
+	}
+
+	return reasons, notes, history, nil
+}
+
+func scanReviewCase(row scanner) (*domain.ReviewCase, error) {
+	var (
+		reviewCase domain.ReviewCase
+		reasons    []byte
+		notes      []byte
+		history    []byte
+	)
+
+	err := row.Scan(
+		&reviewCase.ID,
+		&reviewCase.CheckID,
+		&reviewCase.Address,
+		&reviewCase.Currency,
+		&reviewCase.RiskScore,
+		&reviewCase.RiskLevel,
+		&reviewCase.Decision,
+		&reasons,
+		&reviewCase.Status,
+		&reviewCase.Assignee,
+		&reviewCase.Disposition,
+		&notes,
+		&history,
+		&reviewCase.Version,
+		&reviewCase.CreatedAt,
+		&reviewCase.UpdatedAt,
+		&reviewCase.ClosedAt,
+		&reviewCase.TenantID,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := json.Unmarshal(reasons, &reviewCase.Reasons); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal reasons: %w", err)
+	}
+	if err := json.Unmarshal(notes, &reviewCase.Notes); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal notes: %w", err)
+	}
+	if err := json.Unmarshal(history, &reviewCase.History); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal history: %w", err)
+	}
+
+	return &reviewCase, nil
+}
@@ -0,0 +1,29 @@
+package repositories
+
+import (
+	"context"
+	"testing"
+
+	"go.uber.org/zap"
+)
+
+func newTestPostgresCaseRepository(t *testing.T) (*PostgresCaseRepository, *PostgresOutbox) {
+	t.Helper()
+
+	conn := newTestDB(t)
+	if _, err := conn.ExecContext(context.Background(), "TRUNCATE review_cases, outbox_messages"); err != nil {
+		t.Fatalf("failed to truncate review_cases: %v", err)
+	}
+
+	return NewPostgresCaseRepository(conn, zap.NewNop().Sugar()), NewPostgresOutbox(conn, zap.NewNop().Sugar())
+}
+
+func TestPostgresCaseRepository_Lifecycle(t *testing.T) {
+	repo, outbox := newTestPostgresCaseRepository(t)
+	testCaseRepositoryLifecycle(t, repo, outbox)
+}
+
+func TestPostgresCaseRepository_List(t *testing.T) {
+	repo, _ := newTestPostgresCaseRepository(t)
+	testCaseRepositoryList(t, repo)
+}
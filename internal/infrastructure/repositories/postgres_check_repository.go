@@ -0,0 +1,430 @@
+package repositories
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/Beka01247/bitpanda-aml/internal/domain"
+	"github.com/google/uuid"
+	"github.com/lib/pq"
+	"go.uber.org/zap"
+)
+
+const queryTimeoutDuration = 5 * time.Second
+
+type PostgresCheckRepository struct {
+	db     *sql.DB
+	logger *zap.SugaredLogger
+}
+
+func NewPostgresCheckRepository(db *sql.DB, logger *zap.SugaredLogger) *PostgresCheckRepository {
+	return &PostgresCheckRepository{
+		db:     db,
+		logger: logger,
+	}
+}
+
+// satisfied by both *sql.DB and *sql.Tx
+type execer interface {
+	ExecContext(ctx context.Context, query string, args ...any) (sql.Result, error)
+}
+
+func (r *PostgresCheckRepository) Create(ctx context.Context, check *domain.AMLCheck) error {
+	ctx, cancel := context.WithTimeout(ctx, queryTimeoutDuration)
+	defer cancel()
+
+	if err := r.insert(ctx, r.db, check); err != nil {
+		return err
+	}
+
+	r.logger.Debugw("check created", "check_id", check.ID)
+
+	return nil
+}
+
+func (r *PostgresCheckRepository) CreateWithEvents(ctx context.Context, check *domain.AMLCheck, messages ...*domain.OutboxMessage) error {
+	ctx, cancel := context.WithTimeout(ctx, queryTimeoutDuration)
+	defer cancel()
+
+	err := withTx(ctx, r.db, func(tx *sql.Tx) error {
+		if err := r.insert(ctx, tx, check); err != nil {
+			return err
+		}
+		return insertOutboxMessages(ctx, tx, messages)
+	})
+	if err != nil {
+		return err
+	}
+
+	r.logger.Debugw("check created", "check_id", check.ID, "events", len(messages))
+
+	return nil
+}
+
+func (r *PostgresCheckRepository) insert(ctx context.Context, exec execer, check *domain.AMLCheck) error {
+	query := `
+		INSERT INTO aml_checks (
+			id, address, currency, status, risk_score, risk_level, categories,
+			sanctions, providers, watchlist, decision, matched_rules, report_key, error_message,
+			created_at, updated_at, expires_at, callback_url, tenant_id, provider_statuses, chain, tag, network_type
+		)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, $16, $17, $18, $19, $20, $21, $22, $23)
+	`
+
+	sanctions, err := json.Marshal(check.Sanctions)
+	if err != nil {
+		return fmt.Errorf("failed to marshal sanctions: %w", err)
+	}
+
+	providers, err := json.Marshal(nonNilProviders(check.Providers))
+	if err != nil {
+		return fmt.Errorf("failed to marshal providers: %w", err)
+	}
+
+	watchlist, err := marshalNullable(check.Watchlist)
+	if err != nil {
+		return fmt.Errorf("failed to marshal watchlist: %w", err)
+	}
+
+	matchedRules, err := json.Marshal(nonNilMatchedRules(check.MatchedRules))
+	if err != nil {
+		return fmt.Errorf("failed to marshal matched rules: %w", err)
+	}
+
+	providerStatuses, err := json.Marshal(nonNilProviderStatuses(check.ProviderStatuses))
+	if err != nil {
+		return fmt.Errorf("failed to marshal provider statuses: %w", err)
+	}
+
+	_, err = exec.ExecContext(
+		ctx,
+		query,
+		check.ID,
+		check.Address,
+		check.Currency,
+		check.Status,
+		check.RiskScore,
+		check.RiskLevel,
+		pq.Array(nonNilStrings(check.Categories)),
+		sanctions,
+		providers,
+		watchlist,
+		check.Decision,
+		matchedRules,
+		check.ReportKey,
+		check.ErrorMessage,
+		check.CreatedAt,
+		check.UpdatedAt,
+		check.ExpiresAt,
+		check.CallbackURL,
+		check.TenantID,
+		providerStatuses,
+		check.Chain,
+		check.Tag,
+		check.NetworkType,
+	)
+	if err != nil {
+		var pqErr *pq.Error
+		if errors.As(err, &pqErr) && pqErr.Code == "23505" {
+			return fmt.Errorf("check already exists")
+		}
+		return fmt.Errorf("failed to insert check: %w", err)
+	}
+
+	return nil
+}
+
+func (r *PostgresCheckRepository) Get(ctx context.Context, checkID string) (*domain.AMLCheck, error) {
+	query := `
+		SELECT id, address, currency, status, risk_score, risk_level, categories,
+			sanctions, providers, watchlist, decision, matched_rules, report_key, error_message,
+			created_at, updated_at, expires_at, callback_url, tenant_id, provider_statuses, chain, tag, network_type
+		FROM aml_checks
+		WHERE id = $1
+	`
+
+	// ids are uuids, anything else cannot exist
+	if _, err := uuid.Parse(checkID); err != nil {
+		return nil, nil
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, queryTimeoutDuration)
+	defer cancel()
+
+	var (
+		check        domain.AMLCheck
+		categories   []string
+		sanctions    []byte
+		providers    []byte
+		watchlist    []byte
+		matchedRules []byte
+		statuses     []byte
+	)
+
+	err := r.db.QueryRowContext(ctx, query, checkID).Scan(
+		&check.ID,
+		&check.Address,
+		&check.Currency,
+		&check.Status,
+		&check.RiskScore,
+		&check.RiskLevel,
+		pq.Array(&categories),
+		&sanctions,
+		&providers,
+		&watchlist,
+		&check.Decision,
+		&matchedRules,
+		&check.ReportKey,
+		&check.ErrorMessage,
+		&check.CreatedAt,
+		&check.UpdatedAt,
+		&check.ExpiresAt,
+		&check.CallbackURL,
+		&check.TenantID,
+		&statuses,
+		&check.Chain,
+		&check.Tag,
+		&check.NetworkType,
+	)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to get check: %w", err)
+	}
+
+	check.Categories = nonNilStrings(categories)
+
+	if len(sanctions) > 0 {
+		if err := json.Unmarshal(sanctions, &check.Sanctions); err != nil {
+			return nil, fmt.Errorf("failed to unmarshal sanctions: %w", err)
+		}
+	}
+
+	if err := json.Unmarshal(providers, &check.Providers); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal providers: %w", err)
+	}
+	check.Providers = nonNilProviders(check.Providers)
+
+	if len(watchlist) > 0 {
+		if err := json.Unmarshal(watchlist, &check.Watchlist); err != nil {
+			return nil, fmt.Errorf("failed to unmarshal watchlist: %w", err)
+		}
+	}
+
+	if err := json.Unmarshal(matchedRules, &check.MatchedRules); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal matched rules: %w", err)
+	}
+	check.MatchedRules = nonNilMatchedRules(check.MatchedRules)
+
+	if err := json.Unmarshal(statuses, &check.ProviderStatuses); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal provider statuses: %w", err)
+	}
+	check.ProviderStatuses = nonNilProviderStatuses(check.ProviderStatuses)
+
+	return &check, nil
+}
+
+func (r *PostgresCheckRepository) Update(ctx context.Context, check *domain.AMLCheck) error {
+	ctx, cancel := context.WithTimeout(ctx, queryTimeoutDuration)
+	defer cancel()
+
+	if err := r.update(ctx, r.db, check); err != nil {
+		return err
+	}
+
+	r.logger.Debugw("check updated", "check_id", check.ID, "status", check.Status)
+
+	return nil
+}
+
+func (r *PostgresCheckRepository) UpdateWithEvents(ctx context.Context, check *domain.AMLCheck, messages ...*domain.OutboxMessage) error {
+	ctx, cancel := context.WithTimeout(ctx, queryTimeoutDuration)
+	defer cancel()
+
+	err := withTx(ctx, r.db, func(tx *sql.Tx) error {
+		if err := r.update(ctx, tx, check); err != nil {
+			return err
+		}
+		return insertOutboxMessages(ctx, tx, messages)
+	})
+	if err != nil {
+		return err
+	}
+
+	r.logger.Debugw("check updated", "check_id", check.ID, "status", check.Status, "events", len(messages))
+
+	return nil
+}
+
+func (r *PostgresCheckRepository) update(ctx context.Context, exec execer, check *domain.AMLCheck) error {
+	query := `
+		UPDATE aml_checks
+		SET status = $2, risk_score = $3, risk_level = $4, categories = $5,
+			sanctions = $6, providers = $7, watchlist = $8, decision = $9, matched_rules = $10,
+			report_key = $11, error_message = $12, updated_at = $13, expires_at = $14,
+			provider_statuses = $15
+		WHERE id = $1
+	`
+
+	sanctions, err := json.Marshal(check.Sanctions)
+	if err != nil {
+		return fmt.Errorf("failed to marshal sanctions: %w", err)
+	}
+
+	providers, err := json.Marshal(nonNilProviders(check.Providers))
+	if err != nil {
+		return fmt.Errorf("failed to marshal providers: %w", err)
+	}
+
+	watchlist, err := marshalNullable(check.Watchlist)
+	if err != nil {
+		return fmt.Errorf("failed to marshal watchlist: %w", err)
+	}
+
+	matchedRules, err := json.Marshal(nonNilMatchedRules(check.MatchedRules))
+	if err != nil {
+		return fmt.Errorf("failed to marshal matched rules: %w", err)
+	}
+
+	providerStatuses, err := json.Marshal(nonNilProviderStatuses(check.ProviderStatuses))
+	if err != nil {
+		return fmt.Errorf("failed to marshal provider statuses: %w", err)
+	}
+
+	res, err := exec.ExecContext(
+		ctx,
+		query,
+		check.ID,
+		check.Status,
+		check.RiskScore,
+		check.RiskLevel,
+		pq.Array(nonNilStrings(check.Categories)),
+		sanctions,
+		providers,
+		watchlist,
+		check.Decision,
+		matchedRules,
+		check.ReportKey,
+		check.ErrorMessage,
+		check.UpdatedAt,
+		check.ExpiresAt,
+		providerStatuses,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to update check: %w", err)
+	}
+
+	rows, err := res.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to update check: %w", err)
+	}
+
+	if rows == 0 {
+		return fmt.Errorf("check not found")
+	}
+
+	return nil
+}
+
+// removes expired checks
+func (r *PostgresCheckRepository) CleanupExpired(ctx context.Context, now time.Time) (int, error) {
+	query := `DELETE FROM aml_checks WHERE expires_at < $1`
+
+	ctx, cancel := context.WithTimeout(ctx, queryTimeoutDuration)
+	defer cancel()
+
+	res, err := r.db.ExecContext(ctx, query, now)
+	if err != nil {
+		return 0, fmt.Errorf("failed to cleanup checks: %w", err)
+	}
+
+	count, err := res.RowsAffected()
+	if err != nil {
+		return 0, fmt.Errorf("failed to cleanup checks: %w", err)
+	}
+
+	if count > 0 {
+		r.logger.Infow("expired checks cleaned", "count", count)
+	}
+
+	return int(count), nil
+}
+
+// starts a background cleanup loop
+func (r *PostgresCheckRepository) StartCleanupLoop(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	go func() {
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ctx.Done():
+				r.logger.Info("cleanup loop stopped")
+				return
+			case <-ticker.C:
+				_, err := r.CleanupExpired(ctx, time.Now().UTC())
+				if err != nil {
+					r.logger.Errorw("cleanup failed", "error", err)
+				}
+			}
+		}
+	}()
+}
+
+func withTx(ctx context.Context, db *sql.DB, fn func(*sql.Tx) error) error {
+	tx, err := db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+
+	if err := fn(tx); err != nil {
+		_ = tx.Rollback()
+		return err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	return nil
+}
+
+func nonNilStrings(values []string) []string {
+	if values == nil {
+		return []string{}
+	}
+	return values
+}
+
+// marshals nil pointers to SQL NULL rather than the JSON null literal
+func marshalNullable[T any](value *T) ([]byte, error) {
+	if value == nil {
+		return nil, nil
+	}
+	return json.Marshal(value)
+}
+
+func nonNilProviders(values []domain.ProviderResult) []domain.ProviderResult {
+	if values == nil {
+		return []domain.ProviderResult{}
+	}
+	return values
+}
+
+func nonNilProviderStatuses(values []domain.ProviderStatus) []domain.ProviderStatus {
+	if values == nil {
+		return []domain.ProviderStatus{}
+	}
+	return values
+}
+
+func nonNilMatchedRules(values []domain.MatchedRule) []domain.MatchedRule {
+	if values == nil {
+		return []domain.MatchedRule{}
+	}
+	return values
+}
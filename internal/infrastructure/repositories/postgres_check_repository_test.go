@@ -0,0 +1,75 @@
+package repositories
+
+import (
+	"context"
+	"database/sql"
+	"os"
+	"path/filepath"
+	"sort"
+	"testing"
+
+	"github.com/Beka01247/bitpanda-aml/internal/db"
+	"go.uber.org/zap"
+)
+
+const migrationsPath = "../../../cmd/migrate/migrations"
+
+// opens the database pointed to by TEST_DB_ADDR (e.g. a local postgres container)
+// and applies the up migrations, skipping the test when it is not set
+func newTestDB(t *testing.T) *sql.DB {
+	t.Helper()
+
+	addr := os.Getenv("TEST_DB_ADDR")
+	if addr == "" {
+		t.Skip("TEST_DB_ADDR not set, skipping postgres tests")
+	}
+
+	conn, err := db.New(addr, 5, 5, "1m")
+	if err != nil {
+		t.Fatalf("db.New() error = %v", err)
+	}
+	t.Cleanup(func() { conn.Close() })
+
+	files, err := filepath.Glob(filepath.Join(migrationsPath, "*.up.sql"))
+	if err != nil {
+		t.Fatalf("failed to list migrations: %v", err)
+	}
+	sort.Strings(files)
+
+	for _, file := range files {
+		query, err := os.ReadFile(file)
+		if err != nil {
+			t.Fatalf("failed to read migration %s: %v", file, err)
+		}
+		if _, err := conn.Exec(string(query)); err != nil {
+			t.Fatalf("failed to apply migration %s: %v", file, err)
+		}
+	}
+
+	return conn
+}
+
+func newTestPostgresCheckRepository(t *testing.T) *PostgresCheckRepository {
+	t.Helper()
+
+	conn := newTestDB(t)
+	if _, err := conn.ExecContext(context.Background(), "TRUNCATE aml_checks CASCADE"); err != nil {
+		t.Fatalf("failed to truncate aml_checks: %v", err)
+	}
+
+	return NewPostgresCheckRepository(conn, zap.NewNop().Sugar())
+}
+
+func TestPostgresCheckRepository_CleanupExpired(t *testing.T) {
+	testCheckRepositoryCleanupExpired(t, newTestPostgresCheckRepository(t))
+}
+
+func TestPostgresCheckRepository_CRUD(t *testing.T) {
+	testCheckRepositoryCRUD(t, newTestPostgresCheckRepository(t))
+}
+
+func TestPostgresCheckRepository_WithEvents(t *testing.T) {
+	repo := newTestPostgresCheckRepository(t)
+	outbox := newTestPostgresOutbox(t)
+	testCheckRepositoryWithEvents(t, repo, outbox)
+}
@@ -0,0 +1,194 @@
+package repositories
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/Beka01247/bitpanda-aml/internal/domain"
+	"go.uber.org/zap"
+)
+
+type PostgresOutbox struct {
+	db     *sql.DB
+	logger *zap.SugaredLogger
+}
+
+func NewPostgresOutbox(db *sql.DB, logger *zap.SugaredLogger) *PostgresOutbox {
+	return &PostgresOutbox{
+		db:     db,
+		logger: logger,
+	}
+}
+
+func (o *PostgresOutbox) Enqueue(ctx context.Context, messages ...*domain.OutboxMessage) error {
+	ctx, cancel := context.WithTimeout(ctx, queryTimeoutDuration)
+	defer cancel()
+
+	return withTx(ctx, o.db, func(tx *sql.Tx) error {
+		return insertOutboxMessages(ctx, tx, messages)
+	})
+}
+
+func (o *PostgresOutbox) ClaimPending(ctx context.Context, limit int, lease time.Duration) ([]*domain.OutboxMessage, error) {
+	// SKIP LOCKED lets several replicas relay concurrently without
+	// picking up the same messages
+	query := `
+		UPDATE outbox_messages
+		SET locked_until = $2
+		WHERE id IN (
+			SELECT id FROM outbox_messages
+			WHERE sent_at IS NULL AND (locked_until IS NULL OR locked_until < $3)
+			ORDER BY created_at
+			LIMIT $1
+			FOR UPDATE SKIP LOCKED
+		)
+		RETURNING id, routing_key, event, attempts, last_error, created_at, locked_until, sent_at
+	`
+
+	ctx, cancel := context.WithTimeout(ctx, queryTimeoutDuration)
+	defer cancel()
+
+	now := time.Now().UTC()
+
+	rows, err := o.db.QueryContext(ctx, query, limit, now.Add(lease), now)
+	if err != nil {
+		return nil, fmt.Errorf("failed to claim outbox messages: %w", err)
+	}
+	defer rows.Close()
+
+	messages := make([]*domain.OutboxMessage, 0)
+	for rows.Next() {
+		var (
+			message domain.OutboxMessage
+			event   []byte
+		)
+
+		err := rows.Scan(
+			&message.ID,
+			&message.RoutingKey,
+			&event,
+			&message.Attempts,
+			&message.LastError,
+			&message.CreatedAt,
+			&message.LockedUntil,
+			&message.SentAt,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan outbox message: %w", err)
+		}
+
+		if err := json.Unmarshal(event, &message.Event); err != nil {
+			return nil, fmt.Errorf("failed to unmarshal outbox event: %w", err)
+		}
+
+		messages = append(messages, &message)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to claim outbox messages: %w", err)
+	}
+
+	// RETURNING does not preserve the subquery order
+	sortOutboxMessages(messages)
+
+	return messages, nil
+}
+
+func (o *PostgresOutbox) MarkSent(ctx context.Context, messageID string) error {
+	query := `UPDATE outbox_messages SET sent_at = $2, locked_until = NULL WHERE id = $1`
+
+	ctx, cancel := context.WithTimeout(ctx, queryTimeoutDuration)
+	defer cancel()
+
+	res, err := o.db.ExecContext(ctx, query, messageID, time.Now().UTC())
+	if err != nil {
+		return fmt.Errorf("failed to mark outbox message sent: %w", err)
+	}
+
+	return requireAffected(res, "outbox message not found")
+}
+
+func (o *PostgresOutbox) MarkFailed(ctx context.Context, messageID string, errorMessage string) error {
+	// the lease is kept so the message is retried once it expires
+	query := `UPDATE outbox_messages SET attempts = attempts + 1, last_error = $2 WHERE id = $1`
+
+	ctx, cancel := context.WithTimeout(ctx, queryTimeoutDuration)
+	defer cancel()
+
+	res, err := o.db.ExecContext(ctx, query, messageID, errorMessage)
+	if err != nil {
+		return fmt.Errorf("failed to mark outbox message failed: %w", err)
+	}
+
+	return requireAffected(res, "outbox message not found")
+}
+
+// removes messages that were sent before the given time
+func (o *PostgresOutbox) CleanupSent(ctx context.Context, before time.Time) (int, error) {
+	query := `DELETE FROM outbox_messages WHERE sent_at IS NOT NULL AND sent_at < $1`
+
+	ctx, cancel := context.WithTimeout(ctx, queryTimeoutDuration)
+	defer cancel()
+
+	res, err := o.db.ExecContext(ctx, query, before)
+	if err != nil {
+		return 0, fmt.Errorf("failed to cleanup outbox messages: %w", err)
+	}
+
+	count, err := res.RowsAffected()
+	if err != nil {
+		return 0, fmt.Errorf("failed to cleanup outbox messages: %w", err)
+	}
+
+	if count > 0 {
+		o.logger.Infow("sent outbox messages cleaned", "count", count)
+	}
+
+	return int(count), nil
+}
+
+func insertOutboxMessages(ctx context.Context, exec execer, messages []*domain.OutboxMessage) error {
+	query := `
+		INSERT INTO outbox_messages (id, routing_key, event, attempts, last_error, created_at)
+		VALUES ($1, $2, $3, $4, $5, $6)
+	`
+
+	for _, message := range messages {
+		event, err := json.Marshal(message.Event)
+		if err != nil {
+			return fmt.Errorf("failed to marshal outbox event: %w", err)
+		}
+
+		_, err = exec.ExecContext(
+			ctx,
+			query,
+			message.ID,
+			message.RoutingKey,
+			event,
+			message.Attempts,
+			message.LastError,
+			message.CreatedAt,
+		)
+		if err != nil {
+			return fmt.Errorf("failed to insert outbox message: %w", err)
+		}
+	}
+
+	return nil
+}
+
+func requireAffected(res sql.Result, notFoundMessage string) error {
+	rows, err := res.RowsAffected()
+	if err != nil {
+		return err
+	}
+
+	if rows == 0 {
+		return fmt.Errorf("%s", notFoundMessage)
+	}
+
+	return nil
+}
@@ -0,0 +1,27 @@
+package repositories
+
+import (
+	"context"
+	"testing"
+
+	"go.uber.org/zap"
+)
+
+func newTestPostgresOutbox(t *testing.T) *PostgresOutbox {
+	t.Helper()
+
+	conn := newTestDB(t)
+	if _, err := conn.ExecContext(context.Background(), "TRUNCATE outbox_messages"); err != nil {
+		t.Fatalf("failed to truncate outbox_messages: %v", err)
+	}
+
+	return NewPostgresOutbox(conn, zap.NewNop().Sugar())
+}
+
+func TestPostgresOutbox_Lifecycle(t *testing.T) {
+	testOutboxLifecycle(t, newTestPostgresOutbox(t))
+}
+
+func TestPostgresOutbox_LeaseExpiry(t *testing.T) {
+	testOutboxLeaseExpiry(t, newTestPostgresOutbox(t))
+}
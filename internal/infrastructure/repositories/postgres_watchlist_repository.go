@@ -0,0 +1,239 @@
+package repositories
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/Beka01247/bitpanda-aml/internal/domain"
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+)
+
+const watchlistColumns = `id, address, chain, list_type, reason, author, expires_at, created_at, updated_at`
+
+type PostgresWatchlistRepository struct {
+	db     *sql.DB
+	logger *zap.SugaredLogger
+}
+
+func NewPostgresWatchlistRepository(db *sql.DB, logger *zap.SugaredLogger) *PostgresWatchlistRepository {
+	return &PostgresWatchlistRepository{
+		db:     db,
+		logger: logger,
+	}
+}
+
+func (r *PostgresWatchlistRepository) Create(ctx context.Context, entry *domain.WatchlistEntry) error {
+	query := `
+		INSERT INTO watchlist_entries (` + watchlistColumns + `)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)
+	`
+
+	ctx, cancel := context.WithTimeout(ctx, queryTimeoutDuration)
+	defer cancel()
+
+	_, err := r.db.ExecContext(
+		ctx,
+		query,
+		entry.ID,
+		entry.Address,
+		entry.Chain,
+		entry.ListType,
+		entry.Reason,
+		entry.Author,
+		entry.ExpiresAt,
+		entry.CreatedAt,
+		entry.UpdatedAt,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to insert watchlist entry: %w", err)
+	}
+
+	r.logger.Debugw("watchlist entry created", "entry_id", entry.ID, "list_type", entry.ListType)
+
+	return nil
+}
+
+func (r *PostgresWatchlistRepository) Get(ctx context.Context, entryID string) (*domain.WatchlistEntry, error) {
+	if _, err := uuid.Parse(entryID); err != nil {
+		return nil, nil
+	}
+
+	query := `SELECT ` + watchlistColumns + ` FROM watchlist_entries WHERE id = $1`
+
+	ctx, cancel := context.WithTimeout(ctx, queryTimeoutDuration)
+	defer cancel()
+
+	entry, err := scanWatchlistEntry(r.db.QueryRowContext(ctx, query, entryID))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to get watchlist entry: %w", err)
+	}
+
+	return entry, nil
+}
+
+func (r *PostgresWatchlistRepository) Update(ctx context.Context, entry *domain.WatchlistEntry) error {
+	query := `
+		UPDATE watchlist_entries
+		SET address = $2, chain = $3, list_type = $4, reason = $5, author = $6, expires_at = $7, updated_at = $8
+		WHERE id = $1
+	`
+
+	ctx, cancel := context.WithTimeout(ctx, queryTimeoutDuration)
+	defer cancel()
+
+	res, err := r.db.ExecContext(
+		ctx,
+		query,
+		entry.ID,
+		entry.Address,
+		entry.Chain,
+		entry.ListType,
+		entry.Reason,
+		entry.Author,
+		entry.ExpiresAt,
+		entry.UpdatedAt,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to update watchlist entry: %w", err)
+	}
+
+	if err := requireAffected(res, "watchlist entry not found"); err != nil {
+		return domain.ErrWatchlistEntryNotFound
+	}
+
+	r.logger.Debugw("watchlist entry updated", "entry_id", entry.ID)
+
+	return nil
+}
+
+func (r *PostgresWatchlistRepository) Delete(ctx context.Context, entryID string) error {
+	if _, err := uuid.Parse(entryID); err != nil {
+		return domain.ErrWatchlistEntryNotFound
+	}
+
+	query := `DELETE FROM watchlist_entries WHERE id = $1`
+
+	ctx, cancel := context.WithTimeout(ctx, queryTimeoutDuration)
+	defer cancel()
+
+	res, err := r.db.ExecContext(ctx, query, entryID)
+	if err != nil {
+		return fmt.Errorf("failed to delete watchlist entry: %w", err)
+	}
+
+	if err := requireAffected(res, "watchlist entry not found"); err != nil {
+		return domain.ErrWatchlistEntryNotFound
+	}
+
+	r.logger.Debugw("watchlist entry deleted", "entry_id", entryID)
+
+	return nil
+}
+
+func (r *PostgresWatchlistRepository) List(ctx context.Context, filter domain.WatchlistFilter) ([]*domain.WatchlistEntry, error) {
+	conditions := []string{"TRUE"}
+	args := []any{}
+
+	if filter.Address != "" {
+		args = append(args, filter.Address)
+		conditions = append(conditions, fmt.Sprintf("address = $%d", len(args)))
+	}
+	if filter.Chain != "" {
+		args = append(args, filter.Chain)
+		conditions = append(conditions, fmt.Sprintf("chain = $%d", len(args)))
+	}
+	if filter.ListType != "" {
+		args = append(args, filter.ListType)
+		conditions = append(conditions, fmt.Sprintf("list_type = $%d", len(args)))
+	}
+
+	query := `SELECT ` + watchlistColumns + ` FROM watchlist_entries WHERE ` +
+		strings.Join(conditions, " AND ") + ` ORDER BY created_at DESC`
+
+	ctx, cancel := context.WithTimeout(ctx, queryTimeoutDuration)
+	defer cancel()
+
+	return r.query(ctx, query, args...)
+}
+
+func (r *PostgresWatchlistRepository) Match(ctx context.Context, chain, address string, now time.Time) (*domain.WatchlistEntry, error) {
+	// blocklist sorts before allowlist
+	query := `
+		SELECT ` + watchlistColumns + `
+		FROM watchlist_entries
+		WHERE chain = $1 AND address = $2 AND (expires_at IS NULL OR expires_at > $3)
+		ORDER BY list_type = 'blocklist' DESC, created_at DESC
+		LIMIT 1
+	`
+
+	ctx, cancel := context.WithTimeout(ctx, queryTimeoutDuration)
+	defer cancel()
+
+	entries, err := r.query(ctx, query, chain, address, now)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(entries) == 0 {
+		return nil, nil
+	}
+
+	return entries[0], nil
+}
+
+func (r *PostgresWatchlistRepository) query(ctx context.Context, query string, args ...any) ([]*domain.WatchlistEntry, error) {
+	rows, err := r.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query watchlist entries: %w", err)
+	}
+	defer rows.Close()
+
+	entries := make([]*domain.WatchlistEntry, 0)
+	for rows.Next() {
+		entry, err := scanWatchlistEntry(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan watchlist entry: %w", err)
+		}
+		entries = append(entries, entry)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to query watchlist entries: %w", err)
+	}
+
+	return entries, nil
+}
+
+// satisfied by both *sql.Row and *sql.Rows
+type scanner interface {
+	Scan(dest ...any) error
+}
+
+func scanWatchlistEntry(row scanner) (*domain.WatchlistEntry, error) {
+	var entry domain.WatchlistEntry
+
+	err := row.Scan(
+		&entry.ID,
+		&entry.Address,
+		&entry.Chain,
+		&entry.ListType,
+		&entry.Reason,
+		&entry.Author,
+		&entry.ExpiresAt,
+		&entry.CreatedAt,
+		&entry.UpdatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return &entry, nil
+}
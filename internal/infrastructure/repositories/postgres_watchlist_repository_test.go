@@ -0,0 +1,27 @@
+package repositories
+
+import (
+	"context"
+	"testing"
+
+	"go.uber.org/zap"
+)
+
+func newTestPostgresWatchlistRepository(t *testing.T) *PostgresWatchlistRepository {
+	t.Helper()
+
+	conn := newTestDB(t)
+	if _, err := conn.ExecContext(context.Background(), "TRUNCATE watchlist_entries"); err != nil {
+		t.Fatalf("failed to truncate watchlist_entries: %v", err)
+	}
+
+	return NewPostgresWatchlistRepository(conn, zap.NewNop().Sugar())
+}
+
+func TestPostgresWatchlistRepository_CRUD(t *testing.T) {
+	testWatchlistRepositoryCRUD(t, newTestPostgresWatchlistRepository(t))
+}
+
+func TestPostgresWatchlistRepository_ListAndMatch(t *testing.T) {
+	testWatchlistRepositoryListAndMatch(t, newTestPostgresWatchlistRepository(t))
+}
@@ -0,0 +1,224 @@
+package repositories
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/Beka01247/bitpanda-aml/internal/domain"
+	"github.com/google/uuid"
+	"github.com/lib/pq"
+	"go.uber.org/zap"
+)
+
+const webhookColumns = `id, check_id, event_type, url, payload, status, attempts, last_status_code, last_error,
+	next_attempt_at, created_at, updated_at, delivered_at, tenant_id`
+
+type PostgresWebhookRepository struct {
+	db     *sql.DB
+	logger *zap.SugaredLogger
+}
+
+func NewPostgresWebhookRepository(db *sql.DB, logger *zap.SugaredLogger) *PostgresWebhookRepository {
+	return &PostgresWebhookRepository{
+		db:     db,
+		logger: logger,
+	}
+}
+
+func (r *PostgresWebhookRepository) Create(ctx context.Context, delivery *domain.WebhookDelivery) error {
+	query := `
+		INSERT INTO webhook_deliveries (` + webhookColumns + `)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14)
+	`
+
+	ctx, cancel := context.WithTimeout(ctx, queryTimeoutDuration)
+	defer cancel()
+
+	_, err := r.db.ExecContext(
+		ctx,
+		query,
+		delivery.ID,
+		delivery.CheckID,
+		delivery.EventType,
+		delivery.URL,
+		delivery.Payload,
+		delivery.Status,
+		delivery.Attempts,
+		delivery.LastStatusCode,
+		delivery.LastError,
+		delivery.NextAttemptAt,
+		delivery.CreatedAt,
+		delivery.UpdatedAt,
+		delivery.DeliveredAt,
+		delivery.TenantID,
+	)
+	if err != nil {
+		var pqErr *pq.Error
+		if errors.As(err, &pqErr) && pqErr.Code == "23505" {
+			return domain.ErrWebhookDeliveryExists
+		}
+		return fmt.Errorf("failed to insert webhook delivery: %w", err)
+	}
+
+	r.logger.Debugw("webhook delivery created", "delivery_id", delivery.ID, "check_id", delivery.CheckID)
+
+	return nil
+}
+
+func (r *PostgresWebhookRepository) Get(ctx context.Context, deliveryID string) (*domain.WebhookDelivery, error) {
+	if _, err := uuid.Parse(deliveryID); err != nil {
+		return nil, nil
+	}
+
+	query := `SELECT ` + webhookColumns + ` FROM webhook_deliveries WHERE id = $1`
+
+	ctx, cancel := context.WithTimeout(ctx, queryTimeoutDuration)
+	defer cancel()
+
+	delivery, err := scanWebhookDelivery(r.db.QueryRowContext(ctx, query, deliveryID))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to get webhook delivery: %w", err)
+	}
+
+	return delivery, nil
+}
+
+func (r *PostgresWebhookRepository) List(ctx context.Context, filter domain.WebhookDeliveryFilter) ([]*domain.WebhookDelivery, error) {
+	conditions := []string{"TRUE"}
+	args := []any{}
+
+	if filter.CheckID != "" {
+		if _, err := uuid.Parse(filter.CheckID); err != nil {
+			return []*domain.WebhookDelivery{}, nil
+		}
+		args = append(args, filter.CheckID)
+		conditions = append(conditions, fmt.Sprintf("check_id = $%d", len(args)))
+	}
+	if filter.Status != "" {
+		args = append(args, filter.Status)
+		conditions = append(conditions, fmt.Sprintf("status = $%d", len(args)))
+	}
+	if filter.TenantID != "" {
+		args = append(args, filter.TenantID)
+		conditions = append(conditions, fmt.Sprintf("tenant_id = $%d", len(args)))
+	}
+
+	query := `SELECT ` + webhookColumns + ` FROM webhook_deliveries WHERE ` +
+		strings.Join(conditions, " AND ") + ` ORDER BY created_at DESC`
+
+	ctx, cancel := context.WithTimeout(ctx, queryTimeoutDuration)
+	defer cancel()
+
+	return r.query(ctx, query, args...)
+}
+
+func (r *PostgresWebhookRepository) Update(ctx context.Context, delivery *domain.WebhookDelivery) error {
+	query := `
+		UPDATE webhook_deliveries
+		SET status = $2, attempts = $3, last_status_code = $4, last_error = $5,
+			next_attempt_at = $6, updated_at = $7, delivered_at = $8
+		WHERE id = $1
+	`
+
+	ctx, cancel := context.WithTimeout(ctx, queryTimeoutDuration)
+	defer cancel()
+
+	res, err := r.db.ExecContext(
+		ctx,
+		query,
+		delivery.ID,
+		delivery.Status,
+		delivery.Attempts,
+		delivery.LastStatusCode,
+		delivery.LastError,
+		delivery.NextAttemptAt,
+		delivery.UpdatedAt,
+		delivery.DeliveredAt,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to update webhook delivery: %w", err)
+	}
+
+	if err := requireAffected(res, "webhook delivery not found"); err != nil {
+		return domain.ErrWebhookDeliveryNotFound
+	}
+
+	r.logger.Debugw("webhook delivery updated", "delivery_id", delivery.ID, "status", delivery.Status)
+
+	return nil
+}
+
+func (r *PostgresWebhookRepository) ClaimDue(ctx context.Context, now time.Time, limit int, lease time.Duration) ([]*domain.WebhookDelivery, error) {
+	query := `
+		UPDATE webhook_deliveries
+		SET next_attempt_at = $2
+		WHERE id IN (
+			SELECT id FROM webhook_deliveries
+			WHERE status = 'pending' AND next_attempt_at <= $3
+			ORDER BY next_attempt_at
+			LIMIT $1
+			FOR UPDATE SKIP LOCKED
+		)
+		RETURNING ` + webhookColumns
+
+	ctx, cancel := context.WithTimeout(ctx, queryTimeoutDuration)
+	defer cancel()
+
+	return r.query(ctx, query, limit, now.Add(lease), now)
+}
+
+func (r *PostgresWebhookRepository) query(ctx context.Context, query string, args ...any) ([]*domain.WebhookDelivery, error) {
+	rows, err := r.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query webhook deliveries: %w", err)
+	}
+	defer rows.Close()
+
+	deliveries := make([]*domain.WebhookDelivery, 0)
+	for rows.Next() {
+		delivery, err := scanWebhookDelivery(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan webhook delivery: %w", err)
+		}
+		deliveries = append(deliveries, delivery)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to query webhook deliveries: %w", err)
+	}
+
+	return deliveries, nil
+}
+
+func scanWebhookDelivery(row scanner) (*domain.WebhookDelivery, error) {
+	var delivery domain.WebhookDelivery
+
+	err := row.Scan(
+		&delivery.ID,
+		&delivery.CheckID,
+		&delivery.EventType,
+		&delivery.URL,
+		&delivery.Payload,
+		&delivery.Status,
+		&delivery.Attempts,
+		&delivery.LastStatusCode,
+		&delivery.LastError,
+		&delivery.NextAttemptAt,
+		&delivery.CreatedAt,
+		&delivery.UpdatedAt,
+		&delivery.DeliveredAt,
+		&delivery.TenantID,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return &delivery, nil
+}
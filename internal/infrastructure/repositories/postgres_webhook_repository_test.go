@@ -0,0 +1,35 @@
+package repositories
+
+import (
+	"context"
+	"testing"
+
+	"go.uber.org/zap"
+)
+
+func newTestPostgresWebhookRepository(t *testing.T) *PostgresWebhookRepository {
+	t.Helper()
+
+	conn := newTestDB(t)
+	if _, err := conn.ExecContext(context.Background(), "TRUNCATE webhook_deliveries"); err != nil {
+		t.Fatalf("failed to truncate webhook_deliveries: %v", err)
+	}
+
+	return NewPostgresWebhookRepository(conn, zap.NewNop().Sugar())
+}
+
+func TestPostgresWebhookRepository_Lifecycle(t *testing.T) {
+	testWebhookRepositoryLifecycle(t, newTestPostgresWebhookRepository(t))
+}
+
+func TestPostgresWebhookRepository_CreateDuplicate(t *testing.T) {
+	testWebhookRepositoryCreateDuplicate(t, newTestPostgresWebhookRepository(t))
+}
+
+func TestPostgresWebhookRepository_List(t *testing.T) {
+	testWebhookRepositoryList(t, newTestPostgresWebhookRepository(t))
+}
+
+func TestPostgresWebhookRepository_ClaimDue(t *testing.T) {
+	testWebhookRepositoryClaimDue(t, newTestPostgresWebhookRepository(t))
+}
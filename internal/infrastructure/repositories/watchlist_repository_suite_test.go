@@ -0,0 +1,132 @@
+package repositories
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/Beka01247/bitpanda-aml/internal/domain"
+)
+
+// shared behaviour every domain.WatchlistRepository implementation must satisfy
+
+func testWatchlistRepositoryCRUD(t *testing.T, repo domain.WatchlistRepository) {
+	ctx := context.Background()
+
+	entry := domain.NewWatchlistEntry("0xabc", "ETH", domain.WatchlistBlocklist, "fraud report", "analyst@example.com", nil)
+
+	if err := repo.Create(ctx, entry); err != nil {
+		t.Fatalf("Create() error = %v", err)
+	}
+
+	got, err := repo.Get(ctx, entry.ID)
+	if err != nil {
+		t.Fatalf("Get() error = %v", err)
+	}
+	if got == nil {
+		t.Fatal("Get() returned nil")
+	}
+	if got.Address != entry.Address || got.ListType != domain.WatchlistBlocklist || got.Reason != entry.Reason {
+		t.Errorf("Get() = %+v, want %+v", got, entry)
+	}
+
+	entry.Reason = "confirmed fraud"
+	entry.UpdatedAt = time.Now().UTC()
+	if err := repo.Update(ctx, entry); err != nil {
+		t.Fatalf("Update() error = %v", err)
+	}
+
+	got, _ = repo.Get(ctx, entry.ID)
+	if got.Reason != "confirmed fraud" {
+		t.Errorf("Get() after update Reason = %v, want confirmed fraud", got.Reason)
+	}
+
+	if err := repo.Delete(ctx, entry.ID); err != nil {
+		t.Fatalf("Delete() error = %v", err)
+	}
+
+	got, err = repo.Get(ctx, entry.ID)
+	if err != nil {
+		t.Fatalf("Get() error = %v", err)
+	}
+	if got != nil {
+		t.Error("Get() after delete should return nil")
+	}
+
+	if err := repo.Delete(ctx, entry.ID); !errors.Is(err, domain.ErrWatchlistEntryNotFound) {
+		t.Errorf("Delete() missing error = %v, want %v", err, domain.ErrWatchlistEntryNotFound)
+	}
+
+	if err := repo.Update(ctx, entry); !errors.Is(err, domain.ErrWatchlistEntryNotFound) {
+		t.Errorf("Update() missing error = %v, want %v", err, domain.ErrWatchlistEntryNotFound)
+	}
+}
+
+func testWatchlistRepositoryListAndMatch(t *testing.T, repo domain.WatchlistRepository) {
+	ctx := context.Background()
+	now := time.Now().UTC()
+	past := now.Add(-time.Hour)
+
+	allow := domain.NewWatchlistEntry("bc1qtest", "BTC", domain.WatchlistAllowlist, "exchange hot wallet", "ops", nil)
+	block := domain.NewWatchlistEntry("bc1qtest", "BTC", domain.WatchlistBlocklist, "scam", "analyst", nil)
+	expired := domain.NewWatchlistEntry("0xdef", "ETH", domain.WatchlistBlocklist, "old case", "analyst", &past)
+	other := domain.NewWatchlistEntry("0xdef", "BSC", domain.WatchlistAllowlist, "partner", "ops", nil)
+
+	for _, entry := range []*domain.WatchlistEntry{allow, block, expired, other} {
+		if err := repo.Create(ctx, entry); err != nil {
+			t.Fatalf("Create() error = %v", err)
+		}
+	}
+
+	tests := []struct {
+		name   string
+		filter domain.WatchlistFilter
+		want   int
+	}{
+		{name: "no filter", filter: domain.WatchlistFilter{}, want: 4},
+		{name: "by address", filter: domain.WatchlistFilter{Address: "0xdef"}, want: 2},
+		{name: "by chain", filter: domain.WatchlistFilter{Chain: "BTC"}, want: 2},
+		{name: "by list type", filter: domain.WatchlistFilter{ListType: domain.WatchlistAllowlist}, want: 2},
+		{name: "combined", filter: domain.WatchlistFilter{Chain: "ETH", ListType: domain.WatchlistBlocklist}, want: 1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			entries, err := repo.List(ctx, tt.filter)
+			if err != nil {
+				t.Fatalf("List() error = %v", err)
+			}
+			if len(entries) != tt.want {
+				t.Errorf("List() length = %v, want %v", len(entries), tt.want)
+			}
+		})
+	}
+
+	// blocklist wins when both lists contain the address
+	match, err := repo.Match(ctx, "BTC", "bc1qtest", now)
+	if err != nil {
+		t.Fatalf("Match() error = %v", err)
+	}
+	if match == nil || match.ID != block.ID {
+		t.Errorf("Match() = %+v, want blocklist entry %v", match, block.ID)
+	}
+
+	// expired entries never match
+	match, err = repo.Match(ctx, "ETH", "0xdef", now)
+	if err != nil {
+		t.Fatalf("Match() error = %v", err)
+	}
+	if match != nil {
+		t.Errorf("Match() expired entry = %+v, want nil", match)
+	}
+
+	// matches are scoped by chain
+	match, err = repo.Match(ctx, "BSC", "0xdef", now)
+	if err != nil {
+		t.Fatalf("Match() error = %v", err)
+	}
+	if match == nil || match.ID != other.ID {
+		t.Errorf("Match() = %+v, want %v", match, other.ID)
+	}
+}
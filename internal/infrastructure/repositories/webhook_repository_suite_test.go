@@ -0,0 +1,171 @@
+package repositories
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/Beka01247/bitpanda-aml/internal/domain"
+	"github.com/google/uuid"
+)
+
+// shared behaviour every domain.WebhookDeliveryRepository implementation must satisfy
+
+func newTestWebhookDelivery(checkID string) *domain.WebhookDelivery {
+	return domain.NewWebhookDelivery("acme", checkID, domain.EventAMLReportReady, "https://client.example/hook", []byte(`{"status":"success"}`))
+}
+
+func testWebhookRepositoryLifecycle(t *testing.T, repo domain.WebhookDeliveryRepository) {
+	ctx := context.Background()
+
+	delivery := newTestWebhookDelivery(uuid.New().String())
+	if err := repo.Create(ctx, delivery); err != nil {
+		t.Fatalf("Create() error = %v", err)
+	}
+
+	got, err := repo.Get(ctx, delivery.ID)
+	if err != nil {
+		t.Fatalf("Get() error = %v", err)
+	}
+	if got == nil || got.CheckID != delivery.CheckID || string(got.Payload) != string(delivery.Payload) {
+		t.Fatalf("Get() = %+v", got)
+	}
+
+	retryAt := time.Now().UTC().Add(time.Minute)
+	got.MarkAttemptFailed(503, "unexpected status 503", &retryAt)
+	if err := repo.Update(ctx, got); err != nil {
+		t.Fatalf("Update() error = %v", err)
+	}
+
+	got, err = repo.Get(ctx, delivery.ID)
+	if err != nil {
+		t.Fatalf("Get() error = %v", err)
+	}
+	if got.Status != domain.WebhookDeliveryPending || got.Attempts != 1 || got.LastStatusCode != 503 {
+		t.Errorf("Get() after failed attempt = %+v", got)
+	}
+
+	got.MarkDelivered(204)
+	if err := repo.Update(ctx, got); err != nil {
+		t.Fatalf("Update() error = %v", err)
+	}
+
+	got, err = repo.Get(ctx, delivery.ID)
+	if err != nil {
+		t.Fatalf("Get() error = %v", err)
+	}
+	if got.Status != domain.WebhookDeliveryDelivered || got.DeliveredAt == nil || got.LastError != "" {
+		t.Errorf("Get() after delivery = %+v", got)
+	}
+
+	missing, err := repo.Get(ctx, uuid.New().String())
+	if err != nil || missing != nil {
+		t.Errorf("Get() missing = %+v, %v, want nil, nil", missing, err)
+	}
+
+	if err := repo.Update(ctx, newTestWebhookDelivery(uuid.New().String())); !errors.Is(err, domain.ErrWebhookDeliveryNotFound) {
+		t.Errorf("Update() missing error = %v, want %v", err, domain.ErrWebhookDeliveryNotFound)
+	}
+}
+
+func testWebhookRepositoryCreateDuplicate(t *testing.T, repo domain.WebhookDeliveryRepository) {
+	ctx := context.Background()
+	checkID := uuid.New().String()
+
+	if err := repo.Create(ctx, newTestWebhookDelivery(checkID)); err != nil {
+		t.Fatalf("Create() error = %v", err)
+	}
+	if err := repo.Create(ctx, newTestWebhookDelivery(checkID)); !errors.Is(err, domain.ErrWebhookDeliveryExists) {
+		t.Errorf("Create() same event error = %v, want %v", err, domain.ErrWebhookDeliveryExists)
+	}
+
+	failed := newTestWebhookDelivery(checkID)
+	failed.EventType = domain.EventAMLCheckFailed
+	if err := repo.Create(ctx, failed); err != nil {
+		t.Errorf("Create() other event error = %v", err)
+	}
+}
+
+func testWebhookRepositoryList(t *testing.T, repo domain.WebhookDeliveryRepository) {
+	ctx := context.Background()
+	checkID := uuid.New().String()
+
+	first := newTestWebhookDelivery(checkID)
+	second := newTestWebhookDelivery(checkID)
+	second.EventType = domain.EventAMLCheckFailed
+	second.CreatedAt = first.CreatedAt.Add(time.Second)
+	second.MarkDelivered(200)
+	other := newTestWebhookDelivery(uuid.New().String())
+
+	for _, delivery := range []*domain.WebhookDelivery{first, second, other} {
+		if err := repo.Create(ctx, delivery); err != nil {
+			t.Fatalf("Create() error = %v", err)
+		}
+	}
+
+	byCheck, err := repo.List(ctx, domain.WebhookDeliveryFilter{CheckID: checkID})
+	if err != nil {
+		t.Fatalf("List() error = %v", err)
+	}
+	if len(byCheck) != 2 || byCheck[0].ID != second.ID {
+		t.Errorf("List() by check = %d deliveries, want 2 newest first", len(byCheck))
+	}
+
+	delivered, err := repo.List(ctx, domain.WebhookDeliveryFilter{Status: domain.WebhookDeliveryDelivered})
+	if err != nil {
+		t.Fatalf("List() error = %v", err)
+	}
+	if len(delivered) != 1 || delivered[0].ID != second.ID {
+		t.Errorf("List() delivered = %d deliveries, want 1", len(delivered))
+	}
+
+	invalid, err := repo.List(ctx, domain.WebhookDeliveryFilter{CheckID: "not-a-uuid"})
+	if err != nil || len(invalid) != 0 {
+		t.Errorf("List() invalid check = %d, %v, want empty", len(invalid), err)
+	}
+}
+
+func testWebhookRepositoryClaimDue(t *testing.T, repo domain.WebhookDeliveryRepository) {
+	ctx := context.Background()
+	now := time.Now().UTC()
+
+	due := newTestWebhookDelivery(uuid.New().String())
+	due.NextAttemptAt = now.Add(-time.Minute)
+	later := newTestWebhookDelivery(uuid.New().String())
+	later.NextAttemptAt = now.Add(time.Hour)
+	done := newTestWebhookDelivery(uuid.New().String())
+	done.NextAttemptAt = now.Add(-time.Minute)
+	done.MarkDelivered(200)
+
+	for _, delivery := range []*domain.WebhookDelivery{due, later, done} {
+		if err := repo.Create(ctx, delivery); err != nil {
+			t.Fatalf("Create() error = %v", err)
+		}
+	}
+
+	claimed, err := repo.ClaimDue(ctx, now, 10, time.Minute)
+	if err != nil {
+		t.Fatalf("ClaimDue() error = %v", err)
+	}
+	if len(claimed) != 1 || claimed[0].ID != due.ID {
+		t.Fatalf("ClaimDue() = %d deliveries, want only the due one", len(claimed))
+	}
+
+	// the lease hides it from the next poll
+	claimed, err = repo.ClaimDue(ctx, now, 10, time.Minute)
+	if err != nil {
+		t.Fatalf("ClaimDue() error = %v", err)
+	}
+	if len(claimed) != 0 {
+		t.Errorf("ClaimDue() while leased = %d deliveries, want 0", len(claimed))
+	}
+
+	claimed, err = repo.ClaimDue(ctx, now.Add(2*time.Minute), 10, time.Minute)
+	if err != nil {
+		t.Fatalf("ClaimDue() error = %v", err)
+	}
+	if len(claimed) != 1 || claimed[0].ID != due.ID {
+		t.Errorf("ClaimDue() after lease = %d deliveries, want 1", len(claimed))
+	}
+}
@@ -0,0 +1,110 @@
+package webhook
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/Beka01247/bitpanda-aml/internal/domain"
+)
+
+func TestHTTPSender_Send(t *testing.T) {
+	secret := "test-secret"
+	payload := []byte(`{"check_id":"1","status":"success"}`)
+
+	var verified bool
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		verified = Verify([]byte(secret), r.Header.Get(HeaderTimestamp), body, r.Header.Get(HeaderSignature))
+		if r.Header.Get(HeaderEvent) != domain.EventAMLReportReady || r.Header.Get(HeaderDeliveryID) == "" {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer server.Close()
+
+	delivery := domain.NewWebhookDelivery("acme", "1", domain.EventAMLReportReady, server.URL, payload)
+	statusCode, err := newHTTPSender(secret, 0, nil).Send(context.Background(), delivery)
+	if err != nil {
+		t.Fatalf("Send() error = %v", err)
+	}
+	if statusCode != http.StatusNoContent {
+		t.Errorf("Send() status = %d, want %d", statusCode, http.StatusNoContent)
+	}
+	if !verified {
+		t.Error("signature did not verify with the shared secret")
+	}
+}
+
+func TestHTTPSender_SendErrors(t *testing.T) {
+	tests := []struct {
+		name       string
+		handler    http.HandlerFunc
+		wantStatus int
+	}{
+		{
+			name:       "server error",
+			handler:    func(w http.ResponseWriter, r *http.Request) { w.WriteHeader(http.StatusServiceUnavailable) },
+			wantStatus: http.StatusServiceUnavailable,
+		},
+		{
+			name: "redirect is not followed",
+			handler: func(w http.ResponseWriter, r *http.Request) {
+				http.Redirect(w, r, "https://elsewhere.example", http.StatusFound)
+			},
+			wantStatus: http.StatusFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := httptest.NewServer(tt.handler)
+			defer server.Close()
+
+			delivery := domain.NewWebhookDelivery("acme", "1", domain.EventAMLReportReady, server.URL, []byte(`{}`))
+			statusCode, err := newHTTPSender("secret", 0, nil).Send(context.Background(), delivery)
+			if err == nil {
+				t.Fatal("Send() error = nil, want error")
+			}
+			if statusCode != tt.wantStatus {
+				t.Errorf("Send() status = %d, want %d", statusCode, tt.wantStatus)
+			}
+		})
+	}
+}
+
+func TestHTTPSender_SendRefusesPrivateAddresses(t *testing.T) {
+	var called bool
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		called = true
+	}))
+	defer server.Close()
+
+	delivery := domain.NewWebhookDelivery("acme", "1", domain.EventAMLReportReady, server.URL, []byte(`{}`))
+	if _, err := NewHTTPSender("secret", 0).Send(context.Background(), delivery); !errors.Is(err, errNonPublicAddress) {
+		t.Errorf("Send() error = %v, want %v", err, errNonPublicAddress)
+	}
+	if called {
+		t.Error("loopback callback was called")
+	}
+}
+
+func TestVerify(t *testing.T) {
+	secret := []byte("secret")
+	body := []byte(`{"a":1}`)
+	signature := Sign(secret, "1700000000", body)
+
+	if !Verify(secret, "1700000000", body, signature) {
+		t.Error("Verify() = false for a valid signature")
+	}
+	if Verify(secret, "1700000001", body, signature) {
+		t.Error("Verify() = true for a different timestamp")
+	}
+	if Verify([]byte("other"), "1700000000", body, signature) {
+		t.Error("Verify() = true for a different secret")
+	}
+}
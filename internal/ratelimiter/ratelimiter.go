@@ -1,13 +1,172 @@
 package ratelimiter
 
-import "time"
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	AlgorithmTokenBucket   = "token_bucket"
+	AlgorithmSlidingWindow = "sliding_window"
+	// redis store only
+	AlgorithmFixedWindow = "fixed_window"
+
+	StoreMemory = "memory"
+	StoreRedis  = "redis"
+)
+
+// Requests per Window
+type Limit struct {
+	Requests int
+	Window   time.Duration
+}
+
+type Result struct {
+	Allowed   bool
+	Limit     int
+	Remaining int
+	// time until the client is back to its full budget
+	Reset time.Duration
+	// zero when the request was allowed
+	RetryAfter time.Duration
+}
 
 type Limiter interface {
-	Allow(ip string) (bool, time.Duration)
+	Allow(key string, limit Limit) Result
 }
 
 type Config struct {
 	RequestsPerTimeFrame int
 	TimeFrame            time.Duration
 	Enabled              bool
+	// token_bucket or sliding_window in memory, token_bucket or fixed_window with redis
+	Algorithm string
+	// memory or redis, redis shares quotas between replicas
+	Store string
+	// open falls back to the local limiter while redis is unreachable, closed rejects requests
+	FailurePolicy string
+	RedisTimeout  time.Duration
+	// clients tracked at once, the least recently seen ones are forgotten beyond it
+	MaxClients int
+	// "tenant:acme=600/1m,key:<key id>=10/1s"
+	Overrides string
+	// per client ip, counted before the api key is checked, zero means RequestsPerTimeFrame
+	IPRequestsPerTimeFrame int
+}
+
+func New(cfg Config) (Limiter, error) {
+	switch cfg.Algorithm {
+	case AlgorithmTokenBucket, "":
+		return NewTokenBucketLimiter(cfg.MaxClients), nil
+	case AlgorithmSlidingWindow:
+		return NewSlidingWindowLimiter(cfg.MaxClients), nil
+	case AlgorithmFixedWindow:
+		return nil, fmt.Errorf("rate limiter algorithm %s needs the redis store", cfg.Algorithm)
+	default:
+		return nil, fmt.Errorf("unknown rate limiter algorithm: %s", cfg.Algorithm)
+	}
+}
+
+// picks the bucket and limit for a request
+type Policy struct {
+	Default Limit
+	// keyed by "tenant:<tenant id>" or "key:<key id>"
+	Overrides map[string]Limit
+	// every request from one client ip, authenticated or not
+	IP Limit
+}
+
+func NewPolicy(cfg Config) (Policy, error) {
+	overrides, err := ParseOverrides(cfg.Overrides)
+	if err != nil {
+		return Policy{}, err
+	}
+
+	if cfg.RequestsPerTimeFrame <= 0 || cfg.TimeFrame <= 0 {
+		return Policy{}, fmt.Errorf("rate limit needs a positive request count and time frame")
+	}
+
+	ipRequests := cfg.IPRequestsPerTimeFrame
+	if ipRequests <= 0 {
+		ipRequests = cfg.RequestsPerTimeFrame
+	}
+
+	return Policy{
+		Default:   Limit{Requests: cfg.RequestsPerTimeFrame, Window: cfg.TimeFrame},
+		Overrides: overrides,
+		IP:        Limit{Requests: ipRequests, Window: cfg.TimeFrame},
+	}, nil
+}
+
+// a key override wins over its tenant's, keys of a tenant with an override share one bucket,
+// anonymous requests are limited per client ip with the ip limit
+func (p Policy) Resolve(keyID, tenantID, ip string) (string, Limit) {
+	if keyID != "" {
+		if limit, ok := p.Overrides["key:"+keyID]; ok {
+			return "key:" + keyID, limit
+		}
+	}
+
+	if tenantID != "" {
+		if limit, ok := p.Overrides["tenant:"+tenantID]; ok {
+			return "tenant:" + tenantID, limit
+		}
+	}
+
+	if keyID != "" {
+		return "key:" + keyID, p.Default
+	}
+
+	return "ip:" + ip, p.IP
+}
+
+func ParseOverrides(value string) (map[string]Limit, error) {
+	overrides := make(map[string]Limit)
+	for _, item := range strings.Split(value, ",") {
+		item = strings.TrimSpace(item)
+		if item == "" {
+			continue
+		}
+
+		subject, rawLimit, ok := strings.Cut(item, "=")
+		if !ok {
+			return nil, fmt.Errorf("invalid rate limit override: %s", item)
+		}
+
+		subject = strings.TrimSpace(subject)
+		kind, id, ok := strings.Cut(subject, ":")
+		if !ok || id == "" || (kind != "tenant" && kind != "key") {
+			return nil, fmt.Errorf("invalid rate limit override: %s", item)
+		}
+
+		limit, err := parseLimit(strings.TrimSpace(rawLimit))
+		if err != nil {
+			return nil, fmt.Errorf("invalid rate limit override: %s", item)
+		}
+
+		overrides[subject] = limit
+	}
+	return overrides, nil
+}
+
+// "600/1m"
+func parseLimit(value string) (Limit, error) {
+	rawRequests, rawWindow, ok := strings.Cut(value, "/")
+	if !ok {
+		return Limit{}, fmt.Errorf("missing window")
+	}
+
+	requests, err := strconv.Atoi(rawRequests)
+	if err != nil || requests <= 0 {
+		return Limit{}, fmt.Errorf("invalid request count")
+	}
+
+	window, err := time.ParseDuration(rawWindow)
+	if err != nil || window <= 0 {
+		return Limit{}, fmt.Errorf("invalid window")
+	}
+
+	return Limit{Requests: requests, Window: window}, nil
 }
@@ -0,0 +1,66 @@
+package ratelimiter
+
+import (
+	"testing"
+	"time"
+)
+
+func TestParseOverrides(t *testing.T) {
+	overrides, err := ParseOverrides("tenant:acme=600/1m, key:3f2a=10/1s")
+	if err != nil {
+		t.Fatalf("ParseOverrides() error = %v", err)
+	}
+
+	want := map[string]Limit{
+		"tenant:acme": {Requests: 600, Window: time.Minute},
+		"key:3f2a":    {Requests: 10, Window: time.Second},
+	}
+	if len(overrides) != len(want) {
+		t.Fatalf("got %d overrides, want %d", len(overrides), len(want))
+	}
+	for subject, limit := range want {
+		if overrides[subject] != limit {
+			t.Errorf("overrides[%s] = %+v, want %+v", subject, overrides[subject], limit)
+		}
+	}
+
+	for _, value := range []string{"acme=1/1s", "ip:1.2.3.4=1/1s", "tenant:=1/1s", "tenant:acme=1", "tenant:acme=0/1s", "tenant:acme=1/soon"} {
+		if _, err := ParseOverrides(value); err == nil {
+			t.Errorf("ParseOverrides(%q) want error", value)
+		}
+	}
+}
+
+func TestPolicyResolve(t *testing.T) {
+	policy, err := NewPolicy(Config{
+		RequestsPerTimeFrame:   20,
+		TimeFrame:              5 * time.Second,
+		Overrides:              "tenant:acme=600/1m,key:vip=10/1s",
+		IPRequestsPerTimeFrame: 100,
+	})
+	if err != nil {
+		t.Fatalf("NewPolicy() error = %v", err)
+	}
+
+	tests := []struct {
+		name       string
+		keyID      string
+		tenantID   string
+		wantBucket string
+		wantLimit  Limit
+	}{
+		{"anonymous", "", "", "ip:10.0.0.1", Limit{Requests: 100, Window: 5 * time.Second}},
+		{"key without override", "k1", "other", "key:k1", policy.Default},
+		{"tenant override is shared", "k1", "acme", "tenant:acme", Limit{Requests: 600, Window: time.Minute}},
+		{"key override wins", "vip", "acme", "key:vip", Limit{Requests: 10, Window: time.Second}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			bucket, limit := policy.Resolve(tt.keyID, tt.tenantID, "10.0.0.1")
+			if bucket != tt.wantBucket || limit != tt.wantLimit {
+				t.Errorf("Resolve() = %s %+v, want %s %+v", bucket, limit, tt.wantBucket, tt.wantLimit)
+			}
+		})
+	}
+}
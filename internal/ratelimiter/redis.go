@@ -0,0 +1,251 @@
+package ratelimiter
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+const (
+	FailOpen   = "open"
+	FailClosed = "closed"
+)
+
+// runs a Lua script atomically, *redis.Client satisfies it
+type ScriptRunner interface {
+	Eval(ctx context.Context, script string, keys []string, args ...string) (any, error)
+}
+
+// KEYS[1] counter, ARGV[1] window in ms, returns {count, ms until the window ends}
+const fixedWindowScript = `
+local count = redis.call('INCR', KEYS[1])
+local ttl = redis.call('PTTL', KEYS[1])
+if ttl < 0 then
+	redis.call('PEXPIRE', KEYS[1], ARGV[1])
+	ttl = tonumber(ARGV[1])
+end
+return {count, ttl}
+`
+
+// KEYS[1] bucket, ARGV[1] capacity, ARGV[2] window in ms, uses the server clock so replicas agree,
+// returns {allowed, remaining, ms until full, ms until the next token}
+const tokenBucketScript = `
+local capacity = tonumber(ARGV[1])
+local window = tonumber(ARGV[2])
+local rate = capacity / window
+local time = redis.call('TIME')
+local now = tonumber(time[1]) * 1000 + math.floor(tonumber(time[2]) / 1000)
+
+local state = redis.call('HMGET', KEYS[1], 'tokens', 'ts')
+local tokens = tonumber(state[1])
+local ts = tonumber(state[2])
+if tokens == nil or ts == nil then
+	tokens = capacity
+elseif now > ts then
+	tokens = math.min(capacity, tokens + (now - ts) * rate)
+end
+
+local allowed = 0
+local retry = 0
+if tokens >= 1 then
+	tokens = tokens - 1
+	allowed = 1
+else
+	retry = math.ceil((1 - tokens) / rate)
+end
+
+redis.call('HSET', KEYS[1], 'tokens', tostring(tokens), 'ts', now)
+-- an idle bucket is full again after a window, dropping it is the same
+redis.call('PEXPIRE', KEYS[1], window)
+
+return {allowed, math.floor(tokens), math.ceil((capacity - tokens) / rate), retry}
+`
+
+// shares quotas between replicas, while redis is unreachable it falls back to the local
+// limiter (fail open) or rejects requests (fail closed)
+type RedisLimiter struct {
+	client    ScriptRunner
+	algorithm string
+	prefix    string
+	timeout   time.Duration
+	failOpen  bool
+	fallback  Limiter
+	// how long to skip redis after it failed
+	retryInterval time.Duration
+	logger        *zap.SugaredLogger
+
+	mu        sync.Mutex
+	downUntil time.Time
+	now       func() time.Time
+}
+
+func NewRedisLimiter(client ScriptRunner, cfg Config, logger *zap.SugaredLogger) (*RedisLimiter, error) {
+	local := cfg
+	switch cfg.Algorithm {
+	case AlgorithmTokenBucket, "":
+		local.Algorithm = AlgorithmTokenBucket
+	case AlgorithmFixedWindow:
+		// never lets through more than the shared fixed window would
+		local.Algorithm = AlgorithmSlidingWindow
+	default:
+		return nil, fmt.Errorf("rate limiter algorithm %s is not supported with redis", cfg.Algorithm)
+	}
+
+	switch cfg.FailurePolicy {
+	case FailOpen, FailClosed, "":
+	default:
+		return nil, fmt.Errorf("unknown rate limiter failure policy: %s", cfg.FailurePolicy)
+	}
+
+	fallback, err := New(local)
+	if err != nil {
+		return nil, err
+	}
+
+	timeout := cfg.RedisTimeout
+	if timeout <= 0 {
+		timeout = 100 * time.Millisecond
+	}
+
+	return &RedisLimiter{
+		client:        client,
+		algorithm:     local.Algorithm,
+		prefix:        "ratelimit:",
+		timeout:       timeout,
+		failOpen:      cfg.FailurePolicy != FailClosed,
+		fallback:      fallback,
+		retryInterval: time.Second,
+		logger:        logger,
+		now:           time.Now,
+	}, nil
+}
+
+func (l *RedisLimiter) Allow(key string, limit Limit) Result {
+	if l.isDown() {
+		return l.degraded(key, limit)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), l.timeout)
+	defer cancel()
+
+	var result Result
+	var err error
+	if l.algorithm == AlgorithmTokenBucket {
+		result, err = l.tokenBucket(ctx, key, limit)
+	} else {
+		result, err = l.fixedWindow(ctx, key, limit)
+	}
+	if err != nil {
+		l.markDown(err)
+		return l.degraded(key, limit)
+	}
+
+	l.markUp()
+	return result
+}
+
+func (l *RedisLimiter) fixedWindow(ctx context.Context, key string, limit Limit) (Result, error) {
+	reply, err := l.client.Eval(ctx, fixedWindowScript, []string{l.prefix + "fw:" + key},
+		strconv.FormatInt(limit.Window.Milliseconds(), 10))
+	if err != nil {
+		return Result{}, err
+	}
+
+	values, err := intReply(reply, 2)
+	if err != nil {
+		return Result{}, err
+	}
+	count, ttl := int(values[0]), time.Duration(values[1])*time.Millisecond
+
+	result := Result{
+		Allowed:   count <= limit.Requests,
+		Limit:     limit.Requests,
+		Remaining: max(0, limit.Requests-count),
+		Reset:     ttl,
+	}
+	if !result.Allowed {
+		result.RetryAfter = ttl
+	}
+	return result, nil
+}
+
+func (l *RedisLimiter) tokenBucket(ctx context.Context, key string, limit Limit) (Result, error) {
+	reply, err := l.client.Eval(ctx, tokenBucketScript, []string{l.prefix + "tb:" + key},
+		strconv.Itoa(limit.Requests),
+		strconv.FormatInt(limit.Window.Milliseconds(), 10))
+	if err != nil {
+		return Result{}, err
+	}
+
+	values, err := intReply(reply, 4)
+	if err != nil {
+		return Result{}, err
+	}
+
+	return Result{
+		Allowed:    values[0] == 1,
+		Limit:      limit.Requests,
+		Remaining:  int(values[1]),
+		Reset:      time.Duration(values[2]) * time.Millisecond,
+		RetryAfter: time.Duration(values[3]) * time.Millisecond,
+	}, nil
+}
+
+func (l *RedisLimiter) degraded(key string, limit Limit) Result {
+	if l.failOpen {
+		return l.fallback.Allow(key, limit)
+	}
+
+	return Result{
+		Limit:      limit.Requests,
+		Reset:      l.retryInterval,
+		RetryAfter: l.retryInterval,
+	}
+}
+
+func (l *RedisLimiter) isDown() bool {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return l.now().Before(l.downUntil)
+}
+
+func (l *RedisLimiter) markDown(err error) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if l.downUntil.IsZero() {
+		l.logger.Warnw("redis rate limiter unavailable, degrading", "fail_open", l.failOpen, "error", err)
+	}
+	l.downUntil = l.now().Add(l.retryInterval)
+}
+
+func (l *RedisLimiter) markUp() {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if !l.downUntil.IsZero() {
+		l.logger.Info("redis rate limiter recovered")
+		l.downUntil = time.Time{}
+	}
+}
+
+func intReply(reply any, n int) ([]int64, error) {
+	items, ok := reply.([]any)
+	if !ok || len(items) != n {
+		return nil, fmt.Errorf("unexpected rate limiter reply: %v", reply)
+	}
+
+	values := make([]int64, n)
+	for i, item := range items {
+		value, ok := item.(int64)
+		if !ok {
+			return nil, fmt.Errorf("unexpected rate limiter reply: %v", reply)
+		}
+		values[i] = value
+	}
+	return values, nil
+}
@@ -0,0 +1,147 @@
+package ratelimiter
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/Beka01247/bitpanda-aml/internal/infrastructure/redis"
+	"github.com/alicebob/miniredis/v2"
+	"go.uber.org/zap"
+)
+
+type stubScriptRunner struct {
+	calls int
+	reply any
+	err   error
+}
+
+func (s *stubScriptRunner) Eval(ctx context.Context, script string, keys []string, args ...string) (any, error) {
+	s.calls++
+	return s.reply, s.err
+}
+
+func newTestRedisLimiter(t *testing.T, client ScriptRunner, algorithm, failurePolicy string) *RedisLimiter {
+	t.Helper()
+
+	limiter, err := NewRedisLimiter(client, Config{Algorithm: algorithm, FailurePolicy: failurePolicy, MaxClients: 10}, zap.NewNop().Sugar())
+	if err != nil {
+		t.Fatalf("NewRedisLimiter() error = %v", err)
+	}
+	return limiter
+}
+
+func TestRedisLimiterParsesReplies(t *testing.T) {
+	limit := Limit{Requests: 5, Window: time.Minute}
+
+	client := &stubScriptRunner{reply: []any{int64(1), int64(3), int64(24000), int64(0)}}
+	result := newTestRedisLimiter(t, client, AlgorithmTokenBucket, FailOpen).Allow("key:a", limit)
+	want := Result{Allowed: true, Limit: 5, Remaining: 3, Reset: 24 * time.Second}
+	if result != want {
+		t.Errorf("token bucket result = %+v, want %+v", result, want)
+	}
+
+	client = &stubScriptRunner{reply: []any{int64(6), int64(1500)}}
+	result = newTestRedisLimiter(t, client, AlgorithmFixedWindow, FailOpen).Allow("key:a", limit)
+	want = Result{Limit: 5, Reset: 1500 * time.Millisecond, RetryAfter: 1500 * time.Millisecond}
+	if result != want {
+		t.Errorf("fixed window result = %+v, want %+v", result, want)
+	}
+}
+
+func TestRedisLimiterFailOpenUsesLocalLimiter(t *testing.T) {
+	client := &stubScriptRunner{err: errors.New("connection refused")}
+	limiter := newTestRedisLimiter(t, client, AlgorithmTokenBucket, FailOpen)
+	clock := newFakeClock()
+	limiter.now = clock.Now
+	limit := Limit{Requests: 2, Window: time.Minute}
+
+	var allowed int
+	for range 3 {
+		if limiter.Allow("key:a", limit).Allowed {
+			allowed++
+		}
+	}
+	if allowed != 2 {
+		t.Errorf("allowed %d, want the local limit of 2", allowed)
+	}
+
+	// redis is skipped until the retry interval passes
+	if client.calls != 1 {
+		t.Errorf("redis called %d times, want 1", client.calls)
+	}
+
+	clock.Advance(limiter.retryInterval)
+	client.err = nil
+	client.reply = []any{int64(1), int64(1), int64(1000), int64(0)}
+	if !limiter.Allow("key:a", limit).Allowed || client.calls != 2 {
+		t.Error("limiter didn't go back to redis after the retry interval")
+	}
+}
+
+func TestRedisLimiterFailClosedRejects(t *testing.T) {
+	client := &stubScriptRunner{reply: "garbage"}
+	limiter := newTestRedisLimiter(t, client, AlgorithmTokenBucket, FailClosed)
+
+	result := limiter.Allow("key:a", Limit{Requests: 2, Window: time.Minute})
+	if result.Allowed {
+		t.Fatal("request allowed while redis is failing, want rejected")
+	}
+	if result.RetryAfter != limiter.retryInterval {
+		t.Errorf("retry after = %v, want %v", result.RetryAfter, limiter.retryInterval)
+	}
+}
+
+func TestNewRedisLimiterRejectsUnsupportedConfig(t *testing.T) {
+	logger := zap.NewNop().Sugar()
+
+	if _, err := NewRedisLimiter(&stubScriptRunner{}, Config{Algorithm: AlgorithmSlidingWindow}, logger); err == nil {
+		t.Error("sliding_window with redis want error")
+	}
+	if _, err := NewRedisLimiter(&stubScriptRunner{}, Config{FailurePolicy: "sometimes"}, logger); err == nil {
+		t.Error("unknown failure policy want error")
+	}
+}
+
+// runs the Lua scripts against an in-process redis
+func TestRedisLimiterScripts(t *testing.T) {
+	client := redis.NewClient(redis.Config{Addr: miniredis.RunT(t).Addr()})
+	defer client.Close()
+	if err := client.Ping(context.Background()); err != nil {
+		t.Fatalf("failed to reach redis: %v", err)
+	}
+
+	for _, algorithm := range []string{AlgorithmTokenBucket, AlgorithmFixedWindow} {
+		t.Run(algorithm, func(t *testing.T) {
+			// FailClosed so a script error can't hide behind the local limiter
+			limiter := newTestRedisLimiter(t, client, algorithm, FailClosed)
+			limiter.prefix = fmt.Sprintf("ratelimit-test:%d:", time.Now().UnixNano())
+			limit := Limit{Requests: 3, Window: time.Minute}
+
+			var results []Result
+			for range 4 {
+				results = append(results, limiter.Allow("key:a", limit))
+			}
+
+			var got []string
+			for _, result := range results {
+				got = append(got, fmt.Sprintf("%v/%d", result.Allowed, result.Remaining))
+			}
+			if strings.Join(got, " ") != "true/2 true/1 true/0 false/0" {
+				t.Errorf("results = %s", strings.Join(got, " "))
+			}
+
+			denied := results[3]
+			if denied.RetryAfter <= 0 || denied.RetryAfter > limit.Window {
+				t.Errorf("retry after = %v, want within the window", denied.RetryAfter)
+			}
+
+			if !limiter.Allow("key:b", limit).Allowed {
+				t.Error("other client denied, want its own quota")
+			}
+		})
+	}
+}
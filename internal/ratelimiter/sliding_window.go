@@ -0,0 +1,58 @@
+package ratelimiter
+
+import (
+	"sync"
+	"time"
+)
+
+// keeps the timestamps of the requests allowed in the last Window, at most Requests per client
+type SlidingWindowLimiter struct {
+	mu   sync.Mutex
+	logs *lruStore[requestLog]
+	now  func() time.Time
+}
+
+type requestLog struct {
+	times []time.Time
+}
+
+func NewSlidingWindowLimiter(maxClients int) *SlidingWindowLimiter {
+	return &SlidingWindowLimiter{
+		logs: newLRUStore[requestLog](maxClients),
+		now:  time.Now,
+	}
+}
+
+func (l *SlidingWindowLimiter) Allow(key string, limit Limit) Result {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	now := l.now()
+	log := l.logs.get(key)
+
+	// drop what fell out of the window, reusing the backing array
+	cutoff := now.Add(-limit.Window)
+	expired := 0
+	for expired < len(log.times) && !log.times[expired].After(cutoff) {
+		expired++
+	}
+	log.times = log.times[:copy(log.times, log.times[expired:])]
+
+	// the limit may have shrunk since the log was written
+	if len(log.times) > limit.Requests {
+		log.times = log.times[:copy(log.times, log.times[len(log.times)-limit.Requests:])]
+	}
+
+	result := Result{Limit: limit.Requests}
+	if len(log.times) < limit.Requests {
+		log.times = append(log.times, now)
+		result.Allowed = true
+	} else {
+		result.RetryAfter = log.times[0].Add(limit.Window).Sub(now)
+	}
+
+	result.Remaining = limit.Requests - len(log.times)
+	result.Reset = log.times[len(log.times)-1].Add(limit.Window).Sub(now)
+
+	return result
+}
@@ -0,0 +1,61 @@
+package ratelimiter
+
+import (
+	"testing"
+	"time"
+)
+
+func TestSlidingWindowLimiter(t *testing.T) {
+	clock := newFakeClock()
+	limiter := NewSlidingWindowLimiter(10)
+	limiter.now = clock.Now
+	limit := Limit{Requests: 2, Window: 10 * time.Second}
+
+	if !limiter.Allow("key:a", limit).Allowed {
+		t.Fatal("1st request denied")
+	}
+
+	clock.Advance(4 * time.Second)
+	result := limiter.Allow("key:a", limit)
+	if !result.Allowed || result.Remaining != 0 {
+		t.Fatalf("2nd request = %+v, want allowed with nothing remaining", result)
+	}
+	if result.Reset != 10*time.Second {
+		t.Errorf("reset = %v, want 10s", result.Reset)
+	}
+
+	clock.Advance(4 * time.Second)
+	result = limiter.Allow("key:a", limit)
+	if result.Allowed {
+		t.Fatal("3rd request inside the window allowed")
+	}
+	// the 1st request leaves the window 10s after it was made
+	if result.RetryAfter != 2*time.Second {
+		t.Errorf("retry after = %v, want 2s", result.RetryAfter)
+	}
+
+	clock.Advance(2 * time.Second)
+	result = limiter.Allow("key:a", limit)
+	if !result.Allowed {
+		t.Fatal("request after the oldest one expired denied")
+	}
+	if limiter.Allow("key:a", limit).Allowed {
+		t.Error("request past the limit allowed")
+	}
+}
+
+func TestSlidingWindowLimiterKeepsAtMostLimitEntries(t *testing.T) {
+	clock := newFakeClock()
+	limiter := NewSlidingWindowLimiter(10)
+	limiter.now = clock.Now
+	limit := Limit{Requests: 3, Window: time.Minute}
+
+	for range 100 {
+		limiter.Allow("key:a", limit)
+		clock.Advance(time.Millisecond)
+	}
+
+	if n := len(limiter.logs.get("key:a").times); n != 3 {
+		t.Errorf("log holds %d entries, want 3", n)
+	}
+}
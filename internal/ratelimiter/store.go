@@ -0,0 +1,49 @@
+package ratelimiter
+
+import "container/list"
+
+// keeps at most maxKeys entries and drops the least recently used one beyond it,
+// callers hold the limiter's lock
+type lruStore[T any] struct {
+	maxKeys int
+	order   *list.List
+	entries map[string]*list.Element
+}
+
+type lruEntry[T any] struct {
+	key   string
+	value *T
+}
+
+func newLRUStore[T any](maxKeys int) *lruStore[T] {
+	if maxKeys <= 0 {
+		maxKeys = 100000
+	}
+	return &lruStore[T]{
+		maxKeys: maxKeys,
+		order:   list.New(),
+		entries: make(map[string]*list.Element),
+	}
+}
+
+// returns the entry for key, creating a zero one when missing
+func (s *lruStore[T]) get(key string) *T {
+	if element, ok := s.entries[key]; ok {
+		s.order.MoveToFront(element)
+		return element.Value.(*lruEntry[T]).value
+	}
+
+	if s.order.Len() >= s.maxKeys {
+		oldest := s.order.Back()
+		s.order.Remove(oldest)
+		delete(s.entries, oldest.Value.(*lruEntry[T]).key)
+	}
+
+	entry := &lruEntry[T]{key: key, value: new(T)}
+	s.entries[key] = s.order.PushFront(entry)
+	return entry.value
+}
+
+func (s *lruStore[T]) len() int {
+	return s.order.Len()
+}
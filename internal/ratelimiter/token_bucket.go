@@ -0,0 +1,60 @@
+package ratelimiter
+
+import (
+	"sync"
+	"time"
+)
+
+// refills Requests tokens per Window, a client can burst up to Requests at once
+type TokenBucketLimiter struct {
+	mu      sync.Mutex
+	buckets *lruStore[tokenBucket]
+	now     func() time.Time
+}
+
+type tokenBucket struct {
+	tokens float64
+	last   time.Time
+}
+
+func NewTokenBucketLimiter(maxClients int) *TokenBucketLimiter {
+	return &TokenBucketLimiter{
+		buckets: newLRUStore[tokenBucket](maxClients),
+		now:     time.Now,
+	}
+}
+
+func (l *TokenBucketLimiter) Allow(key string, limit Limit) Result {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	now := l.now()
+	capacity := float64(limit.Requests)
+	// tokens per second
+	rate := capacity / limit.Window.Seconds()
+
+	bucket := l.buckets.get(key)
+	if bucket.last.IsZero() {
+		bucket.tokens = capacity
+	} else if elapsed := now.Sub(bucket.last); elapsed > 0 {
+		bucket.tokens = min(capacity, bucket.tokens+elapsed.Seconds()*rate)
+	}
+	bucket.last = now
+
+	result := Result{Limit: limit.Requests}
+	if bucket.tokens >= 1 {
+		bucket.tokens--
+		result.Allowed = true
+	} else {
+		result.RetryAfter = secondsToDuration((1 - bucket.tokens) / rate)
+	}
+
+	result.Remaining = int(bucket.tokens)
+	result.Reset = secondsToDuration((capacity - bucket.tokens) / rate)
+
+	return result
+}
+
+func secondsToDuration(seconds float64) time.Duration {
+	return time.Duration(seconds * float64(time.Second))
+}
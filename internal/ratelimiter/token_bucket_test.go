@@ -0,0 +1,99 @@
+package ratelimiter
+
+import (
+	"testing"
+	"time"
+)
+
+type fakeClock struct {
+	now time.Time
+}
+
+func (c *fakeClock) Now() time.Time { return c.now }
+
+func (c *fakeClock) Advance(d time.Duration) { c.now = c.now.Add(d) }
+
+func newFakeClock() *fakeClock {
+	return &fakeClock{now: time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)}
+}
+
+func TestTokenBucketLimiter(t *testing.T) {
+	clock := newFakeClock()
+	limiter := NewTokenBucketLimiter(10)
+	limiter.now = clock.Now
+	limit := Limit{Requests: 3, Window: 3 * time.Second}
+
+	for i := range 3 {
+		result := limiter.Allow("key:a", limit)
+		if !result.Allowed {
+			t.Fatalf("request %d denied, want the burst allowed", i+1)
+		}
+		if result.Remaining != 2-i {
+			t.Errorf("request %d remaining = %d, want %d", i+1, result.Remaining, 2-i)
+		}
+	}
+
+	result := limiter.Allow("key:a", limit)
+	if result.Allowed {
+		t.Fatal("4th request allowed, want denied")
+	}
+	if result.RetryAfter != time.Second {
+		t.Errorf("retry after = %v, want 1s", result.RetryAfter)
+	}
+	if result.Reset != 3*time.Second {
+		t.Errorf("reset = %v, want 3s", result.Reset)
+	}
+
+	if !limiter.Allow("key:b", limit).Allowed {
+		t.Error("other client denied, want its own bucket")
+	}
+
+	clock.Advance(time.Second)
+	if !limiter.Allow("key:a", limit).Allowed {
+		t.Error("request after one refill denied")
+	}
+	if limiter.Allow("key:a", limit).Allowed {
+		t.Error("second request after one refill allowed")
+	}
+}
+
+func TestTokenBucketLimiterRefillIsCapped(t *testing.T) {
+	clock := newFakeClock()
+	limiter := NewTokenBucketLimiter(10)
+	limiter.now = clock.Now
+	limit := Limit{Requests: 2, Window: time.Second}
+
+	limiter.Allow("key:a", limit)
+	clock.Advance(time.Hour)
+
+	allowed := 0
+	for range 5 {
+		if limiter.Allow("key:a", limit).Allowed {
+			allowed++
+		}
+	}
+	if allowed != 2 {
+		t.Errorf("allowed %d after a long pause, want the burst of 2", allowed)
+	}
+}
+
+func TestTokenBucketLimiterBoundsClients(t *testing.T) {
+	limiter := NewTokenBucketLimiter(2)
+	limit := Limit{Requests: 1, Window: time.Minute}
+
+	limiter.Allow("ip:1", limit)
+	limiter.Allow("ip:2", limit)
+	limiter.Allow("ip:3", limit)
+
+	if n := limiter.buckets.len(); n != 2 {
+		t.Fatalf("tracking %d clients, want 2", n)
+	}
+
+	// ip:1 was evicted and starts over with a full bucket
+	if !limiter.Allow("ip:1", limit).Allowed {
+		t.Error("evicted client denied")
+	}
+	if limiter.Allow("ip:3", limit).Allowed {
+		t.Error("recent client allowed past its limit")
+	}
+}
@@ -0,0 +1,193 @@
+package http
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+
+	"github.com/Beka01247/bitpanda-aml/internal/application"
+	"github.com/Beka01247/bitpanda-aml/internal/domain"
+	"github.com/go-chi/chi"
+	"github.com/go-playground/validator/v10"
+	"go.uber.org/zap"
+)
+
+type APIKeyHandlers struct {
+	apiKeysUseCase *application.ManageAPIKeysUseCase
+	logger         *zap.SugaredLogger
+	validator      *validator.Validate
+}
+
+func NewAPIKeyHandlers(
+	apiKeysUseCase *application.ManageAPIKeysUseCase,
+	logger *zap.SugaredLogger,
+) *APIKeyHandlers {
+	return &APIKeyHandlers{
+		apiKeysUseCase: apiKeysUseCase,
+		logger:         logger,
+		validator:      validator.New(),
+	}
+}
+
+// CreateAPIKey handles POST /v1/admin/api-keys
+//
+//	@Summary		Issue API key
+//	@Description	Issues a key for a tenant with the given roles. The key itself is only returned in this response
+//	@Tags			admin
+//	@Accept			json
+//	@Produce		json
+//	@Param			request	body		CreateAPIKeyRequest	true	"API key"
+//	@Success		201		{object}	IssuedAPIKeyResponse
+//	@Failure		400		{object}	ErrorResponse
+//	@Failure		500		{object}	ErrorResponse
+//	@Security		ApiKeyAuth
+//	@Router			/admin/api-keys [post]
+func (h *APIKeyHandlers) CreateAPIKey(w http.ResponseWriter, r *http.Request) {
+	var req CreateAPIKeyRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		respondError(w, http.StatusBadRequest, "invalid request body")
+		return
+	}
+
+	if err := h.validator.Struct(req); err != nil {
+		respondError(w, http.StatusBadRequest, fmt.Sprintf("validation failed: %v", err))
+		return
+	}
+
+	roles := make([]domain.Role, 0, len(req.Roles))
+	for _, role := range req.Roles {
+		roles = append(roles, domain.Role(role))
+	}
+
+	issued, err := h.apiKeysUseCase.Issue(r.Context(), tenantID(r), application.IssueAPIKeyInput{
+		TenantID:    req.TenantID,
+		Name:        req.Name,
+		CallbackURL: req.CallbackURL,
+		Roles:       roles,
+	})
+	if err != nil {
+		h.respondUseCaseError(w, err)
+		return
+	}
+
+	respondJSON(w, http.StatusCreated, ToIssuedAPIKeyResponse(issued.Key, issued.Secret))
+}
+
+// ListAPIKeys handles GET /v1/admin/api-keys
+//
+//	@Summary	List API keys
+//	@Tags		admin
+//	@Produce	json
+//	@Param		tenant_id	query		string	false	"Tenant ID, ignored for keys bound to a tenant"
+//	@Success	200			{array}		APIKeyResponse
+//	@Failure	500			{object}	ErrorResponse
+//	@Security	ApiKeyAuth
+//	@Router		/admin/api-keys [get]
+func (h *APIKeyHandlers) ListAPIKeys(w http.ResponseWriter, r *http.Request) {
+	keys, err := h.apiKeysUseCase.List(r.Context(), tenantID(r), domain.APIKeyFilter{
+		TenantID: r.URL.Query().Get("tenant_id"),
+	})
+	if err != nil {
+		h.respondUseCaseError(w, err)
+		return
+	}
+
+	response := make([]APIKeyResponse, 0, len(keys))
+	for _, key := range keys {
+		response = append(response, ToAPIKeyResponse(key))
+	}
+
+	respondJSON(w, http.StatusOK, response)
+}
+
+// GetAPIKey handles GET /v1/admin/api-keys/{key_id}
+//
+//	@Summary	Get API key
+//	@Tags		admin
+//	@Produce	json
+//	@Param		key_id	path		string	true	"Key ID"
+//	@Success	200		{object}	APIKeyResponse
+//	@Failure	404		{object}	ErrorResponse
+//	@Security	ApiKeyAuth
+//	@Router		/admin/api-keys/{key_id} [get]
+func (h *APIKeyHandlers) GetAPIKey(w http.ResponseWriter, r *http.Request) {
+	key, err := h.apiKeysUseCase.Get(r.Context(), tenantID(r), chi.URLParam(r, "key_id"))
+	if err != nil {
+		h.respondUseCaseError(w, err)
+		return
+	}
+
+	respondJSON(w, http.StatusOK, ToAPIKeyResponse(key))
+}
+
+// RotateAPIKey handles POST /v1/admin/api-keys/{key_id}/rotate
+//
+//	@Summary		Rotate API key
+//	@Description	Issues a replacement key for the same tenant. The old key keeps working for grace_seconds, or is revoked right away when it is 0
+//	@Tags			admin
+//	@Accept			json
+//	@Produce		json
+//	@Param			key_id	path		string				true	"Key ID"
+//	@Param			request	body		RotateAPIKeyRequest	false	"Rotation options"
+//	@Success		201		{object}	IssuedAPIKeyResponse
+//	@Failure		400		{object}	ErrorResponse
+//	@Failure		404		{object}	ErrorResponse
+//	@Failure		409		{object}	ErrorResponse
+//	@Security		ApiKeyAuth
+//	@Router			/admin/api-keys/{key_id}/rotate [post]
+func (h *APIKeyHandlers) RotateAPIKey(w http.ResponseWriter, r *http.Request) {
+	var req RotateAPIKeyRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil && !errors.Is(err, io.EOF) {
+		respondError(w, http.StatusBadRequest, "invalid request body")
+		return
+	}
+
+	if err := h.validator.Struct(req); err != nil {
+		respondError(w, http.StatusBadRequest, fmt.Sprintf("validation failed: %v", err))
+		return
+	}
+
+	issued, err := h.apiKeysUseCase.Rotate(r.Context(), tenantID(r), chi.URLParam(r, "key_id"), time.Duration(req.GraceSeconds)*time.Second)
+	if err != nil {
+		h.respondUseCaseError(w, err)
+		return
+	}
+
+	respondJSON(w, http.StatusCreated, ToIssuedAPIKeyResponse(issued.Key, issued.Secret))
+}
+
+// RevokeAPIKey handles DELETE /v1/admin/api-keys/{key_id}
+//
+//	@Summary		Revoke API key
+//	@Description	Revokes the key immediately, it stays listed as revoked
+//	@Tags			admin
+//	@Param			key_id	path	string	true	"Key ID"
+//	@Success		204
+//	@Failure		404	{object}	ErrorResponse
+//	@Security		ApiKeyAuth
+//	@Router			/admin/api-keys/{key_id} [delete]
+func (h *APIKeyHandlers) RevokeAPIKey(w http.ResponseWriter, r *http.Request) {
+	if err := h.apiKeysUseCase.Revoke(r.Context(), tenantID(r), chi.URLParam(r, "key_id")); err != nil {
+		h.respondUseCaseError(w, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (h *APIKeyHandlers) respondUseCaseError(w http.ResponseWriter, err error) {
+	switch {
+	case errors.Is(err, domain.ErrAPIKeyNotFound):
+		respondError(w, http.StatusNotFound, "api key not found")
+	case errors.Is(err, domain.ErrInvalidTenant), errors.Is(err, domain.ErrInvalidRole), errors.Is(err, domain.ErrInvalidCallbackURL):
+		respondError(w, http.StatusBadRequest, err.Error())
+	case errors.Is(err, domain.ErrInvalidAPIKey):
+		respondError(w, http.StatusConflict, "api key is revoked or expired")
+	default:
+		h.logger.Errorw("api key request failed", "error", err)
+		respondError(w, http.StatusInternalServerError, "api key request failed")
+	}
+}
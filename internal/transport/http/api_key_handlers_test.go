@@ -0,0 +1,73 @@
+package http
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/Beka01247/bitpanda-aml/internal/application"
+	"github.com/Beka01247/bitpanda-aml/internal/domain"
+	"github.com/Beka01247/bitpanda-aml/internal/infrastructure/repositories"
+	"go.uber.org/zap"
+)
+
+func TestAPIKeyHandlersTenantIsolation(t *testing.T) {
+	logger := zap.NewNop().Sugar()
+	useCase := application.NewManageAPIKeysUseCase(repositories.NewMemoryAPIKeyRepository(logger), logger)
+	handlers := NewAPIKeyHandlers(useCase, logger)
+
+	keyIDs := make(map[string]string)
+	for _, tenantID := range []string{"acme", "globex"} {
+		issued, err := useCase.Issue(context.Background(), "", application.IssueAPIKeyInput{TenantID: tenantID})
+		if err != nil {
+			t.Fatalf("Issue() error = %v", err)
+		}
+		keyIDs[tenantID] = issued.Key.ID
+	}
+	globexKey := "/admin/api-keys/" + keyIDs["globex"]
+
+	t.Run("list ignores another tenant's filter", func(t *testing.T) {
+		rec := serveAs(testAcmeKey, "/admin/api-keys", handlers.ListAPIKeys, http.MethodGet, "/admin/api-keys?tenant_id=globex", "")
+		var response []APIKeyResponse
+		if err := json.NewDecoder(rec.Body).Decode(&response); err != nil {
+			t.Fatalf("failed to decode response: %v", err)
+		}
+		if len(response) != 1 || response[0].ID != keyIDs["acme"] {
+			t.Errorf("ListAPIKeys() = %+v, want only the acme key", response)
+		}
+	})
+
+	tests := []struct {
+		name    string
+		key     *domain.APIKey
+		pattern string
+		handler http.HandlerFunc
+		method  string
+		target  string
+		body    string
+		want    int
+	}{
+		{"issue for another tenant", testAcmeKey, "/admin/api-keys", handlers.CreateAPIKey, http.MethodPost, "/admin/api-keys", `{"tenant_id": "globex", "roles": ["tenant_admin"]}`, http.StatusBadRequest},
+		{"issue an admin key", testAcmeKey, "/admin/api-keys", handlers.CreateAPIKey, http.MethodPost, "/admin/api-keys", `{"tenant_id": "acme", "roles": ["admin"]}`, http.StatusBadRequest},
+		{"admin key can't issue an admin key either", testAdminKey, "/admin/api-keys", handlers.CreateAPIKey, http.MethodPost, "/admin/api-keys", `{"tenant_id": "acme", "roles": ["admin"]}`, http.StatusBadRequest},
+		{"get another tenant's key", testAcmeKey, "/admin/api-keys/{key_id}", handlers.GetAPIKey, http.MethodGet, globexKey, "", http.StatusNotFound},
+		{"rotate another tenant's key", testAcmeKey, "/admin/api-keys/{key_id}/rotate", handlers.RotateAPIKey, http.MethodPost, globexKey + "/rotate", "", http.StatusNotFound},
+		{"revoke another tenant's key", testAcmeKey, "/admin/api-keys/{key_id}", handlers.RevokeAPIKey, http.MethodDelete, globexKey, "", http.StatusNotFound},
+		{"issue for own tenant", testGlobexKey, "/admin/api-keys", handlers.CreateAPIKey, http.MethodPost, "/admin/api-keys", `{"tenant_id": "globex"}`, http.StatusCreated},
+		{"admin key manages every tenant", testAdminKey, "/admin/api-keys/{key_id}", handlers.GetAPIKey, http.MethodGet, globexKey, "", http.StatusOK},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rec := serveAs(tt.key, tt.pattern, tt.handler, tt.method, tt.target, tt.body)
+			if rec.Code != tt.want {
+				t.Errorf("status = %d, want %d: %s", rec.Code, tt.want, rec.Body.String())
+			}
+		})
+	}
+
+	if key, _ := useCase.Get(context.Background(), "", keyIDs["globex"]); key.RevokedAt != nil || key.ExpiresAt != nil {
+		t.Error("another tenant revoked or rotated the key")
+	}
+}
@@ -0,0 +1,106 @@
+package http
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/Beka01247/bitpanda-aml/internal/application"
+	"github.com/Beka01247/bitpanda-aml/internal/domain"
+	"go.uber.org/zap"
+)
+
+const (
+	defaultAuditLimit = 100
+	maxAuditLimit     = 1000
+)
+
+type AuditHandlers struct {
+	auditLogUseCase *application.AuditLogUseCase
+	logger          *zap.SugaredLogger
+}
+
+func NewAuditHandlers(
+	auditLogUseCase *application.AuditLogUseCase,
+	logger *zap.SugaredLogger,
+) *AuditHandlers {
+	return &AuditHandlers{
+		auditLogUseCase: auditLogUseCase,
+		logger:          logger,
+	}
+}
+
+// ListAuditEntries handles GET /v1/admin/audit
+//
+//	@Summary		Query audit log
+//	@Description	Lists audit entries in the order they were written, oldest first
+//	@Tags			admin
+//	@Produce		json
+//	@Param			check_id	query		string	false	"Check ID"
+//	@Param			address		query		string	false	"Address"
+//	@Param			tenant_id	query		string	false	"Tenant ID, ignored for keys bound to a tenant"
+//	@Param			from		query		string	false	"RFC 3339 time, inclusive"
+//	@Param			to			query		string	false	"RFC 3339 time, exclusive"
+//	@Param			limit		query		int		false	"At most 1000, defaults to 100"
+//	@Success		200			{array}		AuditEntryResponse
+//	@Failure		400			{object}	ErrorResponse
+//	@Failure		500			{object}	ErrorResponse
+//	@Security		ApiKeyAuth
+//	@Router			/admin/audit [get]
+func (h *AuditHandlers) ListAuditEntries(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+	filter := domain.AuditFilter{
+		CheckID:  query.Get("check_id"),
+		Address:  query.Get("address"),
+		Limit:    defaultAuditLimit,
+		TenantID: query.Get("tenant_id"),
+	}
+
+	var err error
+	if filter.From, err = parseTimeParam(r, "from"); err != nil {
+		respondError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	if filter.To, err = parseTimeParam(r, "to"); err != nil {
+		respondError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	if value := query.Get("limit"); value != "" {
+		limit, err := strconv.Atoi(value)
+		if err != nil || limit < 1 || limit > maxAuditLimit {
+			respondError(w, http.StatusBadRequest, "limit must be between 1 and 1000")
+			return
+		}
+		filter.Limit = limit
+	}
+
+	entries, err := h.auditLogUseCase.List(r.Context(), tenantID(r), filter)
+	if err != nil {
+		h.logger.Errorw("failed to list audit entries", "error", err)
+		respondError(w, http.StatusInternalServerError, "failed to list audit entries")
+		return
+	}
+
+	response := make([]AuditEntryResponse, 0, len(entries))
+	for _, entry := range entries {
+		response = append(response, ToAuditEntryResponse(entry))
+	}
+
+	respondJSON(w, http.StatusOK, response)
+}
+
+func parseTimeParam(r *http.Request, name string) (*time.Time, error) {
+	value := r.URL.Query().Get(name)
+	if value == "" {
+		return nil, nil
+	}
+
+	parsed, err := time.Parse(time.RFC3339, value)
+	if err != nil {
+		return nil, fmt.Errorf("%s must be an RFC 3339 time", name)
+	}
+
+	return &parsed, nil
+}
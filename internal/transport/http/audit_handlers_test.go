@@ -0,0 +1,61 @@
+package http
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/Beka01247/bitpanda-aml/internal/application"
+	"github.com/Beka01247/bitpanda-aml/internal/domain"
+	"github.com/Beka01247/bitpanda-aml/internal/infrastructure/repositories"
+	"go.uber.org/zap"
+)
+
+func TestAuditHandlersTenantIsolation(t *testing.T) {
+	logger := zap.NewNop().Sugar()
+	auditLog := repositories.NewMemoryAuditLog(logger)
+	handlers := NewAuditHandlers(application.NewAuditLogUseCase(auditLog, repositories.NewMemoryCheckRepository(logger), logger), logger)
+
+	for _, tenantID := range []string{"acme", "globex"} {
+		entry := domain.NewAuditEntry(domain.AuditCheckCreated, tenantID+"-key", tenantID, "check-"+tenantID, "address", nil)
+		if err := auditLog.Append(context.Background(), entry); err != nil {
+			t.Fatalf("Append() error = %v", err)
+		}
+	}
+
+	tests := []struct {
+		name   string
+		key    *domain.APIKey
+		target string
+		want   []string
+	}{
+		{"tenant key", testAcmeKey, "/admin/audit", []string{"acme"}},
+		{"tenant key asking for another tenant", testAcmeKey, "/admin/audit?tenant_id=globex", []string{"acme"}},
+		{"admin key", testAdminKey, "/admin/audit", []string{"acme", "globex"}},
+		{"admin key by tenant", testAdminKey, "/admin/audit?tenant_id=globex", []string{"globex"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rec := serveAs(tt.key, "/admin/audit", handlers.ListAuditEntries, http.MethodGet, tt.target, "")
+			var response []AuditEntryResponse
+			if err := json.NewDecoder(rec.Body).Decode(&response); err != nil {
+				t.Fatalf("failed to decode response: %v", err)
+			}
+
+			tenants := make([]string, 0, len(response))
+			for _, entry := range response {
+				tenants = append(tenants, entry.TenantID)
+			}
+			if len(tenants) != len(tt.want) {
+				t.Fatalf("ListAuditEntries() tenants = %v, want %v", tenants, tt.want)
+			}
+			for i := range tenants {
+				if tenants[i] != tt.want[i] {
+					t.Errorf("ListAuditEntries() tenants = %v, want %v", tenants, tt.want)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,55 @@
+package http
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/Beka01247/bitpanda-aml/internal/domain"
+)
+
+type contextKey string
+
+const apiKeyContextKey contextKey = "api_key"
+
+// stores the authenticated key, set by the auth middleware
+func ContextWithAPIKey(ctx context.Context, key *domain.APIKey) context.Context {
+	return context.WithValue(ctx, apiKeyContextKey, key)
+}
+
+func APIKeyFromContext(ctx context.Context) *domain.APIKey {
+	key, _ := ctx.Value(apiKeyContextKey).(*domain.APIKey)
+	return key
+}
+
+// the caller's tenant, routes serving tenant data always run behind the auth middleware
+func tenantID(r *http.Request) string {
+	if key := APIKeyFromContext(r.Context()); key != nil {
+		return key.TenantID
+	}
+	return ""
+}
+
+// the key id recorded in the audit log
+func actorID(r *http.Request) string {
+	if key := APIKeyFromContext(r.Context()); key != nil {
+		return key.ID
+	}
+	return ""
+}
+
+// the callback from the request, falling back to the one registered on the api key
+func callbackURL(r *http.Request, requested string) string {
+	if requested != "" {
+		return requested
+	}
+	if key := APIKeyFromContext(r.Context()); key != nil {
+		return key.CallbackURL
+	}
+	return ""
+}
+
+// sanctions identifications are only shown to callers with sanctions:read
+func canReadSanctions(r *http.Request) bool {
+	key := APIKeyFromContext(r.Context())
+	return key != nil && key.Can(domain.PermissionSanctionsRead)
+}
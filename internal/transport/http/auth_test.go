@@ -0,0 +1,30 @@
+package http
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+
+	"github.com/Beka01247/bitpanda-aml/internal/domain"
+	"github.com/go-chi/chi"
+)
+
+// keys as the auth middleware resolves them, the admin one is ADMIN_API_KEY
+var (
+	testAcmeKey   = &domain.APIKey{ID: "acme-key", TenantID: "acme", Roles: []domain.Role{domain.RoleTenantAdmin}}
+	testGlobexKey = &domain.APIKey{ID: "globex-key", TenantID: "globex", Roles: []domain.Role{domain.RoleTenantAdmin}}
+	testAdminKey  = &domain.APIKey{ID: "admin", Name: "ADMIN_API_KEY", Roles: []domain.Role{domain.RoleAdmin}}
+)
+
+// routes the request through chi so URL params resolve, authenticated as key
+func serveAs(key *domain.APIKey, pattern string, handler http.HandlerFunc, method, target, body string) *httptest.ResponseRecorder {
+	router := chi.NewRouter()
+	router.Method(method, pattern, handler)
+
+	req := httptest.NewRequest(method, target, strings.NewReader(body))
+	req = req.WithContext(ContextWithAPIKey(req.Context(), key))
+
+	rec := httptest.NewRecorder()
+	router.ServeHTTP(rec, req)
+	return rec
+}
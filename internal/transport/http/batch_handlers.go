@@ -0,0 +1,211 @@
+package http
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/Beka01247/bitpanda-aml/internal/application"
+	"github.com/Beka01247/bitpanda-aml/internal/domain"
+	"github.com/Beka01247/bitpanda-aml/internal/infrastructure/token"
+	"github.com/go-chi/chi"
+	"github.com/go-playground/validator/v10"
+	"go.uber.org/zap"
+)
+
+type BatchHandlers struct {
+	batchUseCase  *application.CheckBatchUseCase
+	tokenProvider *token.HMACToken
+	apiURL        string
+	logger        *zap.SugaredLogger
+	validator     *validator.Validate
+}
+
+func NewBatchHandlers(
+	batchUseCase *application.CheckBatchUseCase,
+	tokenProvider *token.HMACToken,
+	apiURL string,
+	logger *zap.SugaredLogger,
+) *BatchHandlers {
+	return &BatchHandlers{
+		batchUseCase:  batchUseCase,
+		tokenProvider: tokenProvider,
+		apiURL:        apiURL,
+		logger:        logger,
+		validator:     validator.New(),
+	}
+}
+
+// CheckAddressBatch handles POST /v1/check-address/batch
+//
+//	@Summary		Check many addresses
+//	@Description	Starts one AML check per item, items with an invalid address or currency are rejected individually
+//	@Tags			aml
+//	@Accept			json
+//	@Produce		json
+//	@Param			request	body		BatchCheckRequest	true	"Batch request"
+//	@Success		202		{object}	BatchResponse
+//	@Failure		400		{object}	ErrorResponse
+//	@Failure		500		{object}	ErrorResponse
+//	@Security		ApiKeyAuth
+//	@Router			/check-address/batch [post]
+func (h *BatchHandlers) CheckAddressBatch(w http.ResponseWriter, r *http.Request) {
+	var req BatchCheckRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		respondError(w, http.StatusBadRequest, "invalid request body")
+		return
+	}
+
+	if err := h.validator.Struct(req); err != nil {
+		respondError(w, http.StatusBadRequest, fmt.Sprintf("validation failed: %v", err))
+		return
+	}
+
+	inputs := make([]application.CheckAddressInput, 0, len(req.Items))
+	for _, item := range req.Items {
+		inputs = append(inputs, application.CheckAddressInput{
+			Actor:       actorID(r),
+			Address:     item.Address,
+			Currency:    item.Currency,
+			Network:     item.Network,
+			Tag:         item.Tag,
+			CallbackURL: callbackURL(r, req.CallbackURL),
+			BypassCache: req.BypassCache,
+		})
+	}
+
+	batch, err := h.batchUseCase.Execute(r.Context(), tenantID(r), inputs)
+	if err != nil {
+		h.respondUseCaseError(w, err)
+		return
+	}
+
+	checksTotal.Add(int64(len(batch.CheckIDs())))
+
+	respondJSON(w, http.StatusAccepted, h.batchResponse(r, &application.BatchResult{Batch: batch}))
+}
+
+// GetBatch handles GET /v1/batches/{batch_id}
+//
+//	@Summary		Get batch status
+//	@Description	Per-item status, results and report links of a batch
+//	@Tags			aml
+//	@Produce		json
+//	@Param			batch_id	path		string	true	"Batch ID"
+//	@Success		200			{object}	BatchResponse
+//	@Failure		404			{object}	ErrorResponse
+//	@Failure		410			{object}	ErrorResponse
+//	@Failure		500			{object}	ErrorResponse
+//	@Security		ApiKeyAuth
+//	@Router			/batches/{batch_id} [get]
+func (h *BatchHandlers) GetBatch(w http.ResponseWriter, r *http.Request) {
+	result, err := h.batchUseCase.Get(r.Context(), tenantID(r), chi.URLParam(r, "batch_id"))
+	if err != nil {
+		h.respondUseCaseError(w, err)
+		return
+	}
+
+	respondJSON(w, http.StatusOK, h.batchResponse(r, result))
+}
+
+// GetBatchReport handles GET /v1/batches/{batch_id}/report.pdf
+//
+//	@Summary		Download batch report
+//	@Description	Consolidated PDF with a summary table and the report of every completed check
+//	@Tags			aml
+//	@Produce		application/pdf
+//	@Param			batch_id	path		string	true	"Batch ID"
+//	@Success		200			{file}		binary
+//	@Failure		404			{object}	ErrorResponse
+//	@Failure		409			{object}	ErrorResponse
+//	@Failure		410			{object}	ErrorResponse
+//	@Security		ApiKeyAuth
+//	@Router			/batches/{batch_id}/report.pdf [get]
+func (h *BatchHandlers) GetBatchReport(w http.ResponseWriter, r *http.Request) {
+	batchID := chi.URLParam(r, "batch_id")
+
+	data, err := h.batchUseCase.Report(r.Context(), tenantID(r), batchID)
+	if err != nil {
+		h.respondUseCaseError(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/pdf")
+	w.Header().Set("Content-Disposition", fmt.Sprintf("inline; filename=batch-%s.pdf", batchID))
+	w.WriteHeader(http.StatusOK)
+	w.Write(data)
+}
+
+// items whose check isn't loaded yet are reported as processing
+func (h *BatchHandlers) batchResponse(r *http.Request, result *application.BatchResult) BatchResponse {
+	batch := result.Batch
+	response := BatchResponse{
+		BatchID:   batch.ID,
+		Total:     len(batch.Items),
+		Items:     make([]BatchItemResponse, 0, len(batch.Items)),
+		PollURL:   fmt.Sprintf("%s/v1/batches/%s", h.apiURL, batch.ID),
+		CreatedAt: batch.CreatedAt,
+		ExpiresAt: batch.ExpiresAt,
+	}
+
+	for _, item := range batch.Items {
+		itemResponse := BatchItemResponse{
+			Address:  item.Address,
+			Currency: item.Currency,
+			Network:  item.Network,
+			Tag:      item.Tag,
+			CheckID:  item.CheckID,
+			Status:   "processing",
+			Error:    item.Error,
+		}
+
+		check, loaded := result.Checks[item.CheckID]
+		switch {
+		case item.CheckID == "":
+			itemResponse.Status = "rejected"
+			response.Rejected++
+		case result.Checks != nil && !loaded:
+			itemResponse.Status = "expired"
+			response.Failed++
+		case !loaded || check.Status == domain.StatusProcessing:
+			response.Processing++
+		default:
+			checkResult := newCheckResponse(check, h.tokenProvider, h.apiURL, canReadSanctions(r))
+			itemResponse.Status = checkResult.Status
+			itemResponse.Error = checkResult.Error
+			itemResponse.Result = &checkResult
+			if check.Status == domain.StatusFailed {
+				response.Failed++
+			} else {
+				response.Completed++
+			}
+		}
+
+		response.Items = append(response.Items, itemResponse)
+	}
+
+	response.Status = "processing"
+	if response.Processing == 0 {
+		response.Status = "completed"
+		response.ReportURL = fmt.Sprintf("%s/v1/batches/%s/report.pdf", h.apiURL, batch.ID)
+	}
+
+	return response
+}
+
+func (h *BatchHandlers) respondUseCaseError(w http.ResponseWriter, err error) {
+	switch {
+	case errors.Is(err, domain.ErrInvalidBatch):
+		respondError(w, http.StatusBadRequest, err.Error())
+	case errors.Is(err, domain.ErrBatchNotFound):
+		respondError(w, http.StatusNotFound, "batch not found")
+	case errors.Is(err, domain.ErrBatchExpired):
+		respondError(w, http.StatusGone, "batch expired")
+	case errors.Is(err, domain.ErrBatchNotReady):
+		respondError(w, http.StatusConflict, err.Error())
+	default:
+		h.logger.Errorw("batch request failed", "error", err)
+		respondError(w, http.StatusInternalServerError, "batch request failed")
+	}
+}
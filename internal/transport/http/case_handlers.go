@@ -0,0 +1,232 @@
+package http
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/Beka01247/bitpanda-aml/internal/application"
+	"github.com/Beka01247/bitpanda-aml/internal/domain"
+	"github.com/go-chi/chi"
+	"github.com/go-playground/validator/v10"
+	"go.uber.org/zap"
+)
+
+type CaseHandlers struct {
+	casesUseCase *application.ManageCasesUseCase
+	logger       *zap.SugaredLogger
+	validator    *validator.Validate
+}
+
+func NewCaseHandlers(
+	casesUseCase *application.ManageCasesUseCase,
+	logger *zap.SugaredLogger,
+) *CaseHandlers {
+	return &CaseHandlers{
+		casesUseCase: casesUseCase,
+		logger:       logger,
+		validator:    validator.New(),
+	}
+}
+
+// ListCases handles GET /v1/cases
+//
+//	@Summary		List review cases
+//	@Description	Lists manual review cases, oldest first
+//	@Tags			cases
+//	@Produce		json
+//	@Param			status		query		string	false	"open, in_review, escalated or closed"
+//	@Param			assignee	query		string	false	"Assignee"
+//	@Param			check_id	query		string	false	"Check ID"
+//	@Success		200			{array}		ReviewCaseResponse
+//	@Failure		400			{object}	ErrorResponse
+//	@Failure		500			{object}	ErrorResponse
+//	@Security		ApiKeyAuth
+//	@Router			/cases [get]
+func (h *CaseHandlers) ListCases(w http.ResponseWriter, r *http.Request) {
+	filter := domain.CaseFilter{
+		Assignee: r.URL.Query().Get("assignee"),
+		CheckID:  r.URL.Query().Get("check_id"),
+	}
+
+	if status := r.URL.Query().Get("status"); status != "" {
+		parsed, err := domain.ParseCaseStatus(status)
+		if err != nil {
+			respondError(w, http.StatusBadRequest, err.Error())
+			return
+		}
+		filter.Status = parsed
+	}
+
+	cases, err := h.casesUseCase.List(r.Context(), tenantID(r), filter)
+	if err != nil {
+		h.respondUseCaseError(w, err)
+		return
+	}
+
+	response := make([]ReviewCaseResponse, 0, len(cases))
+	for _, reviewCase := range cases {
+		response = append(response, ToReviewCaseResponse(reviewCase))
+	}
+
+	respondJSON(w, http.StatusOK, response)
+}
+
+// GetCase handles GET /v1/cases/{case_id}
+//
+//	@Summary	Get review case
+//	@Tags		cases
+//	@Produce	json
+//	@Param		case_id	path		string	true	"Case ID"
+//	@Success	200		{object}	ReviewCaseResponse
+//	@Failure	404		{object}	ErrorResponse
+//	@Security	ApiKeyAuth
+//	@Router		/cases/{case_id} [get]
+func (h *CaseHandlers) GetCase(w http.ResponseWriter, r *http.Request) {
+	reviewCase, err := h.casesUseCase.Get(r.Context(), tenantID(r), chi.URLParam(r, "case_id"))
+	if err != nil {
+		h.respondUseCaseError(w, err)
+		return
+	}
+
+	respondJSON(w, http.StatusOK, ToReviewCaseResponse(reviewCase))
+}
+
+// ClaimCase handles POST /v1/cases/{case_id}/claim
+//
+//	@Summary		Claim review case
+//	@Description	Assigns the case to the calling key and moves it to in_review
+//	@Tags			cases
+//	@Produce		json
+//	@Param			case_id	path		string	true	"Case ID"
+//	@Success		200		{object}	ReviewCaseResponse
+//	@Failure		404		{object}	ErrorResponse
+//	@Failure		409		{object}	ErrorResponse
+//	@Security		ApiKeyAuth
+//	@Router			/cases/{case_id}/claim [post]
+func (h *CaseHandlers) ClaimCase(w http.ResponseWriter, r *http.Request) {
+	reviewCase, err := h.casesUseCase.Claim(r.Context(), tenantID(r), chi.URLParam(r, "case_id"), actorID(r))
+	if err != nil {
+		h.respondUseCaseError(w, err)
+		return
+	}
+
+	respondJSON(w, http.StatusOK, ToReviewCaseResponse(reviewCase))
+}
+
+// CommentCase handles POST /v1/cases/{case_id}/comments
+//
+//	@Summary	Comment on review case
+//	@Tags		cases
+//	@Accept		json
+//	@Produce	json
+//	@Param		case_id	path		string				true	"Case ID"
+//	@Param		request	body		CommentCaseRequest	true	"Note"
+//	@Success	200		{object}	ReviewCaseResponse
+//	@Failure	400		{object}	ErrorResponse
+//	@Failure	404		{object}	ErrorResponse
+//	@Failure	409		{object}	ErrorResponse
+//	@Security	ApiKeyAuth
+//	@Router		/cases/{case_id}/comments [post]
+func (h *CaseHandlers) CommentCase(w http.ResponseWriter, r *http.Request) {
+	var req CommentCaseRequest
+	if !h.decode(w, r, &req) {
+		return
+	}
+
+	reviewCase, err := h.casesUseCase.Comment(r.Context(), tenantID(r), chi.URLParam(r, "case_id"), actorID(r), req.Body)
+	if err != nil {
+		h.respondUseCaseError(w, err)
+		return
+	}
+
+	respondJSON(w, http.StatusOK, ToReviewCaseResponse(reviewCase))
+}
+
+// EscalateCase handles POST /v1/cases/{case_id}/escalate
+//
+//	@Summary	Escalate review case
+//	@Tags		cases
+//	@Accept		json
+//	@Produce	json
+//	@Param		case_id	path		string				true	"Case ID"
+//	@Param		request	body		EscalateCaseRequest	true	"Escalation"
+//	@Success	200		{object}	ReviewCaseResponse
+//	@Failure	400		{object}	ErrorResponse
+//	@Failure	404		{object}	ErrorResponse
+//	@Failure	409		{object}	ErrorResponse
+//	@Security	ApiKeyAuth
+//	@Router		/cases/{case_id}/escalate [post]
+func (h *CaseHandlers) EscalateCase(w http.ResponseWriter, r *http.Request) {
+	var req EscalateCaseRequest
+	if !h.decode(w, r, &req) {
+		return
+	}
+
+	reviewCase, err := h.casesUseCase.Escalate(r.Context(), tenantID(r), chi.URLParam(r, "case_id"), actorID(r), req.Reason)
+	if err != nil {
+		h.respondUseCaseError(w, err)
+		return
+	}
+
+	respondJSON(w, http.StatusOK, ToReviewCaseResponse(reviewCase))
+}
+
+// ResolveCase handles POST /v1/cases/{case_id}/resolve
+//
+//	@Summary		Resolve review case
+//	@Description	Closes the case with a final disposition and publishes aml.case.closed
+//	@Tags			cases
+//	@Accept			json
+//	@Produce		json
+//	@Param			case_id	path		string				true	"Case ID"
+//	@Param			request	body		ResolveCaseRequest	true	"Resolution"
+//	@Success		200		{object}	ReviewCaseResponse
+//	@Failure		400		{object}	ErrorResponse
+//	@Failure		404		{object}	ErrorResponse
+//	@Failure		409		{object}	ErrorResponse
+//	@Security		ApiKeyAuth
+//	@Router			/cases/{case_id}/resolve [post]
+func (h *CaseHandlers) ResolveCase(w http.ResponseWriter, r *http.Request) {
+	var req ResolveCaseRequest
+	if !h.decode(w, r, &req) {
+		return
+	}
+
+	reviewCase, err := h.casesUseCase.Resolve(r.Context(), tenantID(r), chi.URLParam(r, "case_id"), actorID(r), req.Disposition, req.Summary)
+	if err != nil {
+		h.respondUseCaseError(w, err)
+		return
+	}
+
+	respondJSON(w, http.StatusOK, ToReviewCaseResponse(reviewCase))
+}
+
+func (h *CaseHandlers) decode(w http.ResponseWriter, r *http.Request, req any) bool {
+	if err := json.NewDecoder(r.Body).Decode(req); err != nil {
+		respondError(w, http.StatusBadRequest, "invalid request body")
+		return false
+	}
+
+	if err := h.validator.Struct(req); err != nil {
+		respondError(w, http.StatusBadRequest, fmt.Sprintf("validation failed: %v", err))
+		return false
+	}
+
+	return true
+}
+
+func (h *CaseHandlers) respondUseCaseError(w http.ResponseWriter, err error) {
+	switch {
+	case errors.Is(err, domain.ErrCaseNotFound):
+		respondError(w, http.StatusNotFound, "case not found")
+	case errors.Is(err, domain.ErrCaseConflict):
+		respondError(w, http.StatusConflict, "case was modified by someone else, reload and retry")
+	case errors.Is(err, domain.ErrInvalidCaseTransition):
+		respondError(w, http.StatusConflict, err.Error())
+	default:
+		h.logger.Errorw("case request failed", "error", err)
+		respondError(w, http.StatusInternalServerError, "case request failed")
+	}
+}
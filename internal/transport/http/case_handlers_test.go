@@ -0,0 +1,116 @@
+package http
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/Beka01247/bitpanda-aml/internal/application"
+	"github.com/Beka01247/bitpanda-aml/internal/domain"
+	"github.com/Beka01247/bitpanda-aml/internal/infrastructure/repositories"
+	"go.uber.org/zap"
+)
+
+func TestCaseHandlersTenantIsolation(t *testing.T) {
+	logger := zap.NewNop().Sugar()
+	repository := repositories.NewMemoryCaseRepository(repositories.NewMemoryOutbox(logger), logger)
+	handlers := NewCaseHandlers(application.NewManageCasesUseCase(repositories.NewMemoryCheckRepository(logger), repository, logger), logger)
+
+	cases := make(map[string]*domain.ReviewCase)
+	for _, tenantID := range []string{"acme", "globex"} {
+		check := domain.NewAMLCheck("address-"+tenantID, "BTC", time.Hour)
+		check.TenantID = tenantID
+		check.RiskLevel = domain.RiskLevelHigh
+		cases[tenantID] = domain.NewReviewCase(check, domain.ReviewReasons(check))
+		if err := repository.Create(context.Background(), cases[tenantID]); err != nil {
+			t.Fatalf("Create() error = %v", err)
+		}
+	}
+	globexCase := "/cases/" + cases["globex"].ID
+
+	t.Run("list only has the caller's cases", func(t *testing.T) {
+		rec := serveAs(testAcmeKey, "/cases", handlers.ListCases, http.MethodGet, "/cases", "")
+		var response []ReviewCaseResponse
+		if err := json.NewDecoder(rec.Body).Decode(&response); err != nil {
+			t.Fatalf("failed to decode response: %v", err)
+		}
+		if len(response) != 1 || response[0].ID != cases["acme"].ID {
+			t.Errorf("ListCases() = %+v, want only the acme case", response)
+		}
+	})
+
+	tests := []struct {
+		name    string
+		key     *domain.APIKey
+		pattern string
+		handler http.HandlerFunc
+		method  string
+		target  string
+		body    string
+		want    int
+	}{
+		{"get another tenant's case", testAcmeKey, "/cases/{case_id}", handlers.GetCase, http.MethodGet, globexCase, "", http.StatusNotFound},
+		{"claim another tenant's case", testAcmeKey, "/cases/{case_id}/claim", handlers.ClaimCase, http.MethodPost, globexCase + "/claim", "", http.StatusNotFound},
+		{"comment on another tenant's case", testAcmeKey, "/cases/{case_id}/comments", handlers.CommentCase, http.MethodPost, globexCase + "/comments", `{"body": "hi"}`, http.StatusNotFound},
+		{"get own case", testGlobexKey, "/cases/{case_id}", handlers.GetCase, http.MethodGet, globexCase, "", http.StatusOK},
+		{"admin key sees every tenant", testAdminKey, "/cases/{case_id}", handlers.GetCase, http.MethodGet, globexCase, "", http.StatusOK},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rec := serveAs(tt.key, tt.pattern, tt.handler, tt.method, tt.target, tt.body)
+			if rec.Code != tt.want {
+				t.Errorf("status = %d, want %d: %s", rec.Code, tt.want, rec.Body.String())
+			}
+		})
+	}
+
+	if reviewCase, _ := repository.Get(context.Background(), cases["globex"].ID); reviewCase.Assignee != "" || len(reviewCase.Notes) != 0 {
+		t.Errorf("another tenant changed the case: %+v", reviewCase)
+	}
+}
+
+func TestCaseHandlersActorIsTheCaller(t *testing.T) {
+	logger := zap.NewNop().Sugar()
+	repository := repositories.NewMemoryCaseRepository(repositories.NewMemoryOutbox(logger), logger)
+	handlers := NewCaseHandlers(application.NewManageCasesUseCase(repositories.NewMemoryCheckRepository(logger), repository, logger), logger)
+
+	check := domain.NewAMLCheck("address", "BTC", time.Hour)
+	check.TenantID = "acme"
+	check.RiskLevel = domain.RiskLevelHigh
+	reviewCase := domain.NewReviewCase(check, domain.ReviewReasons(check))
+	if err := repository.Create(context.Background(), reviewCase); err != nil {
+		t.Fatalf("Create() error = %v", err)
+	}
+	target := "/cases/" + reviewCase.ID
+	colleague := &domain.APIKey{ID: "acme-colleague", TenantID: "acme", Roles: []domain.Role{domain.RoleAnalyst}}
+
+	rec := serveAs(testAcmeKey, "/cases/{case_id}/claim", handlers.ClaimCase, http.MethodPost, target+"/claim", `{"assignee": "acme-colleague"}`)
+	if rec.Code != http.StatusOK {
+		t.Fatalf("ClaimCase() status = %d: %s", rec.Code, rec.Body.String())
+	}
+
+	rec = serveAs(testAcmeKey, "/cases/{case_id}/comments", handlers.CommentCase, http.MethodPost, target+"/comments", `{"author": "acme-colleague", "body": "looked at it"}`)
+	if rec.Code != http.StatusOK {
+		t.Fatalf("CommentCase() status = %d: %s", rec.Code, rec.Body.String())
+	}
+
+	// naming the assignee in the body doesn't make the colleague the assignee
+	rec = serveAs(colleague, "/cases/{case_id}/resolve", handlers.ResolveCase, http.MethodPost, target+"/resolve", `{"actor": "acme-key", "disposition": "cleared"}`)
+	if rec.Code != http.StatusConflict {
+		t.Errorf("ResolveCase() by another key status = %d, want %d", rec.Code, http.StatusConflict)
+	}
+
+	stored, _ := repository.Get(context.Background(), reviewCase.ID)
+	if stored.Assignee != testAcmeKey.ID {
+		t.Errorf("Assignee = %q, want the caller's key id %q", stored.Assignee, testAcmeKey.ID)
+	}
+	if len(stored.Notes) != 1 || stored.Notes[0].Author != testAcmeKey.ID {
+		t.Errorf("Notes = %+v, want one note by %q", stored.Notes, testAcmeKey.ID)
+	}
+	if stored.IsClosed() {
+		t.Error("case was resolved by a key that isn't the assignee")
+	}
+}
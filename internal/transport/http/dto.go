@@ -1,19 +1,42 @@
 package http
 
-import "github.com/Beka01247/bitpanda-aml/internal/domain"
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/Beka01247/bitpanda-aml/internal/domain"
+)
 
 type CheckAddressRequest struct {
-	Address  string `json:"address" validate:"required"`
-	Currency string `json:"currency" validate:"required,oneof=BTC ETH USDT"`
+	Address     string `json:"address" validate:"required"`
+	Currency    string `json:"currency" validate:"required"`
+	CallbackURL string `json:"callback_url,omitempty" validate:"omitempty,url,startswith=https://"`
+	// ask the providers again instead of serving a cached result
+	BypassCache bool `json:"bypass_cache,omitempty"`
+	// chain of a multi-chain asset such as USDT, e.g. TRON or POLYGON, or a test network such as
+	// TESTNET, REGTEST or SEPOLIA, defaults to the first configured mainnet chain
+	Network string `json:"network,omitempty" validate:"omitempty,max=32"`
+	// XRP destination tag, XLM memo or TON comment, an XRP X-address may carry the tag instead
+	Tag string `json:"tag,omitempty" validate:"omitempty,max=128"`
 }
 
 type CheckAddressResponse struct {
-	Status     string               `json:"status"`
-	RiskScore  int                  `json:"risk_score"`
-	RiskLevel  string               `json:"risk_level"`
-	Categories []string             `json:"categories"`
-	Sanctions  SanctionsResponseDTO `json:"sanctions"`
-	PDFURL     string               `json:"pdf_url"`
+	CheckID      string               `json:"check_id"`
+	Status       string               `json:"status"`
+	RiskScore    int                  `json:"risk_score"`
+	RiskLevel    string               `json:"risk_level"`
+	Categories   []string             `json:"categories"`
+	Sanctions    SanctionsResponseDTO `json:"sanctions"`
+	Providers    []ProviderResultDTO  `json:"providers"`
+	Watchlist    *WatchlistMatchDTO   `json:"watchlist,omitempty"`
+	Decision     string               `json:"decision"`
+	MatchedRules []MatchedRuleDTO     `json:"matched_rules"`
+	PDFURL       string               `json:"pdf_url,omitempty"`
+	Error        string               `json:"error,omitempty"`
+	// outcome of each provider lookup, an unavailable sanctions lookup is not a clean one
+	ProviderStatuses []ProviderStatusDTO `json:"provider_statuses"`
+	// set for checks on a test network, answered by the mock provider
+	Test bool `json:"test,omitempty"`
 }
 
 type CheckAddressAcceptedResponse struct {
@@ -22,9 +45,120 @@ type CheckAddressAcceptedResponse struct {
 	PollURL string `json:"poll_url"`
 }
 
+type BatchCheckItemRequest struct {
+	Address string `json:"address" validate:"required"`
+	// validated per item against the asset registry, unsupported ones are rejected individually
+	Currency string `json:"currency" validate:"required"`
+	Network  string `json:"network,omitempty" validate:"omitempty,max=32"`
+	Tag      string `json:"tag,omitempty" validate:"omitempty,max=128"`
+}
+
+type BatchCheckRequest struct {
+	Items []BatchCheckItemRequest `json:"items" validate:"required,min=1,dive"`
+	// applied to every check in the batch
+	CallbackURL string `json:"callback_url,omitempty" validate:"omitempty,url,startswith=https://"`
+	BypassCache bool   `json:"bypass_cache,omitempty"`
+}
+
+type BatchItemResponse struct {
+	Address  string                `json:"address"`
+	Currency string                `json:"currency"`
+	Network  string                `json:"network,omitempty"`
+	Tag      string                `json:"tag,omitempty"`
+	CheckID  string                `json:"check_id,omitempty"`
+	Status   string                `json:"status"`
+	Error    string                `json:"error,omitempty"`
+	Result   *CheckAddressResponse `json:"result,omitempty"`
+}
+
+type BatchResponse struct {
+	BatchID    string              `json:"batch_id"`
+	Status     string              `json:"status"`
+	Total      int                 `json:"total"`
+	Processing int                 `json:"processing"`
+	Completed  int                 `json:"completed"`
+	Failed     int                 `json:"failed"`
+	Rejected   int                 `json:"rejected"`
+	Items      []BatchItemResponse `json:"items"`
+	PollURL    string              `json:"poll_url"`
+	ReportURL  string              `json:"report_url,omitempty"`
+	CreatedAt  time.Time           `json:"created_at"`
+	ExpiresAt  time.Time           `json:"expires_at"`
+}
+
+type CreateAPIKeyRequest struct {
+	TenantID string `json:"tenant_id" validate:"required"`
+	Name     string `json:"name" validate:"max=255"`
+	// default webhook for checks created with this key
+	CallbackURL string `json:"callback_url,omitempty" validate:"omitempty,url,startswith=https://"`
+	// defaults to integrator
+	Roles []string `json:"roles,omitempty" validate:"omitempty,dive,oneof=integrator analyst auditor tenant_admin"`
+}
+
+type RotateAPIKeyRequest struct {
+	// how long the old key keeps working, at most 30 days
+	GraceSeconds int `json:"grace_seconds" validate:"min=0,max=2592000"`
+}
+
+type APIKeyResponse struct {
+	ID          string     `json:"id"`
+	TenantID    string     `json:"tenant_id"`
+	Name        string     `json:"name"`
+	Prefix      string     `json:"prefix"`
+	CallbackURL string     `json:"callback_url,omitempty"`
+	Roles       []string   `json:"roles"`
+	Status      string     `json:"status"`
+	CreatedAt   time.Time  `json:"created_at"`
+	ExpiresAt   *time.Time `json:"expires_at,omitempty"`
+	RevokedAt   *time.Time `json:"revoked_at,omitempty"`
+}
+
+type IssuedAPIKeyResponse struct {
+	APIKey APIKeyResponse `json:"api_key"`
+	// shown once, store it now
+	Key string `json:"key"`
+}
+
+type AuditEntryResponse struct {
+	Sequence  int64             `json:"sequence"`
+	ID        string            `json:"id"`
+	Action    string            `json:"action"`
+	Actor     string            `json:"actor"`
+	TenantID  string            `json:"tenant_id,omitempty"`
+	CheckID   string            `json:"check_id,omitempty"`
+	Address   string            `json:"address,omitempty"`
+	Details   map[string]string `json:"details"`
+	CreatedAt time.Time         `json:"created_at"`
+	PrevHash  string            `json:"prev_hash"`
+	Hash      string            `json:"hash"`
+}
+
 type SanctionsResponseDTO struct {
 	Hit             bool                         `json:"hit"`
 	Identifications []SanctionsIdentificationDTO `json:"identifications"`
+	// screened, skipped or error, only a screened result without a hit is clean
+	Status   string `json:"status,omitempty"`
+	Provider string `json:"provider,omitempty"`
+	// why screening was skipped or failed
+	Reason string `json:"reason,omitempty"`
+	// providers whose answer came from cache, by provider name
+	Cache map[string]CacheInfoDTO `json:"cache,omitempty"`
+}
+
+type ProviderStatusDTO struct {
+	Provider string `json:"provider"`
+	// aml or sanctions
+	Kind string `json:"kind"`
+	// ok, degraded or unavailable
+	Status    string `json:"status"`
+	LatencyMs int64  `json:"latency_ms"`
+	Error     string `json:"error,omitempty"`
+}
+
+type CacheInfoDTO struct {
+	CachedAt time.Time `json:"cached_at"`
+	// age of the result when the check used it
+	AgeSeconds int64 `json:"age_seconds"`
 }
 
 type SanctionsIdentificationDTO struct {
@@ -33,6 +167,115 @@ type SanctionsIdentificationDTO struct {
 	URL      string `json:"url"`
 }
 
+type ProviderResultDTO struct {
+	Provider   string   `json:"provider"`
+	RiskScore  int      `json:"risk_score"`
+	RiskLevel  string   `json:"risk_level"`
+	Categories []string `json:"categories"`
+	Weight     float64  `json:"weight,omitempty"`
+	Error      string   `json:"error,omitempty"`
+	// set when the answer came from cache
+	Cache *CacheInfoDTO `json:"cache,omitempty"`
+}
+
+type MatchedRuleDTO struct {
+	ID          string `json:"id"`
+	Description string `json:"description,omitempty"`
+	Decision    string `json:"decision"`
+}
+
+// present when an internal watchlist entry decided the outcome
+type WatchlistMatchDTO struct {
+	EntryID  string `json:"entry_id"`
+	ListType string `json:"list_type"`
+	Reason   string `json:"reason"`
+	Author   string `json:"author"`
+}
+
+type WatchlistEntryRequest struct {
+	Address   string     `json:"address" validate:"required"`
+	Chain     string     `json:"chain" validate:"required"`
+	ListType  string     `json:"list_type" validate:"required,oneof=blocklist allowlist"`
+	Reason    string     `json:"reason" validate:"required"`
+	ExpiresAt *time.Time `json:"expires_at,omitempty"`
+}
+
+type WatchlistEntryResponse struct {
+	ID        string     `json:"id"`
+	Address   string     `json:"address"`
+	Chain     string     `json:"chain"`
+	ListType  string     `json:"list_type"`
+	Reason    string     `json:"reason"`
+	Author    string     `json:"author"`
+	ExpiresAt *time.Time `json:"expires_at,omitempty"`
+	CreatedAt time.Time  `json:"created_at"`
+	UpdatedAt time.Time  `json:"updated_at"`
+}
+
+type CommentCaseRequest struct {
+	Body string `json:"body" validate:"required"`
+}
+
+type EscalateCaseRequest struct {
+	Reason string `json:"reason" validate:"required"`
+}
+
+type ResolveCaseRequest struct {
+	Disposition string `json:"disposition" validate:"required,oneof=cleared confirmed reported"`
+	Summary     string `json:"summary"`
+}
+
+type CaseNoteDTO struct {
+	Author    string    `json:"author"`
+	Body      string    `json:"body"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+type CaseHistoryEntryDTO struct {
+	Action     string    `json:"action"`
+	Actor      string    `json:"actor"`
+	FromStatus string    `json:"from_status,omitempty"`
+	ToStatus   string    `json:"to_status,omitempty"`
+	Detail     string    `json:"detail,omitempty"`
+	At         time.Time `json:"at"`
+}
+
+type ReviewCaseResponse struct {
+	ID          string                `json:"id"`
+	CheckID     string                `json:"check_id"`
+	TenantID    string                `json:"tenant_id,omitempty"`
+	Address     string                `json:"address"`
+	Currency    string                `json:"currency"`
+	RiskScore   int                   `json:"risk_score"`
+	RiskLevel   string                `json:"risk_level"`
+	Decision    string                `json:"decision"`
+	Reasons     []string              `json:"reasons"`
+	Status      string                `json:"status"`
+	Assignee    string                `json:"assignee,omitempty"`
+	Disposition string                `json:"disposition,omitempty"`
+	Notes       []CaseNoteDTO         `json:"notes"`
+	History     []CaseHistoryEntryDTO `json:"history"`
+	CreatedAt   time.Time             `json:"created_at"`
+	UpdatedAt   time.Time             `json:"updated_at"`
+	ClosedAt    *time.Time            `json:"closed_at,omitempty"`
+}
+
+type WebhookDeliveryResponse struct {
+	ID             string          `json:"id"`
+	CheckID        string          `json:"check_id"`
+	EventType      string          `json:"event_type"`
+	URL            string          `json:"url"`
+	Status         string          `json:"status"`
+	Attempts       int             `json:"attempts"`
+	LastStatusCode int             `json:"last_status_code,omitempty"`
+	LastError      string          `json:"last_error,omitempty"`
+	NextAttemptAt  *time.Time      `json:"next_attempt_at,omitempty"`
+	Payload        json.RawMessage `json:"payload" swaggertype:"object"`
+	CreatedAt      time.Time       `json:"created_at"`
+	UpdatedAt      time.Time       `json:"updated_at"`
+	DeliveredAt    *time.Time      `json:"delivered_at,omitempty"`
+}
+
 type ErrorResponse struct {
 	Error string `json:"error"`
 }
@@ -54,8 +297,239 @@ func ToSanctionsDTO(sanctions *domain.SanctionsResult) SanctionsResponseDTO {
 		})
 	}
 
+	var cache map[string]CacheInfoDTO
+	for provider, info := range sanctions.Cache {
+		if cache == nil {
+			cache = make(map[string]CacheInfoDTO)
+		}
+		cache[provider] = ToCacheInfoDTO(&info)
+	}
+
+	// stored before screening statuses existed
+	status := sanctions.Status
+	if status == "" {
+		status = domain.SanctionsScreened
+	}
+
 	return SanctionsResponseDTO{
 		Hit:             sanctions.Hit,
 		Identifications: identifications,
+		Status:          string(status),
+		Provider:        sanctions.Provider,
+		Reason:          sanctions.Reason,
+		Cache:           cache,
+	}
+}
+
+func ToCacheInfoDTO(info *domain.CacheInfo) CacheInfoDTO {
+	return CacheInfoDTO{
+		CachedAt:   info.CachedAt,
+		AgeSeconds: info.AgeSeconds,
+	}
+}
+
+func ToProviderResultsDTO(providers []domain.ProviderResult) []ProviderResultDTO {
+	results := make([]ProviderResultDTO, 0, len(providers))
+	for _, provider := range providers {
+		categories := provider.Categories
+		if categories == nil {
+			categories = []string{}
+		}
+
+		result := ProviderResultDTO{
+			Provider:   provider.Provider,
+			RiskScore:  provider.RiskScore,
+			RiskLevel:  string(provider.RiskLevel),
+			Categories: categories,
+			Weight:     provider.Weight,
+			Error:      provider.Error,
+		}
+		if provider.Cache != nil {
+			cache := ToCacheInfoDTO(provider.Cache)
+			result.Cache = &cache
+		}
+
+		results = append(results, result)
+	}
+
+	return results
+}
+
+func ToProviderStatusesDTO(statuses []domain.ProviderStatus) []ProviderStatusDTO {
+	results := make([]ProviderStatusDTO, 0, len(statuses))
+	for _, status := range statuses {
+		results = append(results, ProviderStatusDTO{
+			Provider:  status.Provider,
+			Kind:      string(status.Kind),
+			Status:    string(status.Status),
+			LatencyMs: status.LatencyMs,
+			Error:     status.Error,
+		})
+	}
+
+	return results
+}
+
+func ToMatchedRulesDTO(rules []domain.MatchedRule) []MatchedRuleDTO {
+	results := make([]MatchedRuleDTO, 0, len(rules))
+	for _, rule := range rules {
+		results = append(results, MatchedRuleDTO{
+			ID:          rule.ID,
+			Description: rule.Description,
+			Decision:    string(rule.Decision),
+		})
+	}
+
+	return results
+}
+
+func ToWatchlistMatchDTO(match *domain.WatchlistMatch) *WatchlistMatchDTO {
+	if match == nil {
+		return nil
+	}
+
+	return &WatchlistMatchDTO{
+		EntryID:  match.EntryID,
+		ListType: string(match.ListType),
+		Reason:   match.Reason,
+		Author:   match.Author,
+	}
+}
+
+func ToWatchlistEntryResponse(entry *domain.WatchlistEntry) WatchlistEntryResponse {
+	return WatchlistEntryResponse{
+		ID:        entry.ID,
+		Address:   entry.Address,
+		Chain:     entry.Chain,
+		ListType:  string(entry.ListType),
+		Reason:    entry.Reason,
+		Author:    entry.Author,
+		ExpiresAt: entry.ExpiresAt,
+		CreatedAt: entry.CreatedAt,
+		UpdatedAt: entry.UpdatedAt,
+	}
+}
+
+func ToReviewCaseResponse(reviewCase *domain.ReviewCase) ReviewCaseResponse {
+	reasons := reviewCase.Reasons
+	if reasons == nil {
+		reasons = []string{}
+	}
+
+	notes := make([]CaseNoteDTO, 0, len(reviewCase.Notes))
+	for _, note := range reviewCase.Notes {
+		notes = append(notes, CaseNoteDTO{
+			Author:    note.Author,
+			Body:      note.Body,
+			CreatedAt: note.CreatedAt,
+		})
+	}
+
+	history := make([]CaseHistoryEntryDTO, 0, len(reviewCase.History))
+	for _, entry := range reviewCase.History {
+		history = append(history, CaseHistoryEntryDTO{
+			Action:     entry.Action,
+			Actor:      entry.Actor,
+			FromStatus: string(entry.FromStatus),
+			ToStatus:   string(entry.ToStatus),
+			Detail:     entry.Detail,
+			At:         entry.At,
+		})
+	}
+
+	return ReviewCaseResponse{
+		ID:          reviewCase.ID,
+		CheckID:     reviewCase.CheckID,
+		TenantID:    reviewCase.TenantID,
+		Address:     reviewCase.Address,
+		Currency:    reviewCase.Currency,
+		RiskScore:   reviewCase.RiskScore,
+		RiskLevel:   string(reviewCase.RiskLevel),
+		Decision:    string(reviewCase.Decision),
+		Reasons:     reasons,
+		Status:      string(reviewCase.Status),
+		Assignee:    reviewCase.Assignee,
+		Disposition: string(reviewCase.Disposition),
+		Notes:       notes,
+		History:     history,
+		CreatedAt:   reviewCase.CreatedAt,
+		UpdatedAt:   reviewCase.UpdatedAt,
+		ClosedAt:    reviewCase.ClosedAt,
+	}
+}
+
+func ToWebhookDeliveryResponse(delivery *domain.WebhookDelivery) WebhookDeliveryResponse {
+	response := WebhookDeliveryResponse{
+		ID:             delivery.ID,
+		CheckID:        delivery.CheckID,
+		EventType:      delivery.EventType,
+		URL:            delivery.URL,
+		Status:         string(delivery.Status),
+		Attempts:       delivery.Attempts,
+		LastStatusCode: delivery.LastStatusCode,
+		LastError:      delivery.LastError,
+		Payload:        json.RawMessage(delivery.Payload),
+		CreatedAt:      delivery.CreatedAt,
+		UpdatedAt:      delivery.UpdatedAt,
+		DeliveredAt:    delivery.DeliveredAt,
+	}
+
+	// only meaningful while retries are scheduled
+	if delivery.Status == domain.WebhookDeliveryPending {
+		nextAttemptAt := delivery.NextAttemptAt
+		response.NextAttemptAt = &nextAttemptAt
+	}
+
+	return response
+}
+
+func ToAPIKeyResponse(key *domain.APIKey) APIKeyResponse {
+	status := "active"
+	switch {
+	case key.RevokedAt != nil:
+		status = "revoked"
+	case !key.IsActive(time.Now().UTC()):
+		status = "expired"
+	}
+
+	roles := make([]string, 0, len(key.Roles))
+	for _, role := range key.Roles {
+		roles = append(roles, string(role))
+	}
+
+	return APIKeyResponse{
+		ID:          key.ID,
+		TenantID:    key.TenantID,
+		Name:        key.Name,
+		Prefix:      key.Prefix,
+		CallbackURL: key.CallbackURL,
+		Roles:       roles,
+		Status:      status,
+		CreatedAt:   key.CreatedAt,
+		ExpiresAt:   key.ExpiresAt,
+		RevokedAt:   key.RevokedAt,
+	}
+}
+
+func ToIssuedAPIKeyResponse(key *domain.APIKey, secret string) IssuedAPIKeyResponse {
+	return IssuedAPIKeyResponse{
+		APIKey: ToAPIKeyResponse(key),
+		Key:    secret,
+	}
+}
+
+func ToAuditEntryResponse(entry *domain.AuditEntry) AuditEntryResponse {
+	return AuditEntryResponse{
+		Sequence:  entry.Sequence,
+		ID:        entry.ID,
+		Action:    string(entry.Action),
+		Actor:     entry.Actor,
+		TenantID:  entry.TenantID,
+		CheckID:   entry.CheckID,
+		Address:   entry.Address,
+		Details:   entry.Details,
+		CreatedAt: entry.CreatedAt,
+		PrevHash:  entry.PrevHash,
+		Hash:      entry.Hash,
 	}
 }
@@ -25,9 +25,15 @@ var (
 	checksProcessing = expvar.NewInt("checks_processing")
 )
 
+// notifications only reach waiters in the process that finished the check,
+// waiters on other replicas fall back to re-reading the repository this often
+var awaitPollInterval = time.Second
+
 type Handlers struct {
 	checkAddressUseCase *application.CheckAddressUseCase
 	getStatusUseCase    *application.GetCheckStatusUseCase
+	auditLogUseCase     *application.AuditLogUseCase
+	notifier            domain.CheckNotifier
 	reportStorage       domain.ReportStorage
 	tokenProvider       *token.HMACToken
 	checkWaitSeconds    int
@@ -39,6 +45,8 @@ type Handlers struct {
 func NewHandlers(
 	checkAddressUseCase *application.CheckAddressUseCase,
 	getStatusUseCase *application.GetCheckStatusUseCase,
+	auditLogUseCase *application.AuditLogUseCase,
+	notifier domain.CheckNotifier,
 	reportStorage domain.ReportStorage,
 	tokenProvider *token.HMACToken,
 	checkWaitSeconds int,
@@ -48,6 +56,8 @@ func NewHandlers(
 	return &Handlers{
 		checkAddressUseCase: checkAddressUseCase,
 		getStatusUseCase:    getStatusUseCase,
+		auditLogUseCase:     auditLogUseCase,
+		notifier:            notifier,
 		reportStorage:       reportStorage,
 		tokenProvider:       tokenProvider,
 		checkWaitSeconds:    checkWaitSeconds,
@@ -70,6 +80,7 @@ func NewHandlers(
 //	@Failure		400		{object}	ErrorResponse
 //	@Failure		500		{object}	ErrorResponse
 //	@Failure		502		{object}	ErrorResponse
+//	@Security		ApiKeyAuth
 //	@Router			/check-address [post]
 func (h *Handlers) CheckAddress(w http.ResponseWriter, r *http.Request) {
 	checksTotal.Add(1)
@@ -86,9 +97,19 @@ func (h *Handlers) CheckAddress(w http.ResponseWriter, r *http.Request) {
 	}
 
 	// initiate check
-	checkID, err := h.checkAddressUseCase.Execute(r.Context(), req.Address, req.Currency)
+	checkID, err := h.checkAddressUseCase.Execute(r.Context(), application.CheckAddressInput{
+		TenantID:    tenantID(r),
+		Actor:       actorID(r),
+		Address:     req.Address,
+		Currency:    req.Currency,
+		Network:     req.Network,
+		Tag:         req.Tag,
+		CallbackURL: callbackURL(r, req.CallbackURL),
+		BypassCache: req.BypassCache,
+	})
 	if err != nil {
-		if errors.Is(err, domain.ErrInvalidAddress) || errors.Is(err, domain.ErrUnsupportedCurrency) {
+		if errors.Is(err, domain.ErrInvalidAddress) || errors.Is(err, domain.ErrUnsupportedCurrency) || errors.Is(err, domain.ErrInvalidTag) ||
+			errors.Is(err, domain.ErrInvalidCallbackURL) {
 			h.respondError(w, http.StatusBadRequest, err.Error())
 			return
 		}
@@ -104,45 +125,146 @@ func (h *Handlers) CheckAddress(w http.ResponseWriter, r *http.Request) {
 	ctx, cancel := context.WithTimeout(r.Context(), time.Duration(h.checkWaitSeconds)*time.Second)
 	defer cancel()
 
-	ticker := time.NewTicker(500 * time.Millisecond)
+	check, err := h.awaitCheck(ctx, tenantID(r), checkID)
+	checksProcessing.Add(-1)
+	if err != nil {
+		if !errors.Is(err, context.DeadlineExceeded) {
+			h.logger.Warnw("failed to get check status", "check_id", checkID, "error", err)
+		}
+
+		// timeout - return 202 with poll URL
+		h.respondJSON(w, http.StatusAccepted, CheckAddressAcceptedResponse{
+			Status:  "processing",
+			Message: "Check is being processed. Use the poll_url to check status.",
+			PollURL: fmt.Sprintf("%s/v1/check-address/%s", h.apiURL, checkID),
+		})
+		return
+	}
+
+	if check.Status == domain.StatusFailed {
+		checksFailed.Add(1)
+		h.respondError(w, http.StatusBadGateway, fmt.Sprintf("AML check failed: %s", check.ErrorMessage))
+		return
+	}
+
+	checksSuccess.Add(1)
+	h.respondCheckResult(w, r, check)
+}
+
+// CheckEvents handles GET /v1/check-address/{check_id}/events
+//
+//	@Summary		Stream check status
+//	@Description	Server-Sent Events stream of the check status. Sends the current status right away
+//	@Description	and the result once the check completes or fails, then closes.
+//	@Description	Events are named processing, completed and failed.
+//	@Tags			aml
+//	@Produce		text/event-stream
+//	@Param			check_id	path		string	true	"Check ID"
+//	@Success		200			{object}	CheckAddressResponse
+//	@Failure		404			{object}	ErrorResponse
+//	@Failure		410			{object}	ErrorResponse
+//	@Failure		500			{object}	ErrorResponse
+//	@Security		ApiKeyAuth
+//	@Router			/check-address/{check_id}/events [get]
+func (h *Handlers) CheckEvents(w http.ResponseWriter, r *http.Request) {
+	checkID := chi.URLParam(r, "check_id")
+
+	flusher, ok := w.(http.Flusher)
+	if !ok {
+		h.respondError(w, http.StatusInternalServerError, "streaming unsupported")
+		return
+	}
+
+	// errors are still plain JSON, the stream only starts for an existing check
+	check, err := h.getStatusUseCase.Execute(r.Context(), tenantID(r), checkID)
+	if err != nil {
+		h.respondStatusError(w, checkID, err)
+		return
+	}
+
+	// the server write timeout would cut the stream
+	if err := http.NewResponseController(w).SetWriteDeadline(time.Time{}); err != nil {
+		h.logger.Debugw("failed to clear write deadline", "check_id", checkID, "error", err)
+	}
+
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.Header().Set("Connection", "keep-alive")
+	w.Header().Set("X-Accel-Buffering", "no")
+	w.WriteHeader(http.StatusOK)
+
+	h.writeCheckEvent(w, r, check)
+	flusher.Flush()
+
+	if check.Status == domain.StatusProcessing {
+		// ends with the request context, EventSource clients reconnect on their own
+		check, err = h.awaitCheck(r.Context(), tenantID(r), checkID)
+		if err != nil {
+			return
+		}
+
+		h.writeCheckEvent(w, r, check)
+		flusher.Flush()
+	}
+}
+
+// blocks until the check leaves processing, the status is re-read on every notification
+// so a missed or coalesced one can't leave the caller waiting on a stale state
+func (h *Handlers) awaitCheck(ctx context.Context, tenantID, checkID string) (*domain.AMLCheck, error) {
+	updates, unsubscribe := h.notifier.Subscribe(checkID)
+	defer unsubscribe()
+
+	ticker := time.NewTicker(awaitPollInterval)
 	defer ticker.Stop()
 
 	for {
+		// subscribed before reading, so a transition in between is not lost
+		check, err := h.getStatusUseCase.Execute(ctx, tenantID, checkID)
+		if err != nil {
+			return nil, err
+		}
+
+		if check.Status != domain.StatusProcessing {
+			return check, nil
+		}
+
 		select {
 		case <-ctx.Done():
-			// timeout - return 202 with poll URL
-			checksProcessing.Add(-1)
-			h.respondJSON(w, http.StatusAccepted, CheckAddressAcceptedResponse{
-				Status:  "processing",
-				Message: "Check is being processed. Use the poll_url to check status.",
-				PollURL: fmt.Sprintf("%s/v1/check-address/%s", h.apiURL, checkID),
-			})
-			return
-
+			return nil, ctx.Err()
+		case <-updates:
 		case <-ticker.C:
-			check, err := h.getStatusUseCase.Execute(r.Context(), checkID)
-			if err != nil {
-				h.logger.Warnw("failed to get check status", "check_id", checkID, "error", err)
-				continue
-			}
-
-			if check.Status == domain.StatusCompleted {
-				checksProcessing.Add(-1)
-				checksSuccess.Add(1)
-				h.respondCheckResult(w, check)
-				return
-			}
-
-			if check.Status == domain.StatusFailed {
-				checksProcessing.Add(-1)
-				checksFailed.Add(1)
-				h.respondError(w, http.StatusBadGateway, fmt.Sprintf("AML check failed: %s", check.ErrorMessage))
-				return
-			}
 		}
 	}
 }
 
+func (h *Handlers) writeCheckEvent(w http.ResponseWriter, r *http.Request, check *domain.AMLCheck) {
+	var (
+		event string
+		data  any
+	)
+
+	switch check.Status {
+	case domain.StatusCompleted:
+		event, data = "completed", h.checkResult(check, canReadSanctions(r))
+	case domain.StatusFailed:
+		event, data = "failed", h.checkResult(check, canReadSanctions(r))
+	default:
+		event, data = "processing", CheckAddressAcceptedResponse{
+			Status:  "processing",
+			Message: "Check is being processed.",
+			PollURL: fmt.Sprintf("%s/v1/check-address/%s", h.apiURL, check.ID),
+		}
+	}
+
+	payload, err := json.Marshal(data)
+	if err != nil {
+		h.logger.Errorw("failed to marshal check event", "check_id", check.ID, "error", err)
+		return
+	}
+
+	fmt.Fprintf(w, "event: %s\ndata: %s\n\n", event, payload)
+}
+
 // GetCheckStatus handles GET /v1/check-address/{check_id}
 //
 //	@Summary		Get check status
@@ -154,6 +276,7 @@ func (h *Handlers) CheckAddress(w http.ResponseWriter, r *http.Request) {
 //	@Success		202			{object}	CheckAddressAcceptedResponse
 //	@Failure		404			{object}	ErrorResponse
 //	@Failure		500			{object}	ErrorResponse
+//	@Security		ApiKeyAuth
 //	@Router			/check-address/{check_id} [get]
 func (h *Handlers) GetCheckStatus(w http.ResponseWriter, r *http.Request) {
 	checkID := chi.URLParam(r, "check_id")
@@ -162,18 +285,9 @@ func (h *Handlers) GetCheckStatus(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	check, err := h.getStatusUseCase.Execute(r.Context(), checkID)
+	check, err := h.getStatusUseCase.Execute(r.Context(), tenantID(r), checkID)
 	if err != nil {
-		if strings.Contains(err.Error(), "not found") {
-			h.respondError(w, http.StatusNotFound, "check not found")
-			return
-		}
-		if strings.Contains(err.Error(), "expired") {
-			h.respondError(w, http.StatusGone, "check expired")
-			return
-		}
-		h.logger.Errorw("failed to get check status", "check_id", checkID, "error", err)
-		h.respondError(w, http.StatusInternalServerError, "failed to get check status")
+		h.respondStatusError(w, checkID, err)
 		return
 	}
 
@@ -191,7 +305,7 @@ func (h *Handlers) GetCheckStatus(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	h.respondCheckResult(w, check)
+	h.respondCheckResult(w, r, check)
 }
 
 // GetReport handles GET /v1/report/{token}.pdf
@@ -205,6 +319,7 @@ func (h *Handlers) GetCheckStatus(w http.ResponseWriter, r *http.Request) {
 //	@Success		302		{string}	string	"Redirect to presigned URL"
 //	@Failure		404		{object}	ErrorResponse
 //	@Failure		410		{object}	ErrorResponse
+//	@Security		ApiKeyAuth
 //	@Router			/report/{token}.pdf [get]
 func (h *Handlers) GetReport(w http.ResponseWriter, r *http.Request) {
 	tokenStr := chi.URLParam(r, "token")
@@ -224,9 +339,19 @@ func (h *Handlers) GetReport(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	// a leaked link must not expose a report to another tenant
+	if domain.ReportTenant(reportKey) != tenantID(r) {
+		h.respondError(w, http.StatusNotFound, "report not found")
+		return
+	}
+
 	// try to get presigned URL first
 	presignedURL, err := h.reportStorage.PresignGet(r.Context(), reportKey, 5*time.Minute)
 	if err == nil && presignedURL != "" {
+		if !h.recordDownload(w, r, reportKey) {
+			return
+		}
+
 		// Redirect to presigned URL
 		http.Redirect(w, r, presignedURL, http.StatusFound)
 		return
@@ -248,6 +373,10 @@ func (h *Handlers) GetReport(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	if !h.recordDownload(w, r, reportKey) {
+		return
+	}
+
 	// stream PDF
 	w.Header().Set("Content-Type", "application/pdf")
 	w.Header().Set("Content-Disposition", fmt.Sprintf("inline; filename=%s", reportKey))
@@ -255,33 +384,104 @@ func (h *Handlers) GetReport(w http.ResponseWriter, r *http.Request) {
 	w.Write(data)
 }
 
-func (h *Handlers) respondCheckResult(w http.ResponseWriter, check *domain.AMLCheck) {
-	// generate signed token for PDF URL
-	token := h.tokenProvider.Sign(check.ReportKey, 24*time.Hour)
-	pdfURL := fmt.Sprintf("%s/v1/report/%s", h.apiURL, token)
+// every download is audited, reports are not handed out when that fails
+func (h *Handlers) recordDownload(w http.ResponseWriter, r *http.Request, reportKey string) bool {
+	if err := h.auditLogUseCase.RecordReportDownload(r.Context(), actorID(r), reportKey); err != nil {
+		h.respondError(w, http.StatusInternalServerError, "failed to get report")
+		return false
+	}
+	return true
+}
 
+func (h *Handlers) respondStatusError(w http.ResponseWriter, checkID string, err error) {
+	if strings.Contains(err.Error(), "not found") {
+		h.respondError(w, http.StatusNotFound, "check not found")
+		return
+	}
+	if strings.Contains(err.Error(), "expired") {
+		h.respondError(w, http.StatusGone, "check expired")
+		return
+	}
+	h.logger.Errorw("failed to get check status", "check_id", checkID, "error", err)
+	h.respondError(w, http.StatusInternalServerError, "failed to get check status")
+}
+
+func (h *Handlers) respondCheckResult(w http.ResponseWriter, r *http.Request, check *domain.AMLCheck) {
+	h.respondJSON(w, http.StatusOK, h.checkResult(check, canReadSanctions(r)))
+}
+
+// the CheckAddressResponse body for a finished check, sent to webhook callbacks,
+// callbacks go to integrations so they only say whether there was a sanctions hit
+func (h *Handlers) WebhookPayload(check *domain.AMLCheck) ([]byte, error) {
+	return json.Marshal(h.checkResult(check, false))
+}
+
+func (h *Handlers) checkResult(check *domain.AMLCheck, sanctionsDetails bool) CheckAddressResponse {
+	return newCheckResponse(check, h.tokenProvider, h.apiURL, sanctionsDetails)
+}
+
+// sanctionsDetails controls whether the matched sanctions identifications are included
+func newCheckResponse(check *domain.AMLCheck, tokenProvider *token.HMACToken, apiURL string, sanctionsDetails bool) CheckAddressResponse {
 	// ensure categories is not nil
 	categories := check.Categories
 	if categories == nil {
 		categories = []string{}
 	}
 
-	h.respondJSON(w, http.StatusOK, CheckAddressResponse{
-		Status:     "success",
-		RiskScore:  check.RiskScore,
-		RiskLevel:  string(check.RiskLevel),
-		Categories: categories,
-		Sanctions:  ToSanctionsDTO(check.Sanctions),
-		PDFURL:     pdfURL,
-	})
+	if check.Status == domain.StatusFailed {
+		return CheckAddressResponse{
+			CheckID:          check.ID,
+			Status:           "failed",
+			Categories:       categories,
+			Sanctions:        ToSanctionsDTO(nil),
+			Providers:        []ProviderResultDTO{},
+			MatchedRules:     []MatchedRuleDTO{},
+			Error:            check.ErrorMessage,
+			ProviderStatuses: []ProviderStatusDTO{},
+			Test:             check.NetworkType.IsTest(),
+		}
+	}
+
+	sanctions := ToSanctionsDTO(check.Sanctions)
+	if !sanctionsDetails {
+		sanctions.Identifications = []SanctionsIdentificationDTO{}
+	}
+
+	// generate signed token for PDF URL
+	token := tokenProvider.Sign(check.ReportKey, 24*time.Hour)
+	pdfURL := fmt.Sprintf("%s/v1/report/%s", apiURL, token)
+
+	return CheckAddressResponse{
+		CheckID:          check.ID,
+		Status:           "success",
+		RiskScore:        check.RiskScore,
+		RiskLevel:        string(check.RiskLevel),
+		Categories:       categories,
+		Sanctions:        sanctions,
+		Providers:        ToProviderResultsDTO(check.Providers),
+		Watchlist:        ToWatchlistMatchDTO(check.Watchlist),
+		Decision:         string(check.Decision),
+		MatchedRules:     ToMatchedRulesDTO(check.MatchedRules),
+		PDFURL:           pdfURL,
+		ProviderStatuses: ToProviderStatusesDTO(check.ProviderStatuses),
+		Test:             check.NetworkType.IsTest(),
+	}
 }
 
 func (h *Handlers) respondJSON(w http.ResponseWriter, status int, data any) {
+	respondJSON(w, status, data)
+}
+
+func (h *Handlers) respondError(w http.ResponseWriter, status int, message string) {
+	respondError(w, status, message)
+}
+
+func respondJSON(w http.ResponseWriter, status int, data any) {
 	w.Header().Set("Content-Type", "application/json")
 	w.WriteHeader(status)
 	json.NewEncoder(w).Encode(data)
 }
 
-func (h *Handlers) respondError(w http.ResponseWriter, status int, message string) {
-	h.respondJSON(w, status, ErrorResponse{Error: message})
+func respondError(w http.ResponseWriter, status int, message string) {
+	respondJSON(w, status, ErrorResponse{Error: message})
 }
@@ -0,0 +1,47 @@
+package http
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/Beka01247/bitpanda-aml/internal/application"
+	"github.com/Beka01247/bitpanda-aml/internal/domain"
+	"github.com/Beka01247/bitpanda-aml/internal/infrastructure/notifier"
+	"github.com/Beka01247/bitpanda-aml/internal/infrastructure/repositories"
+	"go.uber.org/zap"
+)
+
+// a check finished by another replica is never notified here, the wait has to see it anyway
+func TestAwaitCheckWithoutNotification(t *testing.T) {
+	defer func(interval time.Duration) { awaitPollInterval = interval }(awaitPollInterval)
+	awaitPollInterval = 10 * time.Millisecond
+
+	logger := zap.NewNop().Sugar()
+	repository := repositories.NewMemoryCheckRepository(logger)
+	handlers := NewHandlers(nil, application.NewGetCheckStatusUseCase(repository, logger), nil, notifier.NewInProcessNotifier(), nil, nil, 1, "", logger)
+
+	check := domain.NewAMLCheck("address", "BTC", time.Hour)
+	check.TenantID = "acme"
+	if err := repository.Create(context.Background(), check); err != nil {
+		t.Fatalf("Create() error = %v", err)
+	}
+
+	go func() {
+		time.Sleep(50 * time.Millisecond)
+		failed := *check
+		failed.MarkFailed("provider unavailable")
+		repository.Update(context.Background(), &failed)
+	}()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	got, err := handlers.awaitCheck(ctx, "acme", check.ID)
+	if err != nil {
+		t.Fatalf("awaitCheck() error = %v", err)
+	}
+	if got.Status != domain.StatusFailed {
+		t.Errorf("awaitCheck() status = %s, want %s", got.Status, domain.StatusFailed)
+	}
+}
@@ -0,0 +1,202 @@
+package http
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/Beka01247/bitpanda-aml/internal/application"
+	"github.com/Beka01247/bitpanda-aml/internal/domain"
+	"github.com/go-chi/chi"
+	"github.com/go-playground/validator/v10"
+	"go.uber.org/zap"
+)
+
+type WatchlistHandlers struct {
+	watchlistUseCase *application.ManageWatchlistUseCase
+	logger           *zap.SugaredLogger
+	validator        *validator.Validate
+}
+
+func NewWatchlistHandlers(
+	watchlistUseCase *application.ManageWatchlistUseCase,
+	logger *zap.SugaredLogger,
+) *WatchlistHandlers {
+	return &WatchlistHandlers{
+		watchlistUseCase: watchlistUseCase,
+		logger:           logger,
+		validator:        validator.New(),
+	}
+}
+
+// CreateWatchlistEntry handles POST /v1/admin/watchlist
+//
+//	@Summary		Create watchlist entry
+//	@Description	Adds an address to the internal blocklist or allowlist
+//	@Tags			admin
+//	@Accept			json
+//	@Produce		json
+//	@Param			request	body		WatchlistEntryRequest	true	"Watchlist entry"
+//	@Success		201		{object}	WatchlistEntryResponse
+//	@Failure		400		{object}	ErrorResponse
+//	@Failure		500		{object}	ErrorResponse
+//	@Security		ApiKeyAuth
+//	@Router			/admin/watchlist [post]
+func (h *WatchlistHandlers) CreateWatchlistEntry(w http.ResponseWriter, r *http.Request) {
+	input, ok := h.decodeEntry(w, r)
+	if !ok {
+		return
+	}
+
+	entry, err := h.watchlistUseCase.Create(r.Context(), input)
+	if err != nil {
+		h.respondUseCaseError(w, err)
+		return
+	}
+
+	respondJSON(w, http.StatusCreated, ToWatchlistEntryResponse(entry))
+}
+
+// ListWatchlistEntries handles GET /v1/admin/watchlist
+//
+//	@Summary		List watchlist entries
+//	@Description	Lists internal watchlist entries, optionally filtered
+//	@Tags			admin
+//	@Produce		json
+//	@Param			address		query		string	false	"Address"
+//	@Param			chain		query		string	false	"Chain"
+//	@Param			list_type	query		string	false	"blocklist or allowlist"
+//	@Success		200			{array}		WatchlistEntryResponse
+//	@Failure		400			{object}	ErrorResponse
+//	@Failure		500			{object}	ErrorResponse
+//	@Security		ApiKeyAuth
+//	@Router			/admin/watchlist [get]
+func (h *WatchlistHandlers) ListWatchlistEntries(w http.ResponseWriter, r *http.Request) {
+	filter := domain.WatchlistFilter{
+		Address: r.URL.Query().Get("address"),
+		Chain:   r.URL.Query().Get("chain"),
+	}
+
+	if listType := r.URL.Query().Get("list_type"); listType != "" {
+		parsed, err := domain.ParseWatchlistType(listType)
+		if err != nil {
+			respondError(w, http.StatusBadRequest, err.Error())
+			return
+		}
+		filter.ListType = parsed
+	}
+
+	entries, err := h.watchlistUseCase.List(r.Context(), filter)
+	if err != nil {
+		h.respondUseCaseError(w, err)
+		return
+	}
+
+	response := make([]WatchlistEntryResponse, 0, len(entries))
+	for _, entry := range entries {
+		response = append(response, ToWatchlistEntryResponse(entry))
+	}
+
+	respondJSON(w, http.StatusOK, response)
+}
+
+// GetWatchlistEntry handles GET /v1/admin/watchlist/{entry_id}
+//
+//	@Summary	Get watchlist entry
+//	@Tags		admin
+//	@Produce	json
+//	@Param		entry_id	path		string	true	"Entry ID"
+//	@Success	200			{object}	WatchlistEntryResponse
+//	@Failure	404			{object}	ErrorResponse
+//	@Security	ApiKeyAuth
+//	@Router		/admin/watchlist/{entry_id} [get]
+func (h *WatchlistHandlers) GetWatchlistEntry(w http.ResponseWriter, r *http.Request) {
+	entry, err := h.watchlistUseCase.Get(r.Context(), chi.URLParam(r, "entry_id"))
+	if err != nil {
+		h.respondUseCaseError(w, err)
+		return
+	}
+
+	respondJSON(w, http.StatusOK, ToWatchlistEntryResponse(entry))
+}
+
+// UpdateWatchlistEntry handles PUT /v1/admin/watchlist/{entry_id}
+//
+//	@Summary	Update watchlist entry
+//	@Tags		admin
+//	@Accept		json
+//	@Produce	json
+//	@Param		entry_id	path		string					true	"Entry ID"
+//	@Param		request		body		WatchlistEntryRequest	true	"Watchlist entry"
+//	@Success	200			{object}	WatchlistEntryResponse
+//	@Failure	400			{object}	ErrorResponse
+//	@Failure	404			{object}	ErrorResponse
+//	@Security	ApiKeyAuth
+//	@Router		/admin/watchlist/{entry_id} [put]
+func (h *WatchlistHandlers) UpdateWatchlistEntry(w http.ResponseWriter, r *http.Request) {
+	input, ok := h.decodeEntry(w, r)
+	if !ok {
+		return
+	}
+
+	entry, err := h.watchlistUseCase.Update(r.Context(), chi.URLParam(r, "entry_id"), input)
+	if err != nil {
+		h.respondUseCaseError(w, err)
+		return
+	}
+
+	respondJSON(w, http.StatusOK, ToWatchlistEntryResponse(entry))
+}
+
+// DeleteWatchlistEntry handles DELETE /v1/admin/watchlist/{entry_id}
+//
+//	@Summary	Delete watchlist entry
+//	@Tags		admin
+//	@Param		entry_id	path	string	true	"Entry ID"
+//	@Success	204
+//	@Failure	404	{object}	ErrorResponse
+//	@Security	ApiKeyAuth
+//	@Router		/admin/watchlist/{entry_id} [delete]
+func (h *WatchlistHandlers) DeleteWatchlistEntry(w http.ResponseWriter, r *http.Request) {
+	if err := h.watchlistUseCase.Delete(r.Context(), chi.URLParam(r, "entry_id")); err != nil {
+		h.respondUseCaseError(w, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (h *WatchlistHandlers) decodeEntry(w http.ResponseWriter, r *http.Request) (application.WatchlistEntryInput, bool) {
+	var req WatchlistEntryRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		respondError(w, http.StatusBadRequest, "invalid request body")
+		return application.WatchlistEntryInput{}, false
+	}
+
+	if err := h.validator.Struct(req); err != nil {
+		respondError(w, http.StatusBadRequest, fmt.Sprintf("validation failed: %v", err))
+		return application.WatchlistEntryInput{}, false
+	}
+
+	return application.WatchlistEntryInput{
+		Address:   req.Address,
+		Chain:     req.Chain,
+		ListType:  req.ListType,
+		Reason:    req.Reason,
+		Author:    actorID(r),
+		ExpiresAt: req.ExpiresAt,
+	}, true
+}
+
+func (h *WatchlistHandlers) respondUseCaseError(w http.ResponseWriter, err error) {
+	switch {
+	case errors.Is(err, domain.ErrWatchlistEntryNotFound):
+		respondError(w, http.StatusNotFound, "watchlist entry not found")
+	case errors.Is(err, domain.ErrInvalidWatchlistEntry), errors.Is(err, domain.ErrInvalidAddress):
+		respondError(w, http.StatusBadRequest, err.Error())
+	default:
+		h.logger.Errorw("watchlist request failed", "error", err)
+		respondError(w, http.StatusInternalServerError, "watchlist request failed")
+	}
+}
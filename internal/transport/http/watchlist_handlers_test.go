@@ -0,0 +1,32 @@
+package http
+
+import (
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/Beka01247/bitpanda-aml/internal/application"
+	"github.com/Beka01247/bitpanda-aml/internal/domain"
+	"github.com/Beka01247/bitpanda-aml/internal/infrastructure/repositories"
+	"go.uber.org/zap"
+)
+
+func TestWatchlistHandlersAuthorIsTheCaller(t *testing.T) {
+	logger := zap.NewNop().Sugar()
+	useCase := application.NewManageWatchlistUseCase(domain.NewDefaultAssetRegistry(), repositories.NewMemoryWatchlistRepository(logger), logger)
+	handlers := NewWatchlistHandlers(useCase, logger)
+
+	body := `{"address": "1A1zP1eP5QGefi2DMPTfTL5SLmv7DivfNa", "chain": "bitcoin", "list_type": "allowlist", "reason": "treasury", "author": "someone-else"}`
+	rec := serveAs(testAdminKey, "/admin/watchlist", handlers.CreateWatchlistEntry, http.MethodPost, "/admin/watchlist", body)
+	if rec.Code != http.StatusCreated {
+		t.Fatalf("status = %d, want %d: %s", rec.Code, http.StatusCreated, rec.Body.String())
+	}
+
+	var response WatchlistEntryResponse
+	if err := json.NewDecoder(rec.Body).Decode(&response); err != nil {
+		t.Fatalf("failed to decode response: %v", err)
+	}
+	if response.Author != testAdminKey.ID {
+		t.Errorf("Author = %q, want the caller's key id %q", response.Author, testAdminKey.ID)
+	}
+}
@@ -0,0 +1,119 @@
+package http
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/Beka01247/bitpanda-aml/internal/application"
+	"github.com/Beka01247/bitpanda-aml/internal/domain"
+	"github.com/go-chi/chi"
+	"go.uber.org/zap"
+)
+
+type WebhookHandlers struct {
+	webhooksUseCase *application.DeliverWebhooksUseCase
+	logger          *zap.SugaredLogger
+}
+
+func NewWebhookHandlers(
+	webhooksUseCase *application.DeliverWebhooksUseCase,
+	logger *zap.SugaredLogger,
+) *WebhookHandlers {
+	return &WebhookHandlers{
+		webhooksUseCase: webhooksUseCase,
+		logger:          logger,
+	}
+}
+
+// ListWebhookDeliveries handles GET /v1/webhooks/deliveries
+//
+//	@Summary		List webhook deliveries
+//	@Description	Delivery log of callback attempts, newest first
+//	@Tags			webhooks
+//	@Produce		json
+//	@Param			check_id	query		string	false	"Check ID"
+//	@Param			status		query		string	false	"pending, delivered or failed"
+//	@Success		200			{array}		WebhookDeliveryResponse
+//	@Failure		400			{object}	ErrorResponse
+//	@Failure		500			{object}	ErrorResponse
+//	@Security		ApiKeyAuth
+//	@Router			/webhooks/deliveries [get]
+func (h *WebhookHandlers) ListWebhookDeliveries(w http.ResponseWriter, r *http.Request) {
+	filter := domain.WebhookDeliveryFilter{
+		CheckID: r.URL.Query().Get("check_id"),
+	}
+
+	if status := r.URL.Query().Get("status"); status != "" {
+		parsed, err := domain.ParseWebhookDeliveryStatus(status)
+		if err != nil {
+			respondError(w, http.StatusBadRequest, err.Error())
+			return
+		}
+		filter.Status = parsed
+	}
+
+	deliveries, err := h.webhooksUseCase.List(r.Context(), tenantID(r), filter)
+	if err != nil {
+		h.respondUseCaseError(w, err)
+		return
+	}
+
+	response := make([]WebhookDeliveryResponse, 0, len(deliveries))
+	for _, delivery := range deliveries {
+		response = append(response, ToWebhookDeliveryResponse(delivery))
+	}
+
+	respondJSON(w, http.StatusOK, response)
+}
+
+// GetWebhookDelivery handles GET /v1/webhooks/deliveries/{delivery_id}
+//
+//	@Summary	Get webhook delivery
+//	@Tags		webhooks
+//	@Produce	json
+//	@Param		delivery_id	path		string	true	"Delivery ID"
+//	@Success	200			{object}	WebhookDeliveryResponse
+//	@Failure	404			{object}	ErrorResponse
+//	@Security	ApiKeyAuth
+//	@Router		/webhooks/deliveries/{delivery_id} [get]
+func (h *WebhookHandlers) GetWebhookDelivery(w http.ResponseWriter, r *http.Request) {
+	delivery, err := h.webhooksUseCase.Get(r.Context(), tenantID(r), chi.URLParam(r, "delivery_id"))
+	if err != nil {
+		h.respondUseCaseError(w, err)
+		return
+	}
+
+	respondJSON(w, http.StatusOK, ToWebhookDeliveryResponse(delivery))
+}
+
+// RedeliverWebhook handles POST /v1/webhooks/deliveries/{delivery_id}/redeliver
+//
+//	@Summary		Redeliver webhook
+//	@Description	Sends the stored payload again and resets the retry budget
+//	@Tags			webhooks
+//	@Produce		json
+//	@Param			delivery_id	path		string	true	"Delivery ID"
+//	@Success		200			{object}	WebhookDeliveryResponse
+//	@Failure		404			{object}	ErrorResponse
+//	@Failure		500			{object}	ErrorResponse
+//	@Security		ApiKeyAuth
+//	@Router			/webhooks/deliveries/{delivery_id}/redeliver [post]
+func (h *WebhookHandlers) RedeliverWebhook(w http.ResponseWriter, r *http.Request) {
+	delivery, err := h.webhooksUseCase.Redeliver(r.Context(), tenantID(r), chi.URLParam(r, "delivery_id"))
+	if err != nil {
+		h.respondUseCaseError(w, err)
+		return
+	}
+
+	respondJSON(w, http.StatusOK, ToWebhookDeliveryResponse(delivery))
+}
+
+func (h *WebhookHandlers) respondUseCaseError(w http.ResponseWriter, err error) {
+	if errors.Is(err, domain.ErrWebhookDeliveryNotFound) {
+		respondError(w, http.StatusNotFound, "webhook delivery not found")
+		return
+	}
+
+	h.logger.Errorw("webhook request failed", "error", err)
+	respondError(w, http.StatusInternalServerError, "webhook request failed")
+}
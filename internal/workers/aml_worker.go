@@ -81,5 +81,5 @@ func (w *AMLWorker) handleAMLCheckRequested(event *domain.Event) error {
 
 	// process check
 	ctx := context.Background()
-	return w.processUseCase.Execute(ctx, payload.CheckID, payload.Address, payload.Currency)
+	return w.processUseCase.Execute(ctx, &payload)
 }
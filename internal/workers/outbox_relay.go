@@ -0,0 +1,121 @@
+package workers
+
+import (
+	"context"
+	"time"
+
+	"github.com/Beka01247/bitpanda-aml/internal/domain"
+	"go.uber.org/zap"
+)
+
+type OutboxRelayConfig struct {
+	PollInterval    time.Duration
+	BatchSize       int
+	Lease           time.Duration
+	Retention       time.Duration
+	CleanupInterval time.Duration
+}
+
+// publishes pending outbox messages to the message bus and marks them sent
+type OutboxRelay struct {
+	outbox     domain.Outbox
+	messageBus domain.MessageBus
+	config     OutboxRelayConfig
+	logger     *zap.SugaredLogger
+	ctx        context.Context
+	cancel     context.CancelFunc
+	done       chan struct{}
+}
+
+func NewOutboxRelay(
+	outbox domain.Outbox,
+	messageBus domain.MessageBus,
+	config OutboxRelayConfig,
+	logger *zap.SugaredLogger,
+) *OutboxRelay {
+	ctx, cancel := context.WithCancel(context.Background())
+	return &OutboxRelay{
+		outbox:     outbox,
+		messageBus: messageBus,
+		config:     config,
+		logger:     logger,
+		ctx:        ctx,
+		cancel:     cancel,
+		done:       make(chan struct{}),
+	}
+}
+
+func (r *OutboxRelay) Start() {
+	r.logger.Infow("starting outbox relay", "poll_interval", r.config.PollInterval, "batch_size", r.config.BatchSize)
+
+	go r.run()
+}
+
+func (r *OutboxRelay) Stop() {
+	r.logger.Info("stopping outbox relay")
+	r.cancel()
+	<-r.done
+}
+
+func (r *OutboxRelay) run() {
+	defer close(r.done)
+
+	pollTicker := time.NewTicker(r.config.PollInterval)
+	defer pollTicker.Stop()
+
+	cleanupTicker := time.NewTicker(r.config.CleanupInterval)
+	defer cleanupTicker.Stop()
+
+	for {
+		select {
+		case <-r.ctx.Done():
+			r.logger.Info("outbox relay stopped")
+			return
+		case <-pollTicker.C:
+			// drain the backlog before waiting for the next tick
+			for r.relayBatch() == r.config.BatchSize {
+				if r.ctx.Err() != nil {
+					break
+				}
+			}
+		case <-cleanupTicker.C:
+			if _, err := r.outbox.CleanupSent(r.ctx, time.Now().UTC().Add(-r.config.Retention)); err != nil {
+				r.logger.Errorw("outbox cleanup failed", "error", err)
+			}
+		}
+	}
+}
+
+// relays one batch and returns how many messages were claimed
+func (r *OutboxRelay) relayBatch() int {
+	messages, err := r.outbox.ClaimPending(r.ctx, r.config.BatchSize, r.config.Lease)
+	if err != nil {
+		r.logger.Errorw("failed to claim outbox messages", "error", err)
+		return 0
+	}
+
+	for _, message := range messages {
+		if err := r.messageBus.Publish(r.ctx, message.RoutingKey, message.Event); err != nil {
+			r.logger.Warnw("failed to relay outbox message",
+				"message_id", message.ID,
+				"routing_key", message.RoutingKey,
+				"attempts", message.Attempts+1,
+				"error", err)
+
+			if err := r.outbox.MarkFailed(r.ctx, message.ID, err.Error()); err != nil {
+				r.logger.Errorw("failed to mark outbox message failed", "message_id", message.ID, "error", err)
+			}
+			continue
+		}
+
+		if err := r.outbox.MarkSent(r.ctx, message.ID); err != nil {
+			// the message will be relayed again once the lease expires
+			r.logger.Errorw("failed to mark outbox message sent", "message_id", message.ID, "error", err)
+			continue
+		}
+
+		r.logger.Debugw("outbox message relayed", "message_id", message.ID, "routing_key", message.RoutingKey)
+	}
+
+	return len(messages)
+}
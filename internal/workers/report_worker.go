@@ -14,6 +14,8 @@ const QueueReportJobs = "q_report_jobs"
 type ReportWorker struct {
 	generateReportUseCase    *application.GenerateReportUseCase
 	handleCheckFailedUseCase *application.HandleCheckFailedUseCase
+	manageCasesUseCase       *application.ManageCasesUseCase
+	notifier                 domain.CheckNotifier
 	messageBus               domain.MessageBus
 	logger                   *zap.SugaredLogger
 	ctx                      context.Context
@@ -23,6 +25,8 @@ type ReportWorker struct {
 func NewReportWorker(
 	generateReportUseCase *application.GenerateReportUseCase,
 	handleCheckFailedUseCase *application.HandleCheckFailedUseCase,
+	manageCasesUseCase *application.ManageCasesUseCase,
+	notifier domain.CheckNotifier,
 	messageBus domain.MessageBus,
 	logger *zap.SugaredLogger,
 ) *ReportWorker {
@@ -30,6 +34,8 @@ func NewReportWorker(
 	return &ReportWorker{
 		generateReportUseCase:    generateReportUseCase,
 		handleCheckFailedUseCase: handleCheckFailedUseCase,
+		manageCasesUseCase:       manageCasesUseCase,
+		notifier:                 notifier,
 		messageBus:               messageBus,
 		logger:                   logger,
 		ctx:                      ctx,
@@ -40,7 +46,7 @@ func NewReportWorker(
 func (w *ReportWorker) Start() error {
 	w.logger.Info("starting report worker")
 
-	routingKeys := []string{domain.EventAMLCheckCompleted, domain.EventAMLCheckFailed}
+	routingKeys := []string{domain.EventAMLCheckCompleted, domain.EventAMLCheckFailed, domain.EventAMLReportReady}
 
 	return w.messageBus.Subscribe(w.ctx, QueueReportJobs, routingKeys, w.handleMessage)
 }
@@ -64,6 +70,8 @@ func (w *ReportWorker) handleMessage(body []byte) error {
 		return w.handleAMLCheckCompleted(&event)
 	case domain.EventAMLCheckFailed:
 		return w.handleAMLCheckFailed(&event)
+	case domain.EventAMLReportReady:
+		return w.handleAMLReportReady(&event)
 	default:
 		w.logger.Warnw("unknown event type", "event_type", event.Type)
 		return nil
@@ -86,7 +94,13 @@ func (w *ReportWorker) handleAMLCheckCompleted(event *domain.Event) error {
 
 	// generate report
 	ctx := context.Background()
-	return w.generateReportUseCase.Execute(ctx, payload.CheckID, payload.RiskScore, payload.RiskLevel, payload.Categories, payload.Sanctions)
+	if err := w.generateReportUseCase.Execute(ctx, &payload); err != nil {
+		return err
+	}
+
+	// wake up requests waiting on this check
+	w.notifier.Notify(payload.CheckID, domain.StatusCompleted)
+	return nil
 }
 
 func (w *ReportWorker) handleAMLCheckFailed(event *domain.Event) error {
@@ -106,3 +120,23 @@ func (w *ReportWorker) handleAMLCheckFailed(event *domain.Event) error {
 	ctx := context.Background()
 	return w.handleCheckFailedUseCase.Execute(ctx, payload.CheckID, payload.ErrorMessage)
 }
+
+// opens a review case once the report is stored, if the check needs one
+func (w *ReportWorker) handleAMLReportReady(event *domain.Event) error {
+	// parse payload
+	payloadBytes, err := json.Marshal(event.Payload)
+	if err != nil {
+		w.logger.Errorw("failed to marshal payload", "error", err)
+		return err
+	}
+
+	var payload domain.AMLReportReadyPayload
+	if err := json.Unmarshal(payloadBytes, &payload); err != nil {
+		w.logger.Errorw("failed to unmarshal payload", "error", err)
+		return err
+	}
+
+	ctx := context.Background()
+	_, err = w.manageCasesUseCase.OpenForCheck(ctx, payload.CheckID)
+	return err
+}
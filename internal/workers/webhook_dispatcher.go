@@ -0,0 +1,107 @@
+package workers
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+
+	"github.com/Beka01247/bitpanda-aml/internal/application"
+	"github.com/Beka01247/bitpanda-aml/internal/domain"
+	"go.uber.org/zap"
+)
+
+const QueueWebhooks = "q_webhooks"
+
+// turns terminal check events into webhook deliveries and retries the ones that failed
+type WebhookDispatcher struct {
+	webhooksUseCase *application.DeliverWebhooksUseCase
+	messageBus      domain.MessageBus
+	pollInterval    time.Duration
+	logger          *zap.SugaredLogger
+	ctx             context.Context
+	cancel          context.CancelFunc
+	done            chan struct{}
+}
+
+func NewWebhookDispatcher(
+	webhooksUseCase *application.DeliverWebhooksUseCase,
+	messageBus domain.MessageBus,
+	pollInterval time.Duration,
+	logger *zap.SugaredLogger,
+) *WebhookDispatcher {
+	ctx, cancel := context.WithCancel(context.Background())
+	return &WebhookDispatcher{
+		webhooksUseCase: webhooksUseCase,
+		messageBus:      messageBus,
+		pollInterval:    pollInterval,
+		logger:          logger,
+		ctx:             ctx,
+		cancel:          cancel,
+		done:            make(chan struct{}),
+	}
+}
+
+func (d *WebhookDispatcher) Start() error {
+	d.logger.Infow("starting webhook dispatcher", "poll_interval", d.pollInterval)
+
+	routingKeys := []string{domain.EventAMLReportReady, domain.EventAMLCheckFailed}
+	if err := d.messageBus.Subscribe(d.ctx, QueueWebhooks, routingKeys, d.handleMessage); err != nil {
+		return err
+	}
+
+	go d.run()
+
+	return nil
+}
+
+func (d *WebhookDispatcher) Stop() {
+	d.logger.Info("stopping webhook dispatcher")
+	d.cancel()
+	<-d.done
+}
+
+func (d *WebhookDispatcher) run() {
+	defer close(d.done)
+
+	ticker := time.NewTicker(d.pollInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-d.ctx.Done():
+			d.logger.Info("webhook dispatcher stopped")
+			return
+		case <-ticker.C:
+			if _, err := d.webhooksUseCase.ProcessDue(d.ctx); err != nil {
+				d.logger.Errorw("failed to process webhook retries", "error", err)
+			}
+		}
+	}
+}
+
+func (d *WebhookDispatcher) handleMessage(body []byte) error {
+	var event domain.Event
+	if err := json.Unmarshal(body, &event); err != nil {
+		d.logger.Errorw("failed to unmarshal event", "error", err)
+		return err
+	}
+
+	// both aml.report.ready and aml.check.failed carry the check id
+	payloadBytes, err := json.Marshal(event.Payload)
+	if err != nil {
+		d.logger.Errorw("failed to marshal payload", "error", err)
+		return err
+	}
+
+	var payload struct {
+		CheckID      string `json:"check_id"`
+		ErrorMessage string `json:"error_message"`
+	}
+	if err := json.Unmarshal(payloadBytes, &payload); err != nil {
+		d.logger.Errorw("failed to unmarshal payload", "error", err)
+		return err
+	}
+
+	ctx := context.Background()
+	return d.webhooksUseCase.Enqueue(ctx, payload.CheckID, event.Type, payload.ErrorMessage)
+}